@@ -24,6 +24,7 @@ var (
 // Global flags
 var verboseFlag bool
 var bannerFlag bool
+var noColorFlag bool
 
 // formatListWithOr formats a list of strings with commas and "or" before the last item
 // Example: ["a", "b", "c"] -> "a, b, or c"
@@ -92,6 +93,7 @@ Common Tasks:
 For detailed help on any command, use:
   gh aw [command] --help`,
 	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		console.SetNoColor(noColorFlag)
 		if bannerFlag {
 			console.PrintBanner()
 		}
@@ -249,7 +251,26 @@ Examples:
   ` + string(constants.CLIExtensionPrefix) + ` compile --watch ci-doctor     # Watch and auto-compile
   ` + string(constants.CLIExtensionPrefix) + ` compile --trial --logical-repo owner/repo  # Compile for trial mode
   ` + string(constants.CLIExtensionPrefix) + ` compile --dependabot        # Generate Dependabot manifests
-  ` + string(constants.CLIExtensionPrefix) + ` compile --dependabot --force  # Force overwrite existing dependabot.yml`,
+  ` + string(constants.CLIExtensionPrefix) + ` compile --dependabot --force  # Force overwrite existing dependabot.yml
+  ` + string(constants.CLIExtensionPrefix) + ` compile --manifest manifest.json  # Write a JSON compile manifest
+  ` + string(constants.CLIExtensionPrefix) + ` compile --cache-dir .cache/gh-aw  # Skip recompiling unchanged workflows
+  ` + string(constants.CLIExtensionPrefix) + ` compile --actionlint --summary-on-issues-only  # Hide the actionlint summary on clean runs
+  ` + string(constants.CLIExtensionPrefix) + ` compile --lock-suffix .gen.yml  # Write workflow.gen.yml instead of workflow.lock.yml
+  ` + string(constants.CLIExtensionPrefix) + ` compile --actionlint --sarif actionlint.sarif  # Also write actionlint results as SARIF
+  ` + string(constants.CLIExtensionPrefix) + ` compile --actionlint --format json  # Print actionlint results as JSON
+  ` + string(constants.CLIExtensionPrefix) + ` compile --actionlint --filter-kind shellcheck  # Only show shellcheck findings
+  ` + string(constants.CLIExtensionPrefix) + ` compile --actionlint --fail-on warning  # Fail on actionlint errors or warnings
+  ` + string(constants.CLIExtensionPrefix) + ` compile --actionlint --jobs 4  # Run up to 4 actionlint containers at once
+  ` + string(constants.CLIExtensionPrefix) + ` compile --actionlint --actionlint-version 1.7.7  # Pin the actionlint release
+  ` + string(constants.CLIExtensionPrefix) + ` compile --actionlint --annotations  # Write findings as GitHub Actions annotations
+  ` + string(constants.CLIExtensionPrefix) + ` compile --actionlint --changed-only --base-ref origin/main  # Lint only changed workflows
+  ` + string(constants.CLIExtensionPrefix) + ` compile --actionlint --write-baseline .github/actionlint-baseline.json  # Record current findings
+  ` + string(constants.CLIExtensionPrefix) + ` compile --actionlint --baseline .github/actionlint-baseline.json  # Report only new findings
+  ` + string(constants.CLIExtensionPrefix) + ` compile --actionlint --severity shellcheck=warning --fail-on error  # Treat shellcheck as advisory
+  ` + string(constants.CLIExtensionPrefix) + ` compile --actionlint --dedupe  # Report shared-snippet findings once
+  ` + string(constants.CLIExtensionPrefix) + ` compile --actionlint --actionlint-path /usr/local/bin/actionlint  # Run actionlint without Docker
+  ` + string(constants.CLIExtensionPrefix) + ` compile --check-secrets-exist  # Warn about secrets missing from the repository
+  ` + string(constants.CLIExtensionPrefix) + ` compile --secret-naming permissive  # Accept lowercase secret names`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		engineOverride, _ := cmd.Flags().GetString("engine")
 		actionMode, _ := cmd.Flags().GetString("action-mode")
@@ -267,6 +288,8 @@ Examples:
 		forceOverwrite, _ := cmd.Flags().GetBool("force")
 		refreshStopTime, _ := cmd.Flags().GetBool("refresh-stop-time")
 		forceRefreshActionPins, _ := cmd.Flags().GetBool("force-refresh-action-pins")
+		checkSecretsExist, _ := cmd.Flags().GetBool("check-secrets-exist")
+		secretNaming, _ := cmd.Flags().GetString("secret-naming")
 		zizmor, _ := cmd.Flags().GetBool("zizmor")
 		poutine, _ := cmd.Flags().GetBool("poutine")
 		actionlint, _ := cmd.Flags().GetBool("actionlint")
@@ -275,6 +298,29 @@ Examples:
 		stats, _ := cmd.Flags().GetBool("stats")
 		failFast, _ := cmd.Flags().GetBool("fail-fast")
 		noCheckUpdate, _ := cmd.Flags().GetBool("no-check-update")
+		manifestPath, _ := cmd.Flags().GetString("manifest")
+		cacheDir, _ := cmd.Flags().GetString("cache-dir")
+		summaryOnIssuesOnly, _ := cmd.Flags().GetBool("summary-on-issues-only")
+		lockFileSuffix, _ := cmd.Flags().GetString("lock-suffix")
+		sarifPath, _ := cmd.Flags().GetString("sarif")
+		actionlintFormat, _ := cmd.Flags().GetString("format")
+		filterKinds, _ := cmd.Flags().GetStringArray("filter-kind")
+		ignoreKinds, _ := cmd.Flags().GetStringArray("ignore-kind")
+		failOn, _ := cmd.Flags().GetString("fail-on")
+		failOnHidden, _ := cmd.Flags().GetBool("fail-on-hidden")
+		actionlintJobs, _ := cmd.Flags().GetInt("jobs")
+		actionlintVersion, _ := cmd.Flags().GetString("actionlint-version")
+		actionlintAnnotations, _ := cmd.Flags().GetBool("annotations")
+		actionlintChangedOnly, _ := cmd.Flags().GetBool("changed-only")
+		actionlintBaseRef, _ := cmd.Flags().GetString("base-ref")
+		actionlintBaseline, _ := cmd.Flags().GetString("baseline")
+		actionlintWriteBaseline, _ := cmd.Flags().GetString("write-baseline")
+		actionlintSummaryTop, _ := cmd.Flags().GetInt("summary-top")
+		actionlintSummaryFull, _ := cmd.Flags().GetBool("summary-full")
+		actionlintSeverities, _ := cmd.Flags().GetStringArray("severity")
+		actionlintDedupe, _ := cmd.Flags().GetBool("dedupe")
+		actionlintPath, _ := cmd.Flags().GetString("actionlint-path")
+		actionlintPullAttempts, _ := cmd.Flags().GetInt("actionlint-pull-attempts")
 		verbose, _ := cmd.Flags().GetBool("verbose")
 		if err := validateEngine(engineOverride); err != nil {
 			return err
@@ -302,30 +348,55 @@ Examples:
 			workflowDir = workflowsDir
 		}
 		config := cli.CompileConfig{
-			MarkdownFiles:          args,
-			Verbose:                verbose,
-			EngineOverride:         engineOverride,
-			ActionMode:             actionMode,
-			ActionTag:              actionTag,
-			Validate:               validate,
-			Watch:                  watch,
-			WorkflowDir:            workflowDir,
-			SkipInstructions:       false, // Deprecated field, kept for backward compatibility
-			NoEmit:                 noEmit,
-			Purge:                  purge,
-			TrialMode:              trial,
-			TrialLogicalRepoSlug:   logicalRepo,
-			Strict:                 strict,
-			Dependabot:             dependabot,
-			ForceOverwrite:         forceOverwrite,
-			RefreshStopTime:        refreshStopTime,
-			ForceRefreshActionPins: forceRefreshActionPins,
-			Zizmor:                 zizmor,
-			Poutine:                poutine,
-			Actionlint:             actionlint,
-			JSONOutput:             jsonOutput,
-			Stats:                  stats,
-			FailFast:               failFast,
+			MarkdownFiles:           args,
+			Verbose:                 verbose,
+			EngineOverride:          engineOverride,
+			ActionMode:              actionMode,
+			ActionTag:               actionTag,
+			Validate:                validate,
+			Watch:                   watch,
+			WorkflowDir:             workflowDir,
+			SkipInstructions:        false, // Deprecated field, kept for backward compatibility
+			NoEmit:                  noEmit,
+			Purge:                   purge,
+			TrialMode:               trial,
+			TrialLogicalRepoSlug:    logicalRepo,
+			Strict:                  strict,
+			Dependabot:              dependabot,
+			ForceOverwrite:          forceOverwrite,
+			RefreshStopTime:         refreshStopTime,
+			ForceRefreshActionPins:  forceRefreshActionPins,
+			CheckSecretsExist:       checkSecretsExist,
+			SecretNaming:            secretNaming,
+			Zizmor:                  zizmor,
+			Poutine:                 poutine,
+			Actionlint:              actionlint,
+			JSONOutput:              jsonOutput,
+			Stats:                   stats,
+			FailFast:                failFast,
+			ManifestPath:            manifestPath,
+			CacheDir:                cacheDir,
+			SummaryOnIssuesOnly:     summaryOnIssuesOnly,
+			LockFileSuffix:          lockFileSuffix,
+			ActionlintSARIFPath:     sarifPath,
+			ActionlintFormat:        actionlintFormat,
+			ActionlintFilterKinds:   filterKinds,
+			ActionlintIgnoreKinds:   ignoreKinds,
+			ActionlintFailOn:        failOn,
+			ActionlintFailOnHidden:  failOnHidden,
+			ActionlintJobs:          actionlintJobs,
+			ActionlintVersion:       actionlintVersion,
+			ActionlintAnnotations:   actionlintAnnotations,
+			ActionlintChangedOnly:   actionlintChangedOnly,
+			ActionlintBaseRef:       actionlintBaseRef,
+			ActionlintBaseline:      actionlintBaseline,
+			ActionlintWriteBaseline: actionlintWriteBaseline,
+			ActionlintSummaryTop:    actionlintSummaryTop,
+			ActionlintSummaryFull:   actionlintSummaryFull,
+			ActionlintSeverities:    actionlintSeverities,
+			ActionlintDedupe:        actionlintDedupe,
+			ActionlintPath:          actionlintPath,
+			ActionlintPullAttempts:  actionlintPullAttempts,
 		}
 		if _, err := cli.CompileWorkflows(cmd.Context(), config); err != nil {
 			// Return error as-is without additional formatting
@@ -466,6 +537,9 @@ func init() {
 	// Add global banner flag to root command
 	rootCmd.PersistentFlags().BoolVar(&bannerFlag, "banner", false, "Display ASCII logo banner with purple GitHub color theme")
 
+	// Add global no-color flag to root command
+	rootCmd.PersistentFlags().BoolVar(&noColorFlag, "no-color", false, "Disable colored output (also disabled when NO_COLOR is set or output is not a terminal)")
+
 	// Set output to stderr for consistency with CLI logging guidelines
 	rootCmd.SetOut(os.Stderr)
 
@@ -655,6 +729,8 @@ Use "` + string(constants.CLIExtensionPrefix) + ` help all" to show help for all
 	compileCmd.Flags().Bool("force", false, "Force overwrite of existing dependency files (e.g., dependabot.yml)")
 	compileCmd.Flags().Bool("refresh-stop-time", false, "Force regeneration of stop-after times instead of preserving existing values from lock files")
 	compileCmd.Flags().Bool("force-refresh-action-pins", false, "Force refresh of action pins by clearing the cache and resolving all action SHAs from GitHub API")
+	compileCmd.Flags().Bool("check-secrets-exist", false, "Warn about referenced secrets that are not defined in the repository or its organization (requires network access and gh authentication)")
+	compileCmd.Flags().String("secret-naming", "", "Fail on invalid referenced secret names under this naming policy: strict (uppercase letters, digits, and underscores) or permissive (any name GitHub accepts). Without it, strict violations only warn")
 	compileCmd.Flags().Bool("zizmor", false, "Run zizmor security scanner on generated .lock.yml files")
 	compileCmd.Flags().Bool("poutine", false, "Run poutine security scanner on generated .lock.yml files")
 	compileCmd.Flags().Bool("actionlint", false, "Run actionlint linter on generated .lock.yml files")
@@ -663,6 +739,29 @@ Use "` + string(constants.CLIExtensionPrefix) + ` help all" to show help for all
 	compileCmd.Flags().Bool("stats", false, "Display statistics table sorted by file size (shows jobs, steps, scripts, and shells)")
 	compileCmd.Flags().Bool("fail-fast", false, "Stop at the first validation error instead of collecting all errors")
 	compileCmd.Flags().Bool("no-check-update", false, "Skip checking for gh-aw updates")
+	compileCmd.Flags().String("manifest", "", "Write a machine-readable JSON manifest describing each compiled workflow to the given path")
+	compileCmd.Flags().String("cache-dir", "", "Reuse lock files of unchanged workflows across invocations using a persistent cache in the given directory")
+	compileCmd.Flags().Bool("summary-on-issues-only", false, "Only show the actionlint summary when actionlint reports issues (requires --actionlint)")
+	compileCmd.Flags().String("lock-suffix", "", "File suffix of generated lock files instead of .lock.yml (must end in .yml or .yaml, e.g. .gen.yml)")
+	compileCmd.Flags().String("format", "text", "Output format for actionlint results: text or json (requires --actionlint)")
+	compileCmd.Flags().StringArray("filter-kind", []string{}, "Only display actionlint errors of this kind, e.g. shellcheck (can be used multiple times, requires --actionlint)")
+	compileCmd.Flags().StringArray("ignore-kind", []string{}, "Hide actionlint errors of this kind (can be used multiple times, requires --actionlint)")
+	compileCmd.Flags().String("fail-on", "", "Exit with a nonzero status when actionlint reports findings at this severity: error, warning, or never (requires --actionlint)")
+	compileCmd.Flags().Bool("fail-on-hidden", false, "Count actionlint findings hidden by --filter-kind or --ignore-kind towards --fail-on")
+	compileCmd.Flags().Int("jobs", 0, "Maximum number of actionlint containers to run concurrently (default: GOMAXPROCS)")
+	compileCmd.Flags().String("actionlint-version", "", "actionlint release to run, e.g. 1.7.7 (overrides GH_AW_ACTIONLINT_VERSION; default: latest)")
+	compileCmd.Flags().Bool("annotations", false, "Also write actionlint findings to stdout as GitHub Actions annotations (automatic when GITHUB_ACTIONS=true)")
+	compileCmd.Flags().Bool("changed-only", false, "Only run actionlint on workflows whose source or lock file changed since --base-ref")
+	compileCmd.Flags().String("base-ref", "", "Git base ref for --changed-only (default: origin/$GITHUB_BASE_REF in pull requests)")
+	compileCmd.Flags().String("baseline", "", "Suppress the actionlint findings recorded in this baseline file, reporting only new ones")
+	compileCmd.Flags().String("write-baseline", "", "Write the current actionlint findings to this baseline file")
+	compileCmd.Flags().Int("summary-top", 0, "Number of most frequent kinds listed in the actionlint summary; the rest are collapsed (default: 10)")
+	compileCmd.Flags().Bool("summary-full", false, "List all kinds in the actionlint summary")
+	compileCmd.Flags().StringArray("severity", nil, "Override the severity of an actionlint kind as kind=error or kind=warning (can be repeated)")
+	compileCmd.Flags().String("actionlint-path", "", "Run this pre-installed actionlint binary instead of the Docker image (overrides GH_AW_ACTIONLINT_PATH; requires --actionlint)")
+	compileCmd.Flags().Int("actionlint-pull-attempts", 0, "Attempts at pulling the actionlint image when the network or registry fails (default 3; requires --actionlint)")
+	compileCmd.Flags().Bool("dedupe", false, "Collapse identical actionlint findings across workflows into a single issue (requires --actionlint)")
+	compileCmd.Flags().String("sarif", "", "Write actionlint results as a SARIF 2.1.0 log to this file, e.g. for GitHub code scanning (requires --actionlint)")
 	compileCmd.MarkFlagsMutuallyExclusive("dir", "workflows-dir")
 
 	// Register completions for compile command
@@ -717,9 +816,11 @@ Use "` + string(constants.CLIExtensionPrefix) + ` help all" to show help for all
 	upgradeCmd := cli.NewUpgradeCommand()
 	completionCmd := cli.NewCompletionCommand()
 	hashCmd := cli.NewHashCommand()
+	concurrencyCmd := cli.NewConcurrencyCommand()
 	projectCmd := cli.NewProjectCommand()
 	checksCmd := cli.NewChecksCommand()
 	validateCmd := cli.NewValidateCommand(validateEngine)
+	lintCmd := cli.NewLintCommand(validateEngine)
 
 	// Assign commands to groups
 	// Setup Commands
@@ -735,6 +836,7 @@ Use "` + string(constants.CLIExtensionPrefix) + ` help all" to show help for all
 	// Development Commands
 	compileCmd.GroupID = "development"
 	validateCmd.GroupID = "development"
+	lintCmd.GroupID = "development"
 	mcpCmd.GroupID = "development"
 	statusCmd.GroupID = "development"
 	listCmd.GroupID = "development"
@@ -757,6 +859,7 @@ Use "` + string(constants.CLIExtensionPrefix) + ` help all" to show help for all
 	prCmd.GroupID = "utilities"
 	completionCmd.GroupID = "utilities"
 	hashCmd.GroupID = "utilities"
+	concurrencyCmd.GroupID = "utilities"
 	projectCmd.GroupID = "utilities"
 
 	// version command is intentionally left without a group (common practice)
@@ -787,8 +890,10 @@ Use "` + string(constants.CLIExtensionPrefix) + ` help all" to show help for all
 	rootCmd.AddCommand(secretsCmd)
 	rootCmd.AddCommand(fixCmd)
 	rootCmd.AddCommand(validateCmd)
+	rootCmd.AddCommand(lintCmd)
 	rootCmd.AddCommand(completionCmd)
 	rootCmd.AddCommand(hashCmd)
+	rootCmd.AddCommand(concurrencyCmd)
 	rootCmd.AddCommand(projectCmd)
 
 	// Fix help flag descriptions for all subcommands to be consistent with the
@@ -2,18 +2,25 @@ package cli
 
 import (
 	"bytes"
+	"cmp"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"regexp"
+	"slices"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/github/gh-aw/pkg/console"
 	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/workflow"
+	"github.com/sourcegraph/conc/pool"
 )
 
 var actionlintLog = logger.New("cli:actionlint")
@@ -21,11 +28,114 @@ var actionlintLog = logger.New("cli:actionlint")
 // actionlintVersion caches the actionlint version to avoid repeated Docker calls
 var actionlintVersion string
 
+// actionlintVersionEnvVar pins the actionlint version when --actionlint-version is not set
+const actionlintVersionEnvVar = "GH_AW_ACTIONLINT_VERSION"
+
+// actionlintImageVersion is the pinned actionlint release; empty uses the latest image
+var actionlintImageVersion string
+
+// actionlintReleaseVersionPattern matches actionlint release versions, e.g. 1.7.7
+var actionlintReleaseVersionPattern = regexp.MustCompile(`^[0-9]+\.[0-9]+\.[0-9]+$`)
+
+// resolveActionlintImageVersion returns the actionlint release to pin from the
+// --actionlint-version flag value, falling back to GH_AW_ACTIONLINT_VERSION.
+// An empty result means no version is pinned.
+func resolveActionlintImageVersion(flagValue string) (string, error) {
+	version, source := flagValue, "--actionlint-version"
+	if version == "" {
+		version, source = os.Getenv(actionlintVersionEnvVar), actionlintVersionEnvVar
+	}
+	if version == "" {
+		return "", nil
+	}
+
+	release := strings.TrimPrefix(version, "v")
+	if !actionlintReleaseVersionPattern.MatchString(release) {
+		return "", fmt.Errorf("invalid %s value %q: must be an actionlint release version such as 1.7.7", source, version)
+	}
+	actionlintLog.Printf("Pinned actionlint version %s from %s", release, source)
+	return release, nil
+}
+
+// setActionlintImageVersion pins the actionlint release used for all Docker calls,
+// discarding the cached version if it changes
+func setActionlintImageVersion(version string) {
+	if version != actionlintImageVersion {
+		actionlintImageVersion = version
+		actionlintVersion = ""
+	}
+}
+
+// actionlintPathEnvVar selects a pre-installed actionlint binary when --actionlint-path is not set
+const actionlintPathEnvVar = "GH_AW_ACTIONLINT_PATH"
+
+// actionlintBinaryPath is the pre-installed actionlint binary to run instead of the Docker image;
+// empty runs actionlint in Docker
+var actionlintBinaryPath string
+
+// resolveActionlintBinaryPath returns the absolute path of the pre-installed actionlint binary
+// from the --actionlint-path flag value, falling back to GH_AW_ACTIONLINT_PATH. A value without
+// a path separator is looked up in PATH. An empty result means actionlint runs in Docker.
+func resolveActionlintBinaryPath(flagValue string) (string, error) {
+	path, source := flagValue, "--actionlint-path"
+	if path == "" {
+		path, source = os.Getenv(actionlintPathEnvVar), actionlintPathEnvVar
+	}
+	if path == "" {
+		return "", nil
+	}
+
+	resolved, err := exec.LookPath(path)
+	if err != nil {
+		return "", fmt.Errorf("invalid %s value %q: must be an executable actionlint binary: %w", source, path, err)
+	}
+	resolved, err = filepath.Abs(resolved)
+	if err != nil {
+		return "", fmt.Errorf("invalid %s value %q: %w", source, path, err)
+	}
+	actionlintLog.Printf("Using actionlint binary %s from %s", resolved, source)
+	return resolved, nil
+}
+
+// setActionlintBinaryPath selects the pre-installed actionlint binary used instead of Docker,
+// discarding the cached version if it changes
+func setActionlintBinaryPath(path string) {
+	if path != actionlintBinaryPath {
+		actionlintBinaryPath = path
+		actionlintVersion = ""
+	}
+}
+
+// actionlintImage returns the actionlint Docker image, pinned to actionlintImageVersion when set
+func actionlintImage() string {
+	if actionlintImageVersion == "" {
+		return ActionlintImage
+	}
+	return "rhysd/actionlint:" + actionlintImageVersion
+}
+
+// actionlintDefaultDocsURL is the actionlint documentation of the checks
+const actionlintDefaultDocsURL = "https://github.com/rhysd/actionlint/blob/main/docs/checks.md"
+
+// actionlintDocsURLEnvVar overrides the actionlint checks documentation URL, e.g. with an
+// internal mirror on air-gapped GitHub Enterprise installations
+const actionlintDocsURLEnvVar = "GH_AW_ACTIONLINT_DOCS_URL"
+
+// actionlintDocsBaseURL returns the URL of the actionlint checks documentation
+func actionlintDocsBaseURL() string {
+	if baseURL := strings.TrimSpace(os.Getenv(actionlintDocsURLEnvVar)); baseURL != "" {
+		return baseURL
+	}
+	return actionlintDefaultDocsURL
+}
+
 // getActionlintDocsURL returns the documentation URL for a given actionlint error kind
-// Error kinds map to documentation anchors at https://github.com/rhysd/actionlint/blob/main/docs/checks.md
+// Error kinds map to documentation anchors at https://github.com/rhysd/actionlint/blob/main/docs/checks.md,
+// or at the same anchors of the page set by GH_AW_ACTIONLINT_DOCS_URL
 func getActionlintDocsURL(kind string) string {
+	baseURL := actionlintDocsBaseURL()
 	if kind == "" {
-		return "https://github.com/rhysd/actionlint/blob/main/docs/checks.md"
+		return baseURL
 	}
 
 	// Map error kind to documentation anchor
@@ -51,7 +161,7 @@ func getActionlintDocsURL(kind string) string {
 		}
 	}
 
-	return "https://github.com/rhysd/actionlint/blob/main/docs/checks.md#" + anchor
+	return baseURL + "#" + anchor
 }
 
 // actionlintStats tracks aggregate statistics across all actionlint validations
@@ -59,15 +169,209 @@ var actionlintStats *ActionlintStats
 
 // ActionlintStats tracks actionlint validation statistics across all files
 type ActionlintStats struct {
-	TotalWorkflows    int
-	TotalErrors       int
-	TotalWarnings     int
-	IntegrationErrors int // counts tooling/subprocess failures, not lint findings
-	ErrorsByKind      map[string]int
+	TotalWorkflows     int                            `json:"total_workflows"`
+	TotalErrors        int                            `json:"total_errors"`
+	TotalWarnings      int                            `json:"total_warnings"`
+	IntegrationErrors  int                            `json:"integration_errors"`  // counts tooling/subprocess failures, not lint findings
+	ErrorsByKind       map[string]int                 `json:"errors_by_kind"`      // displayed errors, by kind
+	TotalSuppressed    int                            `json:"total_suppressed"`    // errors hidden by --filter-kind or --ignore-kind
+	SuppressedByKind   map[string]int                 `json:"suppressed_by_kind"`  // hidden errors, by kind
+	IssuesByFile       map[string]ActionlintFileStats `json:"issues_by_file"`      // displayed errors and warnings, by file
+	TotalIgnored       int                            `json:"total_ignored"`       // errors suppressed by actionlint.ignore in workflow frontmatter
+	IgnoredByKind      map[string]int                 `json:"ignored_by_kind"`     // suppressed errors, by kind
+	BaselineSuppressed int                            `json:"baseline_suppressed"` // errors suppressed by --baseline
+	NewSinceBaseline   int                            `json:"new_since_baseline"`  // errors not in the --baseline
+	RawErrors          int                            `json:"raw_errors"`          // errors including those collapsed by --dedupe
+	RawWarnings        int                            `json:"raw_warnings"`        // warnings including those collapsed by --dedupe
+
+	findings    []ActionlintError    // parsed errors from all runs, for SARIF and JSON output
+	parseErrors []error              // actionlint outputs that could not be parsed
+	jsonFormat  bool                 // results are printed as JSON at the end instead of as text
+	kindFilter  actionlintKindFilter // kinds to display, from --filter-kind and --ignore-kind
+	annotations bool                 // displayed errors are also written as GitHub Actions annotations
+
+	suppressedErrors   int // hidden errors, for --fail-on
+	suppressedWarnings int // hidden warnings, for --fail-on
+
+	sourceMaps   map[string]*workflow.SourceMap // lock file source maps, keyed by absolute lock file path
+	ignoredKinds map[string][]string            // actionlint.ignore kinds, keyed by absolute lock file path
+	runnerLabels []string                       // custom self-hosted runner labels declared by the workflows
+
+	baseline        map[ActionlintBaselineEntry]int // remaining matches of the --baseline entries; nil without a baseline
+	baselineEntries []ActionlintBaselineEntry       // baseline entries of all errors, for --write-baseline
+
+	summaryTop int                  // number of kinds listed in each summary breakdown; 0 lists all kinds
+	severities actionlintSeverities // severity overrides from --severity
+
+	pullAttempts int // attempts at pulling the actionlint image, from --actionlint-pull-attempts
+
+	dedupe     bool                             // collapse identical findings across files
+	duplicates map[actionlintDedupeKey][]string // locations of the collapsed findings, by the finding they duplicate
+}
+
+// actionlintDisplayOptions controls how parsed actionlint errors are displayed
+type actionlintDisplayOptions struct {
+	filter      actionlintKindFilter           // kinds to display
+	sourceMaps  map[string]*workflow.SourceMap // lock file source maps, keyed by absolute lock file path
+	annotations bool                           // also write GitHub Actions annotations to stdout
+	severities  actionlintSeverities           // severity overrides from --severity
+	stats       *ActionlintStats               // run statistics, for the locations collapsed by --dedupe
+}
+
+// addSourceMap records the source map of a compiled lock file so that actionlint errors
+// in it can be displayed at their markdown location
+func (s *ActionlintStats) addSourceMap(lockFile string, sourceMap *workflow.SourceMap) {
+	if s == nil || sourceMap == nil {
+		return
+	}
+	absPath, err := filepath.Abs(lockFile)
+	if err != nil {
+		return
+	}
+	if s.sourceMaps == nil {
+		s.sourceMaps = make(map[string]*workflow.SourceMap)
+	}
+	s.sourceMaps[absPath] = sourceMap
+}
+
+// addIgnoredKinds records the actionlint.ignore kinds from the frontmatter of the workflow
+// compiled to lockFile, so that errors of those kinds in it are suppressed
+func (s *ActionlintStats) addIgnoredKinds(lockFile string, kinds []string) {
+	if s == nil || len(kinds) == 0 {
+		return
+	}
+	absPath, err := filepath.Abs(lockFile)
+	if err != nil {
+		return
+	}
+	if s.ignoredKinds == nil {
+		s.ignoredKinds = make(map[string][]string)
+	}
+	s.ignoredKinds[absPath] = kinds
+}
+
+// dropIgnoredFindings removes the errors whose kind is ignored by the frontmatter of their
+// workflow, counting them as ignored. lockFiles maps the paths reported by actionlint to
+// the lock file paths the ignored kinds were recorded for.
+func (s *ActionlintStats) dropIgnoredFindings(findings []ActionlintError, lockFiles map[string]string) []ActionlintError {
+	if s == nil || len(s.ignoredKinds) == 0 {
+		return findings
+	}
+
+	var kept []ActionlintError
+	for _, finding := range findings {
+		lockFile, ok := lockFiles[finding.Filepath]
+		if !ok {
+			lockFile = finding.Filepath
+		}
+		absPath, err := filepath.Abs(lockFile)
+		if err == nil && finding.Kind != "" && slices.Contains(s.ignoredKinds[absPath], finding.Kind) {
+			s.TotalIgnored++
+			s.IgnoredByKind[finding.Kind]++
+			continue
+		}
+		kept = append(kept, finding)
+	}
+	return kept
+}
+
+// sourceMapFor returns the source map for the lock file at path, or nil if there is none
+func (o actionlintDisplayOptions) sourceMapFor(path string) *workflow.SourceMap {
+	if len(o.sourceMaps) == 0 {
+		return nil
+	}
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil
+	}
+	return o.sourceMaps[absPath]
+}
+
+// actionlintDefaultSummaryTop is the number of kinds listed in each summary breakdown
+// unless --summary-top or --summary-full is set
+const actionlintDefaultSummaryTop = 10
+
+// actionlintSummaryTop returns the number of kinds to list in each summary breakdown for
+// the --summary-top and --summary-full flags; 0 lists all kinds
+func actionlintSummaryTop(top int, full bool) int {
+	if full {
+		return 0
+	}
+	if top > 0 {
+		return top
+	}
+	return actionlintDefaultSummaryTop
+}
+
+// Values accepted by --fail-on
+const (
+	actionlintFailOnError   = "error"
+	actionlintFailOnWarning = "warning"
+	actionlintFailOnNever   = "never"
+)
+
+// isActionlintWarningKind reports whether errors of the given kind are warnings.
+// Most actionlint errors are actual errors, not warnings.
+func isActionlintWarningKind(kind string) bool {
+	return strings.Contains(strings.ToLower(kind), "warning")
 }
 
-// actionlintError represents a single error from actionlint JSON output
-type actionlintError struct {
+// Severities accepted by --severity
+const (
+	actionlintSeverityError   = "error"
+	actionlintSeverityWarning = "warning"
+)
+
+// actionlintSeverities maps kinds to a severity, overriding their default classification
+type actionlintSeverities map[string]string
+
+// parseActionlintSeverities parses --severity values of the form kind=error or kind=warning
+func parseActionlintSeverities(values []string) (actionlintSeverities, error) {
+	severities := make(actionlintSeverities, len(values))
+	for _, value := range values {
+		kind, severity, ok := strings.Cut(value, "=")
+		if !ok || kind == "" {
+			return nil, fmt.Errorf("invalid --severity value %q: must be kind=error or kind=warning", value)
+		}
+		if severity != actionlintSeverityError && severity != actionlintSeverityWarning {
+			return nil, fmt.Errorf("invalid --severity value %q: severity must be 'error' or 'warning'", value)
+		}
+		severities[kind] = severity
+	}
+	return severities, nil
+}
+
+// isWarning reports whether errors of the given kind are warnings, honoring the overrides
+func (s actionlintSeverities) isWarning(kind string) bool {
+	if severity, ok := s[kind]; ok {
+		return severity == actionlintSeverityWarning
+	}
+	return isActionlintWarningKind(kind)
+}
+
+// ActionlintFileStats counts the actionlint errors and warnings reported for one file
+type ActionlintFileStats struct {
+	Errors   int `json:"errors"`
+	Warnings int `json:"warnings"`
+}
+
+// actionlintKindFilter selects which actionlint error kinds are displayed
+type actionlintKindFilter struct {
+	only   []string // display only these kinds (all kinds when empty)
+	ignore []string // never display these kinds
+}
+
+// shows reports whether errors of the given kind should be displayed
+func (f actionlintKindFilter) shows(kind string) bool {
+	if len(f.only) > 0 && !slices.Contains(f.only, kind) {
+		return false
+	}
+	return !slices.Contains(f.ignore, kind)
+}
+
+// ActionlintError represents a single error from actionlint JSON output. Filepath is the
+// lock file path as reported by actionlint, relative to the repository root.
+type ActionlintError struct {
 	Message   string `json:"message"`
 	Filepath  string `json:"filepath"`
 	Line      int    `json:"line"`
@@ -80,8 +384,111 @@ type actionlintError struct {
 // initActionlintStats initializes the global actionlint statistics tracker
 func initActionlintStats() {
 	actionlintStats = &ActionlintStats{
-		ErrorsByKind: make(map[string]int),
+		ErrorsByKind:     make(map[string]int),
+		SuppressedByKind: make(map[string]int),
+		IssuesByFile:     make(map[string]ActionlintFileStats),
+		IgnoredByKind:    make(map[string]int),
+	}
+}
+
+// recordFindings adds parsed actionlint errors to the statistics. All errors count
+// towards the totals, as errors or warnings depending on their kind; errors hidden by
+// the kind filter are tracked separately from the displayed errors, which are counted
+// by kind and by file, so the summary can report them.
+func (s *ActionlintStats) recordFindings(findings []ActionlintError) {
+	s.findings = append(s.findings, findings...)
+	for _, finding := range findings {
+		warning := s.severities.isWarning(finding.Kind)
+		if warning {
+			s.TotalWarnings++
+			s.RawWarnings++
+		} else {
+			s.TotalErrors++
+			s.RawErrors++
+		}
+
+		if !s.kindFilter.shows(finding.Kind) {
+			s.TotalSuppressed++
+			if warning {
+				s.suppressedWarnings++
+			} else {
+				s.suppressedErrors++
+			}
+			if finding.Kind != "" {
+				s.SuppressedByKind[finding.Kind]++
+			}
+			continue
+		}
+
+		if finding.Kind != "" {
+			s.ErrorsByKind[finding.Kind]++
+		}
+		fileStats := s.IssuesByFile[finding.Filepath]
+		if warning {
+			fileStats.Warnings++
+		} else {
+			fileStats.Errors++
+		}
+		s.IssuesByFile[finding.Filepath] = fileStats
+	}
+}
+
+// checkFailOn returns an error if the findings reach the severity of the --fail-on
+// policy: "error" fails on errors, "warning" on errors or warnings, and "never" (or an
+// empty policy) never fails. Findings hidden by --filter-kind or --ignore-kind are not
+// counted unless includeHidden is set.
+func (s *ActionlintStats) checkFailOn(policy string, includeHidden bool) error {
+	if s == nil {
+		return nil
+	}
+
+	errorCount, warningCount := s.TotalErrors, s.TotalWarnings
+	if !includeHidden {
+		errorCount -= s.suppressedErrors
+		warningCount -= s.suppressedWarnings
+	}
+	actionlintLog.Printf("Checking --fail-on %q: errors=%d, warnings=%d, includeHidden=%t", policy, errorCount, warningCount, includeHidden)
+
+	switch policy {
+	case actionlintFailOnError:
+		if errorCount > 0 {
+			return fmt.Errorf("actionlint found %d error(s) (--fail-on error)", errorCount)
+		}
+	case actionlintFailOnWarning:
+		if errorCount > 0 || warningCount > 0 {
+			return fmt.Errorf("actionlint found %d error(s) and %d warning(s) (--fail-on warning)", errorCount, warningCount)
+		}
 	}
+	return nil
+}
+
+// countShownErrors returns the number of findings that are errors under the --severity
+// reclassification and are not hidden by the kind filter, the same errors checkFailOn
+// counts for --fail-on error.
+func (s *ActionlintStats) countShownErrors(findings []ActionlintError) int {
+	var severities actionlintSeverities
+	var filter actionlintKindFilter
+	if s != nil {
+		severities, filter = s.severities, s.kindFilter
+	}
+
+	count := 0
+	for _, finding := range findings {
+		if !severities.isWarning(finding.Kind) && filter.shows(finding.Kind) {
+			count++
+		}
+	}
+	return count
+}
+
+// hasFindings reports whether actionlint reported any errors or warnings, or failed to run.
+// Integration failures count as findings so that a broken actionlint setup is never
+// mistaken for a clean run.
+func (s *ActionlintStats) hasFindings() bool {
+	if s == nil {
+		return false
+	}
+	return s.TotalErrors > 0 || s.TotalWarnings > 0 || s.IntegrationErrors > 0
 }
 
 // displayActionlintSummary displays aggregate statistics for all actionlint validations
@@ -117,8 +524,16 @@ func displayActionlintSummary() {
 		// Break down by error kind if we have multiple kinds
 		if len(actionlintStats.ErrorsByKind) > 0 {
 			fmt.Fprintf(os.Stderr, "\n%s\n", console.FormatInfoMessage("Issues by type:"))
-			for kind, count := range actionlintStats.ErrorsByKind {
-				fmt.Fprintf(os.Stderr, "  • %s: %d\n", kind, count)
+			displayActionlintKindBreakdown(actionlintStats.ErrorsByKind, actionlintStats.summaryTop)
+		}
+
+		// Break down by file, worst offenders first
+		if len(actionlintStats.IssuesByFile) > 0 {
+			fmt.Fprintf(os.Stderr, "\n%s\n", console.FormatInfoMessage("Issues by file:"))
+			for _, file := range sortedActionlintIssueFiles(actionlintStats.IssuesByFile) {
+				fileStats := actionlintStats.IssuesByFile[file]
+				fmt.Fprintf(os.Stderr, "  • %s: %d (%d error(s), %d warning(s))\n",
+					file, fileStats.Errors+fileStats.Warnings, fileStats.Errors, fileStats.Warnings)
 			}
 		}
 	} else if actionlintStats.IntegrationErrors > 0 {
@@ -133,6 +548,33 @@ func displayActionlintSummary() {
 			console.FormatSuccessMessage("No issues found"))
 	}
 
+	// Report findings collapsed by --dedupe
+	if duplicates := actionlintStats.totalDuplicates(); duplicates > 0 {
+		fmt.Fprintf(os.Stderr, "\n%s\n", console.FormatInfoMessage(
+			fmt.Sprintf("%d duplicate finding(s) collapsed by --dedupe (%d finding(s) reported by actionlint)",
+				duplicates, actionlintStats.RawErrors+actionlintStats.RawWarnings)))
+	}
+
+	// Report errors hidden by --filter-kind or --ignore-kind
+	if actionlintStats.TotalSuppressed > 0 {
+		fmt.Fprintf(os.Stderr, "\n%s\n", console.FormatInfoMessage(
+			fmt.Sprintf("%d issue(s) hidden by --filter-kind/--ignore-kind:", actionlintStats.TotalSuppressed)))
+		displayActionlintKindBreakdown(actionlintStats.SuppressedByKind, actionlintStats.summaryTop)
+	}
+
+	// Report errors suppressed by actionlint.ignore in workflow frontmatter
+	if actionlintStats.TotalIgnored > 0 {
+		fmt.Fprintf(os.Stderr, "\n%s\n", console.FormatInfoMessage(
+			fmt.Sprintf("%d issue(s) suppressed by actionlint.ignore in workflow frontmatter:", actionlintStats.TotalIgnored)))
+		displayActionlintKindBreakdown(actionlintStats.IgnoredByKind, actionlintStats.summaryTop)
+	}
+
+	// Report errors accepted by --baseline, and those that are new since
+	if actionlintStats.baseline != nil {
+		fmt.Fprintf(os.Stderr, "\n%s\n", console.FormatInfoMessage(
+			fmt.Sprintf("%d pre-existing issue(s) suppressed by baseline, %d new issue(s)", actionlintStats.BaselineSuppressed, actionlintStats.NewSinceBaseline)))
+	}
+
 	// Report any integration failures alongside lint findings
 	if totalIssues > 0 && actionlintStats.IntegrationErrors > 0 {
 		msg := fmt.Sprintf("%d actionlint invocation(s) also failed with tooling errors (not workflow validation failures)",
@@ -143,7 +585,58 @@ func displayActionlintSummary() {
 	fmt.Fprintf(os.Stderr, "\n%s\n", separator)
 }
 
-// getActionlintVersion fetches and caches the actionlint version from Docker
+// displayActionlintKindBreakdown prints the counts by kind, most frequent first. With a
+// positive top, only the top most frequent kinds are listed and the rest are collapsed
+// into a single "other" line.
+func displayActionlintKindBreakdown(byKind map[string]int, top int) {
+	kinds := sortedActionlintKinds(byKind)
+	shown := kinds
+	if top > 0 && len(kinds) > top {
+		shown = kinds[:top]
+	}
+	for _, kind := range shown {
+		fmt.Fprintf(os.Stderr, "  • %s: %d\n", kind, byKind[kind])
+	}
+
+	if others := kinds[len(shown):]; len(others) > 0 {
+		otherCount := 0
+		for _, kind := range others {
+			otherCount += byKind[kind]
+		}
+		fmt.Fprintf(os.Stderr, "  • other (%d kinds): %d\n", len(others), otherCount)
+	}
+}
+
+// sortedActionlintKinds returns the kinds sorted by descending count, then by name
+func sortedActionlintKinds(byKind map[string]int) []string {
+	kinds := slices.Collect(maps.Keys(byKind))
+	slices.SortFunc(kinds, func(a, b string) int {
+		if byKind[a] != byKind[b] {
+			return byKind[b] - byKind[a]
+		}
+		return strings.Compare(a, b)
+	})
+	return kinds
+}
+
+// sortedActionlintIssueFiles returns the files sorted by descending issue count, then by name
+func sortedActionlintIssueFiles(issuesByFile map[string]ActionlintFileStats) []string {
+	files := slices.Collect(maps.Keys(issuesByFile))
+	slices.SortFunc(files, func(a, b string) int {
+		countA := issuesByFile[a].Errors + issuesByFile[a].Warnings
+		countB := issuesByFile[b].Errors + issuesByFile[b].Warnings
+		if countA != countB {
+			return countB - countA
+		}
+		return strings.Compare(a, b)
+	})
+	return files
+}
+
+// getActionlintVersion fetches and caches the actionlint version from Docker.
+// actionlint is not downloaded as a binary: it runs from the actionlintImage() Docker
+// image, which Docker pulls once, stores in its local image cache, and verifies by
+// content digest. The version is only cached in memory for the current process.
 func getActionlintVersion() (string, error) {
 	// Return cached version if already fetched
 	if actionlintVersion != "" {
@@ -161,7 +654,7 @@ func getActionlintVersion() (string, error) {
 		"docker",
 		"run",
 		"--rm",
-		"rhysd/actionlint:latest",
+		actionlintImage(),
 		"--version",
 	)
 
@@ -184,16 +677,72 @@ func getActionlintVersion() (string, error) {
 	return version, nil
 }
 
-// runActionlintOnFile runs the actionlint linter on one or more .lock.yml files using Docker
-func runActionlintOnFile(lockFiles []string, verbose bool, strict bool) error {
+// actionlintChunk is a group of lock files linted by a single actionlint invocation
+type actionlintChunk struct {
+	index     int      // position of the chunk, for deterministic error reporting
+	lockFiles []string // lock file paths as given
+	relPaths  []string // lock file paths relative to the git root
+}
+
+// actionlintChunkResult is the outcome of running actionlint on one chunk
+type actionlintChunkResult struct {
+	chunk     actionlintChunk
+	findings  []ActionlintError
+	parseErr  error  // actionlint output could not be parsed
+	rawOutput string // unparsed output, displayed when parsing fails
+	foundErr  bool   // actionlint exited with code 1, i.e. it reported errors
+	timedOut  bool   // actionlint did not finish in time; nothing was validated
+	warning   string // tooling failure to display, if actionlint failed to run
+	err       error  // timeout or tooling failure to return
+}
+
+// splitActionlintChunks splits the lock files into at most jobs chunks of similar size,
+// keeping files in order so that each chunk covers a contiguous range
+func splitActionlintChunks(lockFiles, relPaths []string, jobs int) []actionlintChunk {
+	count := min(max(jobs, 1), len(lockFiles))
+	chunks := make([]actionlintChunk, 0, count)
+	start := 0
+	for i := range count {
+		// Spread the remainder over the first chunks
+		end := start + len(lockFiles)/count
+		if i < len(lockFiles)%count {
+			end++
+		}
+		chunks = append(chunks, actionlintChunk{index: i, lockFiles: lockFiles[start:end], relPaths: relPaths[start:end]})
+		start = end
+	}
+	return chunks
+}
+
+// runActionlintOnFile runs the actionlint linter on one or more .lock.yml files using Docker,
+// or using the pre-installed binary selected by --actionlint-path.
+// The files are split into up to jobs chunks that are linted concurrently. Each chunk's parsed
+// output is merged into actionlintStats as it completes, and the findings are displayed sorted
+// by file path once all chunks are done, so the output does not depend on scheduling.
+func runActionlintOnFile(lockFiles []string, verbose bool, strict bool, jobs int) error {
 	if len(lockFiles) == 0 {
 		return nil
 	}
 
-	actionlintLog.Printf("Running actionlint on %d file(s): %v (verbose=%t, strict=%t)", len(lockFiles), lockFiles, verbose, strict)
+	actionlintLog.Printf("Running actionlint on %d file(s): %v (verbose=%t, strict=%t, jobs=%d)", len(lockFiles), lockFiles, verbose, strict, jobs)
 
-	// Display actionlint version on first use
-	if actionlintVersion == "" {
+	// Pull the actionlint image up front, so that transient network failures are retried
+	if actionlintBinaryPath == "" {
+		var attempts int
+		if actionlintStats != nil {
+			attempts = actionlintStats.pullAttempts
+		}
+		if err := pullActionlintImage(attempts, verbose); err != nil {
+			return err
+		}
+	}
+
+	// Display actionlint version on first use; a pre-installed binary is trusted as is
+	if actionlintBinaryPath != "" {
+		if verbose {
+			fmt.Fprintf(os.Stderr, "%s\n", console.FormatInfoMessage("Using actionlint binary "+actionlintBinaryPath))
+		}
+	} else if actionlintVersion == "" {
 		version, err := getActionlintVersion()
 		if err != nil {
 			// Log error but continue - version display is not critical
@@ -219,12 +768,120 @@ func runActionlintOnFile(lockFiles []string, verbose bool, strict bool) error {
 		relPaths = append(relPaths, relPath)
 	}
 
-	// Build the Docker command with JSON output for easier parsing
-	// docker run --rm -v "$(pwd)":/workdir -w /workdir rhysd/actionlint:latest -format '{{json .}}' <file1> <file2> ...
-	// Adjust timeout based on number of files (1 minute per file, minimum 5 minutes)
-	timeoutDuration := time.Duration(max(5, len(lockFiles))) * time.Minute
-	ctx, cancel := context.WithTimeout(context.Background(), timeoutDuration)
-	defer cancel()
+	// Always show that actionlint is running (regular verbosity)
+	if len(lockFiles) == 1 {
+		fmt.Fprintf(os.Stderr, "%s\n", console.FormatInfoMessage("Running actionlint (includes shellcheck & pyflakes) on "+relPaths[0]))
+	} else {
+		fmt.Fprintf(os.Stderr, "%s\n", console.FormatInfoMessage(fmt.Sprintf("Running actionlint (includes shellcheck & pyflakes) on %d files", len(lockFiles))))
+	}
+
+	// In verbose mode, also show the command that users can run directly
+	if verbose {
+		directCmd := fmt.Sprintf("docker run --rm -v \"%s:/workdir\" -w /workdir %s -format '{{json .}}' %s",
+			gitRoot, actionlintImage(), strings.Join(relPaths, " "))
+		if actionlintBinaryPath != "" {
+			directCmd = fmt.Sprintf("cd \"%s\" && \"%s\" -format '{{json .}}' %s", gitRoot, actionlintBinaryPath, strings.Join(relPaths, " "))
+		}
+		fmt.Fprintf(os.Stderr, "%s\n", console.FormatInfoMessage("Run actionlint directly: "+directCmd))
+	}
+
+	// Generate a config listing the custom self-hosted runner labels, so that they are
+	// not reported as unknown; without it, actionlint still runs with the repository config
+	var configDir string
+	if actionlintStats != nil && len(actionlintStats.runnerLabels) > 0 {
+		configDir, err = writeActionlintConfig(gitRoot, actionlintStats.runnerLabels)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, console.FormatWarningMessage("Could not generate actionlint config for self-hosted runner labels: "+err.Error()))
+		} else {
+			defer os.RemoveAll(configDir)
+			if verbose {
+				fmt.Fprintf(os.Stderr, "%s\n", console.FormatInfoMessage("Passing self-hosted runner labels to actionlint: "+strings.Join(actionlintStats.runnerLabels, ", ")))
+			}
+		}
+	}
+
+	// Lint the chunks concurrently, merging each result into the statistics under the mutex
+	chunks := splitActionlintChunks(lockFiles, relPaths, jobs)
+	var mu sync.Mutex
+	p := pool.NewWithResults[actionlintChunkResult]().WithMaxGoroutines(len(chunks))
+	for _, chunk := range chunks {
+		p.Go(func() actionlintChunkResult {
+			result := runActionlintChunk(gitRoot, configDir, chunk)
+			mu.Lock()
+			defer mu.Unlock()
+			mergeActionlintChunkResult(&result)
+			return result
+		})
+	}
+	results := p.Wait()
+	slices.SortFunc(results, func(a, b actionlintChunkResult) int {
+		return a.chunk.index - b.chunk.index
+	})
+
+	// Display the findings of all chunks together, sorted by file path
+	var findings []ActionlintError
+	for _, result := range results {
+		findings = append(findings, result.findings...)
+	}
+	if actionlintStats == nil || !actionlintStats.jsonFormat {
+		var displayOptions actionlintDisplayOptions
+		if actionlintStats != nil {
+			displayOptions = actionlintDisplayOptions{
+				filter:      actionlintStats.kindFilter,
+				sourceMaps:  actionlintStats.sourceMaps,
+				annotations: actionlintStats.annotations,
+				severities:  actionlintStats.severities,
+				stats:       actionlintStats,
+			}
+		}
+		displayActionlintErrors(findings, displayOptions)
+	}
+
+	// Report timeouts and tooling failures first, in chunk order
+	for _, result := range results {
+		if result.err != nil {
+			return result.err
+		}
+	}
+
+	// actionlint uses exit code 1 when errors are found; in strict mode, errors are
+	// treated as compilation failures, otherwise they are only displayed
+	if !strict {
+		return nil
+	}
+	fileDescription := "workflows"
+	if len(lockFiles) == 1 {
+		fileDescription = filepath.Base(lockFiles[0])
+	}
+	for _, result := range results {
+		// When the output could not be parsed, no errors were counted even though
+		// actionlint signalled failures via exit code 1, so report a tooling issue
+		if result.foundErr && result.parseErr != nil {
+			return fmt.Errorf("strict mode: actionlint exited with errors on %s but output could not be parsed — this is likely a tooling or integration error", fileDescription)
+		}
+	}
+	// Errors suppressed by actionlint.ignore, reclassified as warnings by --severity, or
+	// hidden by --filter-kind and --ignore-kind do not fail strict mode
+	if errorCount := actionlintStats.countShownErrors(findings); errorCount > 0 {
+		return fmt.Errorf("strict mode: actionlint found %d errors in %s - workflows must have no actionlint errors in strict mode", errorCount, fileDescription)
+	}
+	return nil
+}
+
+// actionlintCommand builds the actionlint command with JSON output for easier parsing, run on
+// relPaths from gitRoot. It runs the pre-installed binary when one is selected, and otherwise:
+// docker run --rm -v "$(pwd)":/workdir -w /workdir rhysd/actionlint:<version> -format '{{json .}}' <file1> <file2> ...
+// If configDir is set, the actionlint config generated in it is used.
+func actionlintCommand(ctx context.Context, gitRoot, configDir string, relPaths []string) *exec.Cmd {
+	if actionlintBinaryPath != "" {
+		args := []string{"-format", "{{json .}}"}
+		if configDir != "" {
+			args = append(args, "-config-file", filepath.Join(configDir, actionlintConfigFileName))
+		}
+		cmd := exec.CommandContext(ctx, actionlintBinaryPath, append(args, relPaths...)...)
+		cmd.Dir = gitRoot
+		return cmd
+	}
 
 	// Build Docker command arguments
 	dockerArgs := []string{
@@ -232,26 +889,30 @@ func runActionlintOnFile(lockFiles []string, verbose bool, strict bool) error {
 		"--rm",
 		"-v", gitRoot + ":/workdir",
 		"-w", "/workdir",
-		"rhysd/actionlint:latest",
-		"-format", "{{json .}}",
+	}
+	if configDir != "" {
+		dockerArgs = append(dockerArgs, "-v", configDir+":"+actionlintConfigMountPath+":ro")
+	}
+	dockerArgs = append(dockerArgs, actionlintImage(), "-format", "{{json .}}")
+	if configDir != "" {
+		dockerArgs = append(dockerArgs, "-config-file", actionlintConfigMountPath+"/"+actionlintConfigFileName)
 	}
 	dockerArgs = append(dockerArgs, relPaths...)
 
-	cmd := exec.CommandContext(ctx, "docker", dockerArgs...)
+	return exec.CommandContext(ctx, "docker", dockerArgs...)
+}
 
-	// Always show that actionlint is running (regular verbosity)
-	if len(lockFiles) == 1 {
-		fmt.Fprintf(os.Stderr, "%s\n", console.FormatInfoMessage("Running actionlint (includes shellcheck & pyflakes) on "+relPaths[0]))
-	} else {
-		fmt.Fprintf(os.Stderr, "%s\n", console.FormatInfoMessage(fmt.Sprintf("Running actionlint (includes shellcheck & pyflakes) on %d files", len(lockFiles))))
-	}
+// runActionlintChunk runs actionlint on one chunk of lock files and parses its output.
+// If configDir is set, the actionlint config generated in it is used.
+func runActionlintChunk(gitRoot, configDir string, chunk actionlintChunk) actionlintChunkResult {
+	result := actionlintChunkResult{chunk: chunk}
 
-	// In verbose mode, also show the command that users can run directly
-	if verbose {
-		dockerCmd := fmt.Sprintf("docker run --rm -v \"%s:/workdir\" -w /workdir rhysd/actionlint:latest -format '{{json .}}' %s",
-			gitRoot, strings.Join(relPaths, " "))
-		fmt.Fprintf(os.Stderr, "%s\n", console.FormatInfoMessage("Run actionlint directly: "+dockerCmd))
-	}
+	// Adjust timeout based on number of files (1 minute per file, minimum 5 minutes)
+	timeoutDuration := time.Duration(max(5, len(chunk.lockFiles))) * time.Minute
+	ctx, cancel := context.WithTimeout(context.Background(), timeoutDuration)
+	defer cancel()
+
+	cmd := actionlintCommand(ctx, gitRoot, configDir, chunk.relPaths)
 
 	// Capture output
 	var stdout, stderr bytes.Buffer
@@ -259,50 +920,29 @@ func runActionlintOnFile(lockFiles []string, verbose bool, strict bool) error {
 	cmd.Stderr = &stderr
 
 	// Run the command
-	err = cmd.Run()
+	err := cmd.Run()
+
+	fileDescription := "workflows"
+	if len(chunk.lockFiles) == 1 {
+		fileDescription = filepath.Base(chunk.lockFiles[0])
+	}
 
 	// Check for timeout
 	if ctx.Err() == context.DeadlineExceeded {
 		fileList := "files"
-		if len(lockFiles) == 1 {
-			fileList = filepath.Base(lockFiles[0])
+		if len(chunk.lockFiles) == 1 {
+			fileList = fileDescription
 		}
-		if actionlintStats != nil {
-			actionlintStats.IntegrationErrors++
-		}
-		return fmt.Errorf("actionlint timed out after %d minutes on %s - this may indicate a Docker or network issue", int(timeoutDuration.Minutes()), fileList)
-	}
-
-	// Track workflows in statistics (count number of files validated)
-	if actionlintStats != nil {
-		actionlintStats.TotalWorkflows += len(lockFiles)
+		result.timedOut = true
+		result.err = fmt.Errorf("actionlint timed out after %d minutes on %s - this may indicate a Docker or network issue", int(timeoutDuration.Minutes()), fileList)
+		return result
 	}
 
-	// Parse and reformat the output, get total error count and error details
-	totalErrors, errorsByKind, parseErr := parseAndDisplayActionlintOutput(stdout.String(), verbose)
-	if parseErr != nil {
-		actionlintLog.Printf("Failed to parse actionlint output: %v", parseErr)
-		// Track this as an integration error: output was produced but could not be parsed
-		if actionlintStats != nil {
-			actionlintStats.IntegrationErrors++
-		}
-		fmt.Fprintln(os.Stderr, console.FormatWarningMessage(
-			"actionlint output could not be parsed — this is a tooling error, not a workflow validation failure: "+parseErr.Error()))
-		// Fall back to showing raw output
-		if stdout.Len() > 0 {
-			fmt.Fprint(os.Stderr, stdout.String())
-		}
-		if stderr.Len() > 0 {
-			fmt.Fprint(os.Stderr, stderr.String())
-		}
-	} else {
-		// Track error statistics
-		if actionlintStats != nil {
-			actionlintStats.TotalErrors += totalErrors
-			for kind, count := range errorsByKind {
-				actionlintStats.ErrorsByKind[kind] += count
-			}
-		}
+	// Parse the output, keeping the raw output to show if it cannot be parsed
+	result.findings, _, result.parseErr = parseActionlintOutput(stdout.String())
+	if result.parseErr != nil {
+		actionlintLog.Printf("Failed to parse actionlint output: %v", result.parseErr)
+		result.rawOutput = stdout.String() + stderr.String()
 	}
 
 	// Check if the error is due to findings (expected) or actual failure
@@ -314,80 +954,129 @@ func runActionlintOnFile(lockFiles []string, verbose bool, strict bool) error {
 		var exitErr *exec.ExitError
 		if errors.As(err, &exitErr) {
 			exitCode := exitErr.ExitCode()
-			actionlintLog.Printf("Actionlint exited with code %d, found %d errors", exitCode, totalErrors)
-			// Exit code 1 indicates errors were found
+			actionlintLog.Printf("Actionlint exited with code %d, found %d errors", exitCode, len(result.findings))
 			if exitCode == 1 {
-				// In strict mode, errors are treated as compilation failures
-				if strict {
-					fileDescription := "workflows"
-					if len(lockFiles) == 1 {
-						fileDescription = filepath.Base(lockFiles[0])
-					}
-					// When the output could not be parsed (parseErr != nil), totalErrors will be
-					// 0 even though actionlint signalled failures via exit code 1.  Produce an
-					// unambiguous message so the caller understands this is a tooling issue.
-					if parseErr != nil {
-						return fmt.Errorf("strict mode: actionlint exited with errors on %s but output could not be parsed — this is likely a tooling or integration error", fileDescription)
-					}
-					return fmt.Errorf("strict mode: actionlint found %d errors in %s - workflows must have no actionlint errors in strict mode", totalErrors, fileDescription)
-				}
-				// In non-strict mode, errors are logged but not treated as failures
-				return nil
+				result.foundErr = true
+				return result
 			}
 			// Other exit codes indicate actual tooling/subprocess failures, not lint findings.
-			fileDescription := "workflows"
-			if len(lockFiles) == 1 {
-				fileDescription = filepath.Base(lockFiles[0])
-			}
-			if actionlintStats != nil {
-				actionlintStats.IntegrationErrors++
-			}
-			fmt.Fprintln(os.Stderr, console.FormatWarningMessage(
-				fmt.Sprintf("actionlint failed with exit code %d on %s — this is a tooling error, not a workflow validation failure", exitCode, fileDescription)))
-			return fmt.Errorf("actionlint failed with exit code %d on %s", exitCode, fileDescription)
+			result.warning = fmt.Sprintf("actionlint failed with exit code %d on %s — this is a tooling error, not a workflow validation failure", exitCode, fileDescription)
+			result.err = fmt.Errorf("actionlint failed with exit code %d on %s", exitCode, fileDescription)
+			return result
 		}
 		// Non-ExitError errors (e.g., command not found) are integration/tooling failures.
+		result.warning = "actionlint could not be invoked — this is a tooling error, not a workflow validation failure: " + err.Error()
+		result.err = fmt.Errorf("actionlint failed: %w", err)
+	}
+
+	return result
+}
+
+// mergeActionlintChunkResult records the result of one chunk in actionlintStats and displays
+// its warnings. Errors suppressed by actionlint.ignore in workflow frontmatter or by the
+// baseline are removed from the result. Calls must be serialized.
+func mergeActionlintChunkResult(result *actionlintChunkResult) {
+	// A timeout is a tooling failure; no files were validated
+	if result.timedOut {
 		if actionlintStats != nil {
 			actionlintStats.IntegrationErrors++
 		}
-		fmt.Fprintln(os.Stderr, console.FormatWarningMessage(
-			"actionlint could not be invoked — this is a tooling error, not a workflow validation failure: "+err.Error()))
-		return fmt.Errorf("actionlint failed: %w", err)
+		return
 	}
 
-	return nil
-}
+	// Track workflows in statistics (count number of files validated)
+	if actionlintStats != nil {
+		actionlintStats.TotalWorkflows += len(result.chunk.lockFiles)
+	}
 
-// parseAndDisplayActionlintOutput parses actionlint JSON output and displays it in the desired format
-// Returns the total number of errors found and a breakdown by kind
-func parseAndDisplayActionlintOutput(stdout string, verbose bool) (int, map[string]int, error) {
-	// Skip if no output
-	if stdout == "" || strings.TrimSpace(stdout) == "" {
-		actionlintLog.Print("No actionlint output to parse")
-		return 0, make(map[string]int), nil
+	if result.parseErr != nil {
+		// Track this as an integration error: output was produced but could not be parsed
+		if actionlintStats != nil {
+			actionlintStats.IntegrationErrors++
+			actionlintStats.parseErrors = append(actionlintStats.parseErrors, result.parseErr)
+		}
+		fmt.Fprintln(os.Stderr, console.FormatWarningMessage(
+			"actionlint output could not be parsed — this is a tooling error, not a workflow validation failure: "+result.parseErr.Error()))
+		// Fall back to showing raw output
+		if result.rawOutput != "" {
+			fmt.Fprint(os.Stderr, result.rawOutput)
+		}
+	} else if actionlintStats != nil {
+		// Drop errors suppressed by workflow frontmatter, then track error statistics
+		lockFiles := make(map[string]string, len(result.chunk.relPaths))
+		for i, relPath := range result.chunk.relPaths {
+			lockFiles[relPath] = result.chunk.lockFiles[i]
+		}
+		result.findings = actionlintStats.dropIgnoredFindings(result.findings, lockFiles)
+		result.findings = actionlintStats.dropBaselineFindings(result.findings)
+		result.findings = actionlintStats.dropDuplicateFindings(result.findings)
+		actionlintStats.recordFindings(result.findings)
 	}
 
-	// Parse JSON errors from stdout - actionlint outputs a single JSON array
-	var errors []actionlintError
-	if err := json.Unmarshal([]byte(stdout), &errors); err != nil {
-		return 0, nil, fmt.Errorf("failed to parse actionlint JSON output: %w", err)
+	if result.warning != "" {
+		if actionlintStats != nil {
+			actionlintStats.IntegrationErrors++
+		}
+		fmt.Fprintln(os.Stderr, console.FormatWarningMessage(result.warning))
 	}
+}
 
-	totalErrors := len(errors)
-	actionlintLog.Printf("Parsed %d actionlint errors from output", totalErrors)
+// parseAndDisplayActionlintOutput parses actionlint JSON output and displays the errors whose kind
+// passes the filter in the desired format. Errors in lock files with a source map are displayed at
+// the markdown location that produced them. With opts.annotations, displayed errors are also
+// written to stdout as GitHub Actions workflow commands.
+// Returns all parsed errors and a breakdown of the displayed errors by kind
+func parseAndDisplayActionlintOutput(stdout string, verbose bool, opts actionlintDisplayOptions) ([]ActionlintError, map[string]int, error) {
+	errors, _, err := parseActionlintOutput(stdout)
+	if err != nil {
+		return nil, nil, err
+	}
+	return errors, displayActionlintErrors(errors, opts), nil
+}
 
-	// Track errors by kind
-	errorsByKind := make(map[string]int)
+// displayActionlintErrors displays the errors whose kind passes the filter, sorted by file
+// path, line, and column so that the output is deterministic
+// Returns a breakdown of the displayed errors by kind
+func displayActionlintErrors(errors []ActionlintError, opts actionlintDisplayOptions) map[string]int {
+	sorted := slices.Clone(errors)
+	slices.SortStableFunc(sorted, func(a, b ActionlintError) int {
+		return cmp.Or(
+			strings.Compare(a.Filepath, b.Filepath),
+			cmp.Compare(a.Line, b.Line),
+			cmp.Compare(a.Column, b.Column),
+		)
+	})
 
 	// Display errors using CompilerError format
-	for _, err := range errors {
-		// Track error kind
+	errorsByKind := make(map[string]int)
+	for _, err := range sorted {
+		if !opts.filter.shows(err.Kind) {
+			continue
+		}
 		if err.Kind != "" {
 			errorsByKind[err.Kind]++
 		}
 
+		// Translate the lock file location to the markdown source when possible
+		position := console.ErrorPosition{
+			File:   err.Filepath,
+			Line:   err.Line,
+			Column: err.Column,
+		}
+		var locationNote string
+		mapped := false
+		if sourceMap := opts.sourceMapFor(err.Filepath); sourceMap != nil {
+			if source, ok := sourceMap.Lookup(err.Line, err.Column); ok {
+				position = console.ErrorPosition{File: sourceMap.MarkdownPath, Line: source.Line, Column: source.Column}
+				mapped = true
+				locationNote = fmt.Sprintf("reported by actionlint at %s:%d:%d", err.Filepath, err.Line, err.Column)
+			} else {
+				locationNote = fmt.Sprintf("in code generated by gh-aw; no matching line in %s", sourceMap.MarkdownPath)
+			}
+		}
+
 		// Read file content for context display
-		fileContent, readErr := os.ReadFile(err.Filepath)
+		fileContent, readErr := os.ReadFile(position.File)
 		var fileLines []string
 		if readErr == nil {
 			fileLines = strings.Split(string(fileContent), "\n")
@@ -395,9 +1084,9 @@ func parseAndDisplayActionlintOutput(stdout string, verbose bool) (int, map[stri
 
 		// Create context lines around the error
 		var context []string
-		if len(fileLines) > 0 && err.Line > 0 && err.Line <= len(fileLines) {
-			startLine := max(1, err.Line-2)
-			endLine := min(len(fileLines), err.Line+2)
+		if len(fileLines) > 0 && position.Line > 0 && position.Line <= len(fileLines) {
+			startLine := max(1, position.Line-2)
+			endLine := min(len(fileLines), position.Line+2)
 
 			for i := startLine; i <= endLine; i++ {
 				if i-1 < len(fileLines) {
@@ -407,9 +1096,8 @@ func parseAndDisplayActionlintOutput(stdout string, verbose bool) (int, map[stri
 		}
 
 		// Map kind to error type
-		// Most actionlint errors are actual errors, not warnings
 		errorType := "error"
-		if strings.Contains(strings.ToLower(err.Kind), "warning") {
+		if opts.severities.isWarning(err.Kind) {
 			errorType = "warning"
 		}
 
@@ -419,21 +1107,93 @@ func parseAndDisplayActionlintOutput(stdout string, verbose bool) (int, map[stri
 			docsURL := getActionlintDocsURL(err.Kind)
 			message = fmt.Sprintf("[%s] %s\n\n  📖 %s", err.Kind, err.Message, docsURL)
 		}
+		if locationNote != "" {
+			message += "\n  ℹ " + locationNote
+		}
+		if duplicates := opts.stats.duplicateLocations(err); len(duplicates) > 0 {
+			message += fmt.Sprintf("\n  ℹ also reported at %d other location(s): %s", len(duplicates), strings.Join(duplicates, ", "))
+		}
 
 		// Create and format CompilerError
 		compilerErr := console.CompilerError{
-			Position: console.ErrorPosition{
-				File:   err.Filepath,
-				Line:   err.Line,
-				Column: err.Column,
-			},
-			Type:    errorType,
-			Message: message,
-			Context: context,
+			Position: position,
+			Type:     errorType,
+			Message:  message,
+			Context:  context,
 		}
 
 		fmt.Fprint(os.Stderr, console.FormatError(compilerErr))
+
+		if opts.annotations {
+			fmt.Fprintln(os.Stdout, formatActionlintAnnotation(err, position, mapped, errorType))
+		}
+	}
+
+	return errorsByKind
+}
+
+// formatActionlintAnnotation formats an actionlint error as a GitHub Actions workflow command
+// at the given display position. actionlint reports lock file paths relative to the repository
+// root already; markdown paths from a source map are made repository-relative so that the
+// annotation is attached to the file in the pull request.
+func formatActionlintAnnotation(err ActionlintError, position console.ErrorPosition, mapped bool, errorType string) string {
+	if mapped {
+		if relPath, relErr := getRepositoryRelativePath(position.File); relErr == nil {
+			position.File = relPath
+		}
+	}
+	position.File = filepath.ToSlash(position.File)
+
+	message := err.Message
+	if err.Kind != "" {
+		message = fmt.Sprintf("[%s] %s", err.Kind, err.Message)
+	}
+	return console.FormatGitHubAnnotation(console.CompilerError{Position: position, Type: errorType, Message: message})
+}
+
+// actionlintAnnotationsEnabled reports whether actionlint errors should be written as GitHub
+// Actions annotations: always with --annotations, and automatically inside GitHub Actions.
+// Annotations are written to stdout, so they are never emitted alongside JSON output.
+func actionlintAnnotationsEnabled(config CompileConfig) bool {
+	if config.JSONOutput || config.ActionlintFormat == "json" {
+		return false
+	}
+	return config.ActionlintAnnotations || os.Getenv("GITHUB_ACTIONS") == "true"
+}
+
+// ParseActionlintOutput parses the JSON output of actionlint (as produced with
+// -format '{{json .}}') without displaying it. Empty output yields no errors.
+func ParseActionlintOutput(stdout string) ([]ActionlintError, error) {
+	// Skip if no output
+	if strings.TrimSpace(stdout) == "" {
+		actionlintLog.Print("No actionlint output to parse")
+		return nil, nil
+	}
+
+	// Parse JSON errors from stdout - actionlint outputs a single JSON array
+	var errors []ActionlintError
+	if err := json.Unmarshal([]byte(stdout), &errors); err != nil {
+		return nil, fmt.Errorf("failed to parse actionlint JSON output: %w", err)
+	}
+	actionlintLog.Printf("Parsed %d actionlint errors from output", len(errors))
+	return errors, nil
+}
+
+// parseActionlintOutput parses actionlint JSON output without displaying it
+// Returns the parsed errors and a breakdown by kind
+func parseActionlintOutput(stdout string) ([]ActionlintError, map[string]int, error) {
+	errors, err := ParseActionlintOutput(stdout)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Track errors by kind
+	errorsByKind := make(map[string]int)
+	for _, err := range errors {
+		if err.Kind != "" {
+			errorsByKind[err.Kind]++
+		}
 	}
 
-	return totalErrors, errorsByKind, nil
+	return errors, errorsByKind, nil
 }
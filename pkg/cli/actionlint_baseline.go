@@ -0,0 +1,158 @@
+// This file provides baselines of accepted actionlint findings.
+//
+// A baseline records the actionlint findings of a repository at one point in time, so
+// that they can be adopted incrementally: --write-baseline writes the current findings,
+// and --baseline suppresses the findings recorded in a baseline while still reporting
+// (and failing on) new ones.
+//
+// Findings are matched by file, kind, and normalized message rather than by position,
+// so that a baseline keeps matching when unrelated edits move a finding to another line.
+// Normalization replaces the line and column numbers that some messages embed (e.g.
+// shellcheck's "SC2086:info:3:12:") with placeholders. A baseline entry matches at most
+// one finding, so a repeated finding counts as new once it occurs more often than it did
+// when the baseline was written.
+
+package cli
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var actionlintBaselineLog = logger.New("cli:actionlint_baseline")
+
+// actionlintBaselineVersion is the version of the baseline file format
+const actionlintBaselineVersion = 1
+
+// ActionlintBaseline is the file format of an actionlint baseline
+type ActionlintBaseline struct {
+	Version  int                       `json:"version"`
+	Findings []ActionlintBaselineEntry `json:"findings"`
+}
+
+// ActionlintBaselineEntry identifies one accepted finding, independently of its position
+type ActionlintBaselineEntry struct {
+	File    string `json:"file"`
+	Kind    string `json:"kind"`
+	Message string `json:"message"`
+}
+
+// actionlintBaselinePositionPattern matches line and column numbers embedded in messages
+var actionlintBaselinePositionPattern = regexp.MustCompile(`\b[0-9]+:[0-9]+\b|\bline [0-9]+\b`)
+
+// normalizeActionlintMessage replaces the line and column numbers embedded in an actionlint
+// message with placeholders and collapses whitespace
+func normalizeActionlintMessage(message string) string {
+	message = actionlintBaselinePositionPattern.ReplaceAllStringFunc(message, func(match string) string {
+		if strings.HasPrefix(match, "line ") {
+			return "line N"
+		}
+		return "N:N"
+	})
+	return strings.Join(strings.Fields(message), " ")
+}
+
+// newActionlintBaselineEntry returns the baseline entry of a finding
+func newActionlintBaselineEntry(finding ActionlintError) ActionlintBaselineEntry {
+	return ActionlintBaselineEntry{
+		File:    filepath.ToSlash(finding.Filepath),
+		Kind:    finding.Kind,
+		Message: normalizeActionlintMessage(finding.Message),
+	}
+}
+
+// loadActionlintBaseline reads the baseline at path into remaining-match counts. A missing
+// file is an error unless allowMissing is set, in which case the baseline is empty.
+func loadActionlintBaseline(path string, allowMissing bool) (map[ActionlintBaselineEntry]int, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) && allowMissing {
+		actionlintBaselineLog.Printf("Baseline %s does not exist yet, using an empty baseline", path)
+		return make(map[ActionlintBaselineEntry]int), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read actionlint baseline (create one with --write-baseline): %w", err)
+	}
+
+	var baseline ActionlintBaseline
+	if err := json.Unmarshal(data, &baseline); err != nil {
+		return nil, fmt.Errorf("failed to parse actionlint baseline %s: %w", path, err)
+	}
+	if baseline.Version != actionlintBaselineVersion {
+		return nil, fmt.Errorf("unsupported actionlint baseline version %d in %s (expected %d)", baseline.Version, path, actionlintBaselineVersion)
+	}
+
+	entries := make(map[ActionlintBaselineEntry]int, len(baseline.Findings))
+	for _, entry := range baseline.Findings {
+		entries[entry]++
+	}
+	actionlintBaselineLog.Printf("Loaded %d baseline finding(s) from %s", len(baseline.Findings), path)
+	return entries, nil
+}
+
+// dropBaselineFindings records the findings for --write-baseline and, when a baseline is
+// loaded, removes the findings it accepts, counting them as baseline issues and the rest
+// as new issues
+func (s *ActionlintStats) dropBaselineFindings(findings []ActionlintError) []ActionlintError {
+	if s == nil {
+		return findings
+	}
+	for _, finding := range findings {
+		s.baselineEntries = append(s.baselineEntries, newActionlintBaselineEntry(finding))
+	}
+	if s.baseline == nil {
+		return findings
+	}
+
+	var kept []ActionlintError
+	for _, finding := range findings {
+		entry := newActionlintBaselineEntry(finding)
+		if s.baseline[entry] > 0 {
+			s.baseline[entry]--
+			s.BaselineSuppressed++
+			continue
+		}
+		s.NewSinceBaseline++
+		kept = append(kept, finding)
+	}
+	return kept
+}
+
+// writeActionlintBaseline writes the findings collected during this run to path as a baseline
+func writeActionlintBaseline(path string, verbose bool) error {
+	baseline := ActionlintBaseline{Version: actionlintBaselineVersion, Findings: []ActionlintBaselineEntry{}}
+	if actionlintStats != nil {
+		baseline.Findings = append(baseline.Findings, actionlintStats.baselineEntries...)
+	}
+	slices.SortFunc(baseline.Findings, func(a, b ActionlintBaselineEntry) int {
+		return cmp.Or(strings.Compare(a.File, b.File), strings.Compare(a.Kind, b.Kind), strings.Compare(a.Message, b.Message))
+	})
+	actionlintBaselineLog.Printf("Writing actionlint baseline: path=%s, findings=%d", path, len(baseline.Findings))
+
+	data, err := json.MarshalIndent(baseline, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal actionlint baseline: %w", err)
+	}
+	if dir := filepath.Dir(path); dir != "" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create baseline directory: %w", err)
+		}
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write actionlint baseline: %w", err)
+	}
+
+	if verbose {
+		fmt.Fprintln(os.Stderr, console.FormatSuccessMessage(fmt.Sprintf("Wrote %d actionlint finding(s) to baseline %s", len(baseline.Findings), path)))
+	}
+	return nil
+}
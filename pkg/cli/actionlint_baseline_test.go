@@ -0,0 +1,83 @@
+//go:build !integration
+
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewActionlintBaselineEntry(t *testing.T) {
+	a := newActionlintBaselineEntry(ActionlintError{Filepath: ".github/workflows/a.lock.yml", Line: 40, Kind: "shellcheck", Message: "shellcheck reported issue in this script: SC2086:info:3:12: Double quote to prevent globbing"})
+	b := newActionlintBaselineEntry(ActionlintError{Filepath: ".github/workflows/a.lock.yml", Line: 52, Kind: "shellcheck", Message: "shellcheck reported issue in this script: SC2086:info:5:8: Double  quote to prevent globbing"})
+
+	assert.Equal(t, a, b, "entries should not depend on positions or whitespace")
+	assert.Equal(t, "shellcheck reported issue in this script: SC2086:info:N:N: Double quote to prevent globbing", a.Message, "positions should be normalized")
+}
+
+func TestActionlintBaselineRoundTrip(t *testing.T) {
+	originalStats := actionlintStats
+	defer func() { actionlintStats = originalStats }()
+	baselinePath := filepath.Join(testutil.TempDir(t, "actionlint-baseline-*"), "baseline.json")
+
+	legacy := []ActionlintError{
+		{Filepath: "a.lock.yml", Line: 10, Kind: "expression", Message: "property \"foo\" is not defined"},
+		{Filepath: "a.lock.yml", Line: 20, Kind: "expression", Message: "property \"foo\" is not defined"},
+		{Filepath: "b.lock.yml", Line: 5, Kind: "runner-label", Message: "label \"gpu\" is unknown"},
+	}
+
+	// Record the legacy findings
+	initActionlintStats()
+	actionlintStats.recordFindings(actionlintStats.dropBaselineFindings(legacy))
+	require.NoError(t, writeActionlintBaseline(baselinePath, false), "baseline should be written")
+
+	// A later run where the findings moved and a new one appeared
+	baseline, err := loadActionlintBaseline(baselinePath, false)
+	require.NoError(t, err, "baseline should load")
+	initActionlintStats()
+	actionlintStats.baseline = baseline
+	later := []ActionlintError{
+		{Filepath: "a.lock.yml", Line: 12, Kind: "expression", Message: "property \"foo\" is not defined"},
+		{Filepath: "a.lock.yml", Line: 22, Kind: "expression", Message: "property \"foo\" is not defined"},
+		{Filepath: "a.lock.yml", Line: 30, Kind: "expression", Message: "property \"foo\" is not defined"},
+		{Filepath: "b.lock.yml", Line: 9, Kind: "runner-label", Message: "label \"gpu\" is unknown"},
+	}
+	kept := actionlintStats.dropBaselineFindings(later)
+	actionlintStats.recordFindings(kept)
+
+	require.Len(t, kept, 1, "only the finding beyond the baseline should be kept")
+	assert.Equal(t, 30, kept[0].Line, "the extra repeated finding should be new")
+	assert.Equal(t, 3, actionlintStats.BaselineSuppressed, "baseline findings should be counted")
+	assert.Equal(t, 1, actionlintStats.NewSinceBaseline, "new findings should be counted")
+	require.Error(t, actionlintStats.checkFailOn(actionlintFailOnError, false), "new findings should still fail")
+
+	output := testutil.CaptureStderr(t, func() {
+		actionlintStats.TotalWorkflows = 2
+		displayActionlintSummary()
+	})
+	assert.Contains(t, output, "3 pre-existing issue(s) suppressed by baseline, 1 new issue(s)", "summary should report the baseline")
+}
+
+func TestLoadActionlintBaselineErrors(t *testing.T) {
+	dir := testutil.TempDir(t, "actionlint-baseline-*")
+	missing := filepath.Join(dir, "missing.json")
+
+	_, err := loadActionlintBaseline(missing, false)
+	require.Error(t, err, "missing baseline should fail")
+	assert.Contains(t, err.Error(), "--write-baseline", "error should explain how to create a baseline")
+
+	baseline, err := loadActionlintBaseline(missing, true)
+	require.NoError(t, err, "missing baseline should be allowed when it is about to be written")
+	assert.Empty(t, baseline, "missing baseline should be empty")
+
+	unsupported := filepath.Join(dir, "unsupported.json")
+	require.NoError(t, os.WriteFile(unsupported, []byte(`{"version":2,"findings":[]}`), 0644), "should write baseline")
+	_, err = loadActionlintBaseline(unsupported, false)
+	require.Error(t, err, "unsupported version should fail")
+	assert.Contains(t, err.Error(), "unsupported actionlint baseline version 2", "error should name the version")
+}
@@ -0,0 +1,106 @@
+// This file limits actionlint to the workflows changed relative to a git base ref.
+//
+// With --changed-only, only lock files whose markdown source or lock file differs
+// from the merge base of --base-ref and HEAD (including uncommitted changes) are
+// passed to actionlint. When no base ref is available or the changed files cannot
+// be determined, every lock file is checked and a warning explains why.
+
+package cli
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var actionlintChangedLog = logger.New("cli:actionlint_changed")
+
+// actionlintChangedFiles is the set of files changed relative to a base ref.
+// A nil set includes every workflow.
+type actionlintChangedFiles struct {
+	files map[string]bool // absolute paths
+}
+
+// resolveActionlintBaseRef returns the base ref for --changed-only: the --base-ref
+// value, or the pull request base branch when running in a GitHub Actions pull request
+func resolveActionlintBaseRef(baseRef string) string {
+	if baseRef != "" {
+		return baseRef
+	}
+	if prBase := os.Getenv("GITHUB_BASE_REF"); prBase != "" {
+		return "origin/" + prBase
+	}
+	return ""
+}
+
+// openActionlintChangedFiles returns the changed files to limit actionlint to, or nil
+// when every workflow should be checked
+func openActionlintChangedFiles(config CompileConfig) *actionlintChangedFiles {
+	if !config.ActionlintChangedOnly || !config.Actionlint || config.NoEmit {
+		return nil
+	}
+	return loadActionlintChangedFiles(config.ActionlintBaseRef)
+}
+
+// loadActionlintChangedFiles lists the files changed between the merge base of baseRef
+// and HEAD and the working tree. It returns nil, after printing a warning, when the
+// changed files cannot be determined, so that every workflow is checked.
+func loadActionlintChangedFiles(baseRef string) *actionlintChangedFiles {
+	baseRef = resolveActionlintBaseRef(baseRef)
+	if baseRef == "" {
+		fmt.Fprintln(os.Stderr, console.FormatWarningMessage("--changed-only: no base ref provided (use --base-ref); running actionlint on all workflows"))
+		return nil
+	}
+
+	gitRoot, err := findGitRoot()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, console.FormatWarningMessage("--changed-only: not in a git repository; running actionlint on all workflows"))
+		return nil
+	}
+
+	mergeBase, err := exec.Command("git", "-C", gitRoot, "merge-base", baseRef, "HEAD").Output()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("--changed-only: cannot find merge base with %s; running actionlint on all workflows", baseRef)))
+		return nil
+	}
+
+	output, err := exec.Command("git", "-C", gitRoot, "diff", "--name-only", strings.TrimSpace(string(mergeBase))).Output()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("--changed-only: cannot list files changed since %s; running actionlint on all workflows", baseRef)))
+		return nil
+	}
+
+	changed := parseActionlintChangedFiles(gitRoot, string(output))
+	actionlintChangedLog.Printf("Found %d file(s) changed since %s", len(changed.files), baseRef)
+	return changed
+}
+
+// parseActionlintChangedFiles parses `git diff --name-only` output, whose paths are
+// relative to the repository root
+func parseActionlintChangedFiles(gitRoot, output string) *actionlintChangedFiles {
+	changed := &actionlintChangedFiles{files: make(map[string]bool)}
+	for line := range strings.SplitSeq(output, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			changed.files[filepath.Join(gitRoot, filepath.FromSlash(line))] = true
+		}
+	}
+	return changed
+}
+
+// includes reports whether the workflow compiled from markdownFile to lockFile changed
+func (c *actionlintChangedFiles) includes(markdownFile, lockFile string) bool {
+	if c == nil {
+		return true
+	}
+	for _, path := range []string{markdownFile, lockFile} {
+		if absPath, err := filepath.Abs(path); err == nil && c.files[absPath] {
+			return true
+		}
+	}
+	return false
+}
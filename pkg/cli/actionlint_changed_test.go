@@ -0,0 +1,90 @@
+//go:build !integration
+
+package cli
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveActionlintBaseRef(t *testing.T) {
+	t.Setenv("GITHUB_BASE_REF", "")
+	assert.Empty(t, resolveActionlintBaseRef(""), "no base ref outside pull requests")
+	assert.Equal(t, "main", resolveActionlintBaseRef("main"), "explicit base ref")
+
+	t.Setenv("GITHUB_BASE_REF", "release")
+	assert.Equal(t, "origin/release", resolveActionlintBaseRef(""), "pull request base branch")
+	assert.Equal(t, "main", resolveActionlintBaseRef("main"), "explicit base ref wins over the pull request base")
+}
+
+func TestActionlintChangedFilesIncludes(t *testing.T) {
+	gitRoot := testutil.TempDir(t, "actionlint-changed-*")
+	changed := parseActionlintChangedFiles(gitRoot, ".github/workflows/a.md\n.github/workflows/b.lock.yml\n")
+	workflowsDir := filepath.Join(gitRoot, ".github", "workflows")
+
+	assert.True(t, changed.includes(filepath.Join(workflowsDir, "a.md"), filepath.Join(workflowsDir, "a.lock.yml")), "changed source should be included")
+	assert.True(t, changed.includes(filepath.Join(workflowsDir, "b.md"), filepath.Join(workflowsDir, "b.lock.yml")), "changed lock file should be included")
+	assert.False(t, changed.includes(filepath.Join(workflowsDir, "c.md"), filepath.Join(workflowsDir, "c.lock.yml")), "unchanged workflow should be excluded")
+
+	var all *actionlintChangedFiles
+	assert.True(t, all.includes("c.md", "c.lock.yml"), "nil set should include every workflow")
+}
+
+func TestLoadActionlintChangedFiles(t *testing.T) {
+	gitRoot := testutil.TempDir(t, "actionlint-changed-repo-*")
+	t.Chdir(gitRoot)
+	t.Setenv("GITHUB_BASE_REF", "")
+
+	git := func(args ...string) {
+		t.Helper()
+		if output, err := exec.Command("git", args...).CombinedOutput(); err != nil {
+			t.Skipf("git %v failed: %v\n%s", args, err, output)
+		}
+	}
+	git("init", "-q")
+	git("config", "user.name", "Test User")
+	git("config", "user.email", "test@example.com")
+	for _, name := range []string{"a.md", "a.lock.yml", "b.md", "b.lock.yml"} {
+		require.NoError(t, os.WriteFile(name, []byte(name), 0644), "should write %s", name)
+	}
+	git("add", ".")
+	git("commit", "-q", "-m", "base")
+	git("tag", "base")
+	require.NoError(t, os.WriteFile("a.md", []byte("changed"), 0644), "should modify a.md")
+	git("commit", "-q", "-am", "change a")
+	require.NoError(t, os.WriteFile("b.lock.yml", []byte("uncommitted"), 0644), "should modify b.lock.yml")
+
+	t.Run("with base ref", func(t *testing.T) {
+		changed := loadActionlintChangedFiles("base")
+		require.NotNil(t, changed, "changed files should be determined")
+		assert.True(t, changed.includes("a.md", "a.lock.yml"), "committed source change should be included")
+		assert.True(t, changed.includes("b.md", "b.lock.yml"), "uncommitted lock change should be included")
+	})
+
+	t.Run("unchanged workflow", func(t *testing.T) {
+		require.NoError(t, os.WriteFile("b.lock.yml", []byte("b.lock.yml"), 0644), "should restore b.lock.yml")
+		changed := loadActionlintChangedFiles("base")
+		require.NotNil(t, changed, "changed files should be determined")
+		assert.False(t, changed.includes("b.md", "b.lock.yml"), "unchanged workflow should be excluded")
+	})
+
+	t.Run("without base ref", func(t *testing.T) {
+		var changed *actionlintChangedFiles
+		output := testutil.CaptureStderr(t, func() { changed = loadActionlintChangedFiles("") })
+		assert.Nil(t, changed, "every workflow should be checked")
+		assert.Contains(t, output, "no base ref provided", "fallback should be explained")
+	})
+
+	t.Run("unknown base ref", func(t *testing.T) {
+		var changed *actionlintChangedFiles
+		output := testutil.CaptureStderr(t, func() { changed = loadActionlintChangedFiles("does-not-exist") })
+		assert.Nil(t, changed, "every workflow should be checked")
+		assert.Contains(t, output, "cannot find merge base with does-not-exist", "fallback should be explained")
+	})
+}
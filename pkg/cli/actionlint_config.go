@@ -0,0 +1,114 @@
+// This file generates the actionlint configuration for custom self-hosted runner labels.
+//
+// actionlint reports runner-label errors for runner labels it does not know unless
+// they are listed in its configuration. When compiled workflows declare custom labels
+// in runs-on, a temporary actionlint.yaml listing them is generated before actionlint
+// runs, mounted into the actionlint container, and removed afterwards. The repository's
+// own .github/actionlint.yaml (or .yml) is used as the base of the generated config, so
+// its settings still apply.
+
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/goccy/go-yaml"
+)
+
+var actionlintConfigLog = logger.New("cli:actionlint_config")
+
+// actionlintConfigMountPath is where the generated config directory is mounted in the container
+const actionlintConfigMountPath = "/gh-aw-actionlint-config"
+
+// actionlintConfigFileName is the name of the generated config file
+const actionlintConfigFileName = "actionlint.yaml"
+
+// addRunnerLabels records the custom self-hosted runner labels of a compiled workflow
+func (s *ActionlintStats) addRunnerLabels(labels []string) {
+	if s == nil {
+		return
+	}
+	for _, label := range labels {
+		if !slices.Contains(s.runnerLabels, label) {
+			s.runnerLabels = append(s.runnerLabels, label)
+		}
+	}
+}
+
+// writeActionlintConfig writes an actionlint config listing the given self-hosted runner
+// labels, based on the repository's actionlint config in gitRoot, to a new temporary
+// directory. It returns the directory, which the caller must remove, or "" when there
+// are no labels.
+func writeActionlintConfig(gitRoot string, labels []string) (string, error) {
+	if len(labels) == 0 {
+		return "", nil
+	}
+
+	config, err := readRepositoryActionlintConfig(gitRoot)
+	if err != nil {
+		return "", err
+	}
+	mergeActionlintRunnerLabels(config, labels)
+
+	data, err := yaml.Marshal(config)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal actionlint config: %w", err)
+	}
+
+	dir, err := os.MkdirTemp("", "gh-aw-actionlint-*")
+	if err != nil {
+		return "", fmt.Errorf("failed to create actionlint config directory: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, actionlintConfigFileName), data, 0644); err != nil {
+		os.RemoveAll(dir)
+		return "", fmt.Errorf("failed to write actionlint config: %w", err)
+	}
+
+	actionlintConfigLog.Printf("Wrote actionlint config with %d self-hosted runner label(s) to %s", len(labels), dir)
+	return dir, nil
+}
+
+// readRepositoryActionlintConfig reads the repository's .github/actionlint.yaml or
+// .github/actionlint.yml, returning an empty config if there is none
+func readRepositoryActionlintConfig(gitRoot string) (map[string]any, error) {
+	config := make(map[string]any)
+	for _, name := range []string{"actionlint.yaml", "actionlint.yml"} {
+		path := filepath.Join(gitRoot, ".github", name)
+		data, err := os.ReadFile(path)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		if err := yaml.Unmarshal(data, &config); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+		if config == nil {
+			config = make(map[string]any)
+		}
+		actionlintConfigLog.Printf("Using repository actionlint config %s", path)
+		return config, nil
+	}
+	return config, nil
+}
+
+// mergeActionlintRunnerLabels adds labels to the self-hosted-runner.labels list of config
+func mergeActionlintRunnerLabels(config map[string]any, labels []string) {
+	selfHosted, _ := config["self-hosted-runner"].(map[string]any)
+	if selfHosted == nil {
+		selfHosted = make(map[string]any)
+	}
+	existing, _ := selfHosted["labels"].([]any)
+	for _, label := range labels {
+		if !slices.Contains(existing, any(label)) {
+			existing = append(existing, label)
+		}
+	}
+	selfHosted["labels"] = existing
+	config["self-hosted-runner"] = selfHosted
+}
@@ -0,0 +1,56 @@
+//go:build !integration
+
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/goccy/go-yaml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteActionlintConfig(t *testing.T) {
+	t.Run("no labels", func(t *testing.T) {
+		dir, err := writeActionlintConfig(testutil.TempDir(t, "actionlint-config-*"), nil)
+		require.NoError(t, err, "no labels should not fail")
+		assert.Empty(t, dir, "no config should be generated without labels")
+	})
+
+	t.Run("merges repository config", func(t *testing.T) {
+		gitRoot := testutil.TempDir(t, "actionlint-config-*")
+		require.NoError(t, os.MkdirAll(filepath.Join(gitRoot, ".github"), 0755), "should create .github")
+		repoConfig := "self-hosted-runner:\n  labels:\n    - existing-runner\nconfig-variables:\n  - ENVIRONMENT\n"
+		require.NoError(t, os.WriteFile(filepath.Join(gitRoot, ".github", "actionlint.yml"), []byte(repoConfig), 0644), "should write repository config")
+
+		dir, err := writeActionlintConfig(gitRoot, []string{"gpu-runner", "existing-runner"})
+		require.NoError(t, err, "config should be generated")
+		require.NotEmpty(t, dir, "config directory should be returned")
+		defer os.RemoveAll(dir)
+
+		data, err := os.ReadFile(filepath.Join(dir, actionlintConfigFileName))
+		require.NoError(t, err, "config file should exist")
+		var config struct {
+			SelfHostedRunner struct {
+				Labels []string `yaml:"labels"`
+			} `yaml:"self-hosted-runner"`
+			ConfigVariables []string `yaml:"config-variables"`
+		}
+		require.NoError(t, yaml.Unmarshal(data, &config), "config should be valid YAML")
+		assert.Equal(t, []string{"existing-runner", "gpu-runner"}, config.SelfHostedRunner.Labels, "labels should be merged without duplicates")
+		assert.Equal(t, []string{"ENVIRONMENT"}, config.ConfigVariables, "repository settings should be kept")
+	})
+
+	t.Run("invalid repository config", func(t *testing.T) {
+		gitRoot := testutil.TempDir(t, "actionlint-config-*")
+		require.NoError(t, os.MkdirAll(filepath.Join(gitRoot, ".github"), 0755), "should create .github")
+		require.NoError(t, os.WriteFile(filepath.Join(gitRoot, ".github", "actionlint.yaml"), []byte("- not\n- a map\n"), 0644), "should write repository config")
+
+		_, err := writeActionlintConfig(gitRoot, []string{"gpu-runner"})
+		require.Error(t, err, "invalid repository config should fail")
+		assert.Contains(t, err.Error(), "failed to parse", "error should explain the problem")
+	})
+}
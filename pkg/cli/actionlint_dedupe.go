@@ -0,0 +1,85 @@
+// This file provides deduplication of identical actionlint findings across files.
+//
+// When the same shared snippet is inlined into several compiled workflows, actionlint
+// reports the same finding once per lock file. With --dedupe, findings with the same
+// kind and normalized message are collapsed into the first one reported, and the
+// locations of the others are listed with it. Messages are normalized as for baselines,
+// so that line and column numbers embedded in a message do not tell duplicates apart,
+// while findings with different messages are always kept apart.
+//
+// TotalErrors and TotalWarnings then count unique issues; RawErrors and RawWarnings
+// count every finding reported by actionlint.
+
+package cli
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var actionlintDedupeLog = logger.New("cli:actionlint_dedupe")
+
+// actionlintDedupeKey identifies findings that are duplicates of each other
+type actionlintDedupeKey struct {
+	kind    string
+	message string
+}
+
+// newActionlintDedupeKey returns the deduplication key of a finding
+func newActionlintDedupeKey(finding ActionlintError) actionlintDedupeKey {
+	return actionlintDedupeKey{kind: finding.Kind, message: normalizeActionlintMessage(finding.Message)}
+}
+
+// formatActionlintLocation formats the lock file location of a finding as file:line:column
+func formatActionlintLocation(finding ActionlintError) string {
+	return fmt.Sprintf("%s:%d:%d", filepath.ToSlash(finding.Filepath), finding.Line, finding.Column)
+}
+
+// dropDuplicateFindings removes, with --dedupe, the findings that duplicate a finding
+// already recorded in this run, recording their locations with the first one. The
+// removed findings are counted in RawErrors and RawWarnings only.
+func (s *ActionlintStats) dropDuplicateFindings(findings []ActionlintError) []ActionlintError {
+	if s == nil || !s.dedupe {
+		return findings
+	}
+	if s.duplicates == nil {
+		s.duplicates = make(map[actionlintDedupeKey][]string)
+	}
+
+	var kept []ActionlintError
+	for _, finding := range findings {
+		key := newActionlintDedupeKey(finding)
+		locations, seen := s.duplicates[key]
+		if !seen {
+			s.duplicates[key] = []string{}
+			kept = append(kept, finding)
+			continue
+		}
+		s.duplicates[key] = append(locations, formatActionlintLocation(finding))
+		if s.severities.isWarning(finding.Kind) {
+			s.RawWarnings++
+		} else {
+			s.RawErrors++
+		}
+	}
+	actionlintDedupeLog.Printf("Collapsed %d duplicate finding(s)", len(findings)-len(kept))
+	return kept
+}
+
+// duplicateLocations returns the locations of the findings collapsed into finding by --dedupe
+func (s *ActionlintStats) duplicateLocations(finding ActionlintError) []string {
+	if s == nil || !s.dedupe {
+		return nil
+	}
+	return s.duplicates[newActionlintDedupeKey(finding)]
+}
+
+// totalDuplicates returns the number of findings collapsed by --dedupe
+func (s *ActionlintStats) totalDuplicates() int {
+	if s == nil {
+		return 0
+	}
+	return s.RawErrors + s.RawWarnings - s.TotalErrors - s.TotalWarnings
+}
@@ -0,0 +1,76 @@
+//go:build !integration
+
+package cli
+
+import (
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewActionlintDedupeKey(t *testing.T) {
+	a := ActionlintError{Kind: "shellcheck", Message: "shellcheck reported issue in this script: SC2086:info:3:12: Double quote", Filepath: "a.lock.yml", Line: 10}
+	b := ActionlintError{Kind: "shellcheck", Message: "shellcheck reported issue in this script: SC2086:info:7:4: Double quote", Filepath: "b.lock.yml", Line: 42}
+	c := ActionlintError{Kind: "shellcheck", Message: "shellcheck reported issue in this script: SC2046:warning:3:12: Quote this", Filepath: "a.lock.yml", Line: 10}
+	d := ActionlintError{Kind: "expression", Message: a.Message}
+
+	assert.Equal(t, newActionlintDedupeKey(a), newActionlintDedupeKey(b), "line numbers should not tell findings apart")
+	assert.NotEqual(t, newActionlintDedupeKey(a), newActionlintDedupeKey(c), "distinct messages should be kept apart")
+	assert.NotEqual(t, newActionlintDedupeKey(a), newActionlintDedupeKey(d), "distinct kinds should be kept apart")
+}
+
+func TestMergeActionlintChunkResultDedupe(t *testing.T) {
+	originalStats := actionlintStats
+	defer func() { actionlintStats = originalStats }()
+	initActionlintStats()
+	actionlintStats.dedupe = true
+	actionlintStats.severities = actionlintSeverities{"expression": actionlintSeverityWarning}
+
+	result := actionlintChunkResult{
+		chunk: actionlintChunk{lockFiles: []string{"a.lock.yml", "b.lock.yml", "c.lock.yml"}, relPaths: []string{"a.lock.yml", "b.lock.yml", "c.lock.yml"}},
+		findings: []ActionlintError{
+			{Message: "SC2086:info:3:12: Double quote", Filepath: "a.lock.yml", Line: 10, Column: 9, Kind: "shellcheck"},
+			{Message: "SC2086:info:3:12: Double quote", Filepath: "b.lock.yml", Line: 20, Column: 9, Kind: "shellcheck"},
+			{Message: "SC2086:info:3:12: Double quote", Filepath: "c.lock.yml", Line: 30, Column: 9, Kind: "shellcheck"},
+			{Message: "undefined variable", Filepath: "b.lock.yml", Line: 5, Column: 3, Kind: "expression"},
+		},
+	}
+	mergeActionlintChunkResult(&result)
+
+	require.Len(t, result.findings, 2, "duplicates should be removed from the result")
+	assert.Equal(t, 1, actionlintStats.TotalErrors, "errors should count unique issues")
+	assert.Equal(t, 1, actionlintStats.TotalWarnings, "warnings should count unique issues")
+	assert.Equal(t, 3, actionlintStats.RawErrors, "raw errors should count every finding")
+	assert.Equal(t, 1, actionlintStats.RawWarnings, "raw warnings should count every finding")
+	assert.Equal(t, []string{"b.lock.yml:20:9", "c.lock.yml:30:9"}, actionlintStats.duplicateLocations(result.findings[0]), "collapsed locations should be listed")
+
+	report := buildActionlintJSONReport(actionlintStats)
+	require.Len(t, report.Errors, 2, "JSON should report unique issues")
+	assert.Equal(t, []string{"b.lock.yml:20:9", "c.lock.yml:30:9"}, report.Errors[0].DuplicateLocations, "JSON should list the collapsed locations")
+	assert.Empty(t, report.Errors[1].DuplicateLocations, "unique findings should have no collapsed locations")
+
+	output := testutil.CaptureStderr(t, displayActionlintSummary)
+	assert.Contains(t, output, "2 duplicate finding(s) collapsed by --dedupe (4 finding(s) reported by actionlint)", "summary should report collapsed findings")
+}
+
+func TestMergeActionlintChunkResultWithoutDedupe(t *testing.T) {
+	originalStats := actionlintStats
+	defer func() { actionlintStats = originalStats }()
+	initActionlintStats()
+
+	result := actionlintChunkResult{
+		chunk: actionlintChunk{lockFiles: []string{"a.lock.yml", "b.lock.yml"}, relPaths: []string{"a.lock.yml", "b.lock.yml"}},
+		findings: []ActionlintError{
+			{Message: "SC2086", Filepath: "a.lock.yml", Kind: "shellcheck"},
+			{Message: "SC2086", Filepath: "b.lock.yml", Kind: "shellcheck"},
+		},
+	}
+	mergeActionlintChunkResult(&result)
+
+	assert.Len(t, result.findings, 2, "duplicates should be kept without --dedupe")
+	assert.Equal(t, 2, actionlintStats.TotalErrors, "every finding should be counted")
+	assert.Equal(t, actionlintStats.TotalErrors, actionlintStats.RawErrors, "raw errors should match the total")
+	assert.Zero(t, actionlintStats.totalDuplicates(), "nothing should be collapsed")
+}
@@ -0,0 +1,90 @@
+// This file provides JSON output for actionlint results.
+//
+// printActionlintJSON prints the actionlint findings collected during compilation,
+// together with the aggregate ActionlintStats, as a single JSON document on stdout
+// so that CI dashboards can consume them instead of the text summary.
+
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var actionlintJSONLog = logger.New("cli:actionlint_json")
+
+// ActionlintJSONError is a single actionlint finding in the JSON output
+type ActionlintJSONError struct {
+	File    string `json:"file"`
+	Line    int    `json:"line"`
+	Column  int    `json:"column"`
+	Kind    string `json:"kind"`
+	Message string `json:"message"`
+	DocsURL string `json:"docs_url"`
+
+	// DuplicateLocations lists, with --dedupe, the file:line:column locations of the
+	// identical findings collapsed into this one
+	DuplicateLocations []string `json:"duplicate_locations,omitempty"`
+}
+
+// ActionlintJSONReport is the JSON document printed by --format json
+type ActionlintJSONReport struct {
+	Errors []ActionlintJSONError `json:"errors"`
+	Stats  ActionlintStats       `json:"stats"`
+}
+
+// buildActionlintJSONReport converts the collected findings and statistics into a report.
+// Findings hidden by --filter-kind or --ignore-kind are left out of the errors array.
+// An empty findings list yields an empty errors array rather than null.
+func buildActionlintJSONReport(stats *ActionlintStats) ActionlintJSONReport {
+	report := ActionlintJSONReport{Errors: []ActionlintJSONError{}}
+	if stats == nil {
+		report.Stats.ErrorsByKind = map[string]int{}
+		report.Stats.SuppressedByKind = map[string]int{}
+		report.Stats.IssuesByFile = map[string]ActionlintFileStats{}
+		report.Stats.IgnoredByKind = map[string]int{}
+		return report
+	}
+
+	for _, finding := range stats.findings {
+		if !stats.kindFilter.shows(finding.Kind) {
+			continue
+		}
+		report.Errors = append(report.Errors, ActionlintJSONError{
+			File:    filepath.ToSlash(finding.Filepath),
+			Line:    finding.Line,
+			Column:  finding.Column,
+			Kind:    finding.Kind,
+			Message: finding.Message,
+			DocsURL: getActionlintDocsURL(finding.Kind),
+
+			DuplicateLocations: stats.duplicateLocations(finding),
+		})
+	}
+	report.Stats = *stats
+	return report
+}
+
+// printActionlintJSON prints the actionlint results collected during this run as JSON to
+// stdout. If any actionlint output could not be parsed, nothing is printed and an error
+// is returned, so that consumers never see a partial report.
+func printActionlintJSON() error {
+	if actionlintStats != nil && len(actionlintStats.parseErrors) > 0 {
+		actionlintJSONLog.Printf("Skipping JSON output: %d unparsable actionlint output(s)", len(actionlintStats.parseErrors))
+		return fmt.Errorf("actionlint output could not be parsed: %w", errors.Join(actionlintStats.parseErrors...))
+	}
+
+	report := buildActionlintJSONReport(actionlintStats)
+	actionlintJSONLog.Printf("Printing actionlint JSON: errors=%d", len(report.Errors))
+
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal actionlint JSON: %w", err)
+	}
+	fmt.Println(string(data))
+	return nil
+}
@@ -0,0 +1,157 @@
+//go:build !integration
+
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseActionlintOutputDoesNotDisplay(t *testing.T) {
+	stdout := `[{"message":"label \"ubuntu-slim\" is unknown","filepath":".github/workflows/test.lock.yml","line":10,"column":14,"kind":"runner-label","snippet":"","end_column":24}]`
+
+	var findings []ActionlintError
+	var kinds map[string]int
+	var err error
+	output := testutil.CaptureStderr(t, func() {
+		findings, kinds, err = parseActionlintOutput(stdout)
+	})
+
+	require.NoError(t, err, "valid output should parse")
+	assert.Empty(t, output, "parsing should not display errors")
+	require.Len(t, findings, 1, "one finding should be parsed")
+	assert.Equal(t, map[string]int{"runner-label": 1}, kinds, "kinds should be counted")
+
+	_, _, err = parseActionlintOutput("not json")
+	require.Error(t, err, "invalid output should fail to parse")
+}
+
+func TestBuildActionlintJSONReport(t *testing.T) {
+	stats := &ActionlintStats{
+		TotalWorkflows: 2,
+		TotalErrors:    2,
+		ErrorsByKind:   map[string]int{"runner-label": 1, "expression": 1},
+		findings: []ActionlintError{
+			{Message: "label \"ubuntu-slim\" is unknown", Filepath: ".github/workflows/a.lock.yml", Line: 10, Column: 14, Kind: "runner-label"},
+			{Message: "undefined variable", Filepath: ".github/workflows/b.lock.yml", Line: 3, Column: 7, Kind: "expression"},
+		},
+	}
+
+	report := buildActionlintJSONReport(stats)
+
+	assert.Equal(t, []ActionlintJSONError{
+		{File: ".github/workflows/a.lock.yml", Line: 10, Column: 14, Kind: "runner-label", Message: "label \"ubuntu-slim\" is unknown", DocsURL: getActionlintDocsURL("runner-label")},
+		{File: ".github/workflows/b.lock.yml", Line: 3, Column: 7, Kind: "expression", Message: "undefined variable", DocsURL: getActionlintDocsURL("expression")},
+	}, report.Errors, "each finding should be serialized with its docs URL")
+	assert.Equal(t, 2, report.Stats.TotalWorkflows, "stats should be included")
+	assert.Equal(t, 2, report.Stats.TotalErrors, "stats should be included")
+
+	data, err := json.Marshal(report)
+	require.NoError(t, err, "report should marshal")
+	var decoded map[string]any
+	require.NoError(t, json.Unmarshal(data, &decoded), "report should be valid JSON")
+	statsJSON, ok := decoded["stats"].(map[string]any)
+	require.True(t, ok, "stats should be an object")
+	assert.Equal(t, map[string]any{"runner-label": 1.0, "expression": 1.0}, statsJSON["errors_by_kind"], "stats should use snake_case keys")
+	assert.NotContains(t, statsJSON, "findings", "internal findings should not be serialized")
+}
+
+func TestBuildActionlintJSONReportKindFilter(t *testing.T) {
+	stats := &ActionlintStats{
+		kindFilter: actionlintKindFilter{ignore: []string{"expression"}},
+		findings: []ActionlintError{
+			{Message: "SC2086", Filepath: "a.lock.yml", Kind: "shellcheck"},
+			{Message: "undefined variable", Filepath: "a.lock.yml", Kind: "expression"},
+		},
+	}
+
+	report := buildActionlintJSONReport(stats)
+
+	require.Len(t, report.Errors, 1, "hidden kinds should be left out of the errors array")
+	assert.Equal(t, "shellcheck", report.Errors[0].Kind, "displayed kind should be kept")
+}
+
+func TestBuildActionlintJSONReportEmpty(t *testing.T) {
+	data, err := json.Marshal(buildActionlintJSONReport(nil))
+	require.NoError(t, err, "report should marshal")
+	assert.JSONEq(t, `{"errors":[],"stats":{"total_workflows":0,"total_errors":0,"total_warnings":0,"integration_errors":0,"errors_by_kind":{},"total_suppressed":0,"suppressed_by_kind":{},"issues_by_file":{},"total_ignored":0,"ignored_by_kind":{},"baseline_suppressed":0,"new_since_baseline":0,"raw_errors":0,"raw_warnings":0}}`,
+		string(data), "empty report should use empty collections rather than null")
+}
+
+func TestPrintActionlintJSONParseError(t *testing.T) {
+	initActionlintStats()
+	t.Cleanup(func() { actionlintStats = nil })
+	actionlintStats.findings = []ActionlintError{{Message: "partial", Filepath: "a.lock.yml", Kind: "expression"}}
+	actionlintStats.parseErrors = []error{errors.New("failed to parse actionlint JSON output: unexpected end of JSON input")}
+
+	err := printActionlintJSON()
+	require.Error(t, err, "unparsable actionlint output should surface as an error")
+	assert.Contains(t, err.Error(), "could not be parsed", "error should explain the parse failure")
+}
+
+func TestValidateCompileConfigActionlintFlags(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  CompileConfig
+		wantErr string
+	}{
+		{name: "default format", config: CompileConfig{}},
+		{name: "text format", config: CompileConfig{ActionlintFormat: "text"}},
+		{name: "json format with actionlint", config: CompileConfig{Actionlint: true, ActionlintFormat: "json"}},
+		{name: "json format without actionlint", config: CompileConfig{ActionlintFormat: "json"}, wantErr: "requires --actionlint"},
+		{name: "json format with no-emit", config: CompileConfig{Actionlint: true, NoEmit: true, ActionlintFormat: "json"}, wantErr: "requires --actionlint"},
+		{name: "json format with json output", config: CompileConfig{Actionlint: true, JSONOutput: true, ActionlintFormat: "json"}, wantErr: "cannot be used with --json"},
+		{name: "kind filters with actionlint", config: CompileConfig{Actionlint: true, ActionlintFilterKinds: []string{"shellcheck"}, ActionlintIgnoreKinds: []string{"expression"}}},
+		{name: "filter-kind without actionlint", config: CompileConfig{ActionlintFilterKinds: []string{"shellcheck"}}, wantErr: "--filter-kind and --ignore-kind require --actionlint"},
+		{name: "ignore-kind with no-emit", config: CompileConfig{Actionlint: true, NoEmit: true, ActionlintIgnoreKinds: []string{"shellcheck"}}, wantErr: "--filter-kind and --ignore-kind require --actionlint"},
+		{name: "fail-on with actionlint", config: CompileConfig{Actionlint: true, ActionlintFailOn: "warning", ActionlintFailOnHidden: true}},
+		{name: "fail-on without actionlint", config: CompileConfig{ActionlintFailOn: "error"}, wantErr: "--fail-on requires --actionlint"},
+		{name: "unknown fail-on", config: CompileConfig{Actionlint: true, ActionlintFailOn: "info"}, wantErr: "invalid --fail-on value"},
+		{name: "fail-on-hidden without fail-on", config: CompileConfig{Actionlint: true, ActionlintFailOnHidden: true}, wantErr: "--fail-on-hidden requires --fail-on"},
+		{name: "jobs", config: CompileConfig{Actionlint: true, ActionlintJobs: 4}},
+		{name: "negative jobs", config: CompileConfig{Actionlint: true, ActionlintJobs: -1}, wantErr: "--jobs must be a positive number"},
+		{name: "actionlint-version with actionlint", config: CompileConfig{Actionlint: true, ActionlintVersion: "1.7.7"}},
+		{name: "actionlint-version without actionlint", config: CompileConfig{ActionlintVersion: "1.7.7"}, wantErr: "--actionlint-version requires --actionlint"},
+		{name: "annotations with actionlint", config: CompileConfig{Actionlint: true, ActionlintAnnotations: true}},
+		{name: "annotations without actionlint", config: CompileConfig{ActionlintAnnotations: true}, wantErr: "--annotations requires --actionlint"},
+		{name: "annotations with json format", config: CompileConfig{Actionlint: true, ActionlintAnnotations: true, ActionlintFormat: "json"}, wantErr: "--annotations cannot be used with --json"},
+		{name: "changed-only with base ref", config: CompileConfig{Actionlint: true, ActionlintChangedOnly: true, ActionlintBaseRef: "origin/main"}},
+		{name: "changed-only without actionlint", config: CompileConfig{ActionlintChangedOnly: true}, wantErr: "--changed-only requires --actionlint"},
+		{name: "base-ref without changed-only", config: CompileConfig{Actionlint: true, ActionlintBaseRef: "origin/main"}, wantErr: "--base-ref requires --changed-only"},
+		{name: "baseline with actionlint", config: CompileConfig{Actionlint: true, ActionlintBaseline: "baseline.json", ActionlintWriteBaseline: "baseline.json"}},
+		{name: "baseline without actionlint", config: CompileConfig{ActionlintBaseline: "baseline.json"}, wantErr: "--baseline and --write-baseline require --actionlint"},
+		{name: "write-baseline with no-emit", config: CompileConfig{Actionlint: true, NoEmit: true, ActionlintWriteBaseline: "baseline.json"}, wantErr: "--baseline and --write-baseline require --actionlint"},
+		{name: "summary-top", config: CompileConfig{Actionlint: true, ActionlintSummaryTop: 5}},
+		{name: "negative summary-top", config: CompileConfig{Actionlint: true, ActionlintSummaryTop: -1}, wantErr: "--summary-top must be a positive number"},
+		{name: "summary-top with summary-full", config: CompileConfig{Actionlint: true, ActionlintSummaryTop: 5, ActionlintSummaryFull: true}, wantErr: "--summary-top cannot be used with --summary-full"},
+		{name: "severity", config: CompileConfig{Actionlint: true, ActionlintSeverities: []string{"shellcheck=warning"}}},
+		{name: "severity without actionlint", config: CompileConfig{ActionlintSeverities: []string{"shellcheck=warning"}}, wantErr: "--severity requires --actionlint"},
+		{name: "invalid severity", config: CompileConfig{Actionlint: true, ActionlintSeverities: []string{"shellcheck=info"}}, wantErr: "invalid --severity value"},
+		{name: "dedupe", config: CompileConfig{Actionlint: true, ActionlintDedupe: true}},
+		{name: "dedupe without actionlint", config: CompileConfig{ActionlintDedupe: true}, wantErr: "--dedupe requires --actionlint"},
+		{name: "actionlint-path", config: CompileConfig{Actionlint: true, ActionlintPath: "/usr/local/bin/actionlint"}},
+		{name: "actionlint-path without actionlint", config: CompileConfig{ActionlintPath: "/usr/local/bin/actionlint"}, wantErr: "--actionlint-path requires --actionlint"},
+		{name: "actionlint-path with actionlint-version", config: CompileConfig{Actionlint: true, ActionlintPath: "/usr/local/bin/actionlint", ActionlintVersion: "1.7.7"}, wantErr: "--actionlint-path cannot be used with --actionlint-version"},
+		{name: "actionlint-pull-attempts", config: CompileConfig{Actionlint: true, ActionlintPullAttempts: 5}},
+		{name: "actionlint-pull-attempts without actionlint", config: CompileConfig{ActionlintPullAttempts: 5}, wantErr: "--actionlint-pull-attempts requires --actionlint"},
+		{name: "negative actionlint-pull-attempts", config: CompileConfig{Actionlint: true, ActionlintPullAttempts: -1}, wantErr: "--actionlint-pull-attempts must be a positive number"},
+		{name: "unknown format", config: CompileConfig{Actionlint: true, ActionlintFormat: "xml"}, wantErr: "invalid --format value"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateCompileConfig(tt.config)
+			if tt.wantErr != "" {
+				require.Error(t, err, "config should be rejected")
+				assert.Contains(t, err.Error(), tt.wantErr, "error should explain the problem")
+			} else {
+				assert.NoError(t, err, "config should be accepted")
+			}
+		})
+	}
+}
@@ -0,0 +1,129 @@
+// This file pulls the actionlint Docker image with retries.
+//
+// actionlint is downloaded as the rhysd/actionlint Docker image. Without an explicit pull,
+// the image is pulled implicitly by the first docker run, and a network failure in the
+// middle of that pull fails the whole compilation. pullActionlintImage pulls the image
+// before actionlint runs instead, retrying transient failures (network errors and 5xx
+// registry responses) with exponential backoff. Errors that a retry cannot fix, such as
+// an unknown image or tag, fail immediately.
+//
+// Docker verifies every layer against its digest and only tags an image once all of its
+// layers are complete, so a failed pull never leaves a corrupt image behind: a retry
+// resumes from the layers that were verified and discards the rest.
+
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var actionlintPullLog = logger.New("cli:actionlint_pull")
+
+// actionlintDefaultPullAttempts is the number of pull attempts without --actionlint-pull-attempts
+const actionlintDefaultPullAttempts = 3
+
+// actionlintPullBackoff is the wait before the first retry; it doubles after each retry
+var actionlintPullBackoff = 2 * time.Second
+
+// actionlintPulledImage is the image pulled during this run, so that it is only pulled once
+var actionlintPulledImage string
+
+// dockerPullPermanentErrors are docker pull messages of errors that a retry cannot fix
+var dockerPullPermanentErrors = []string{
+	"not found",
+	"manifest unknown",
+	"pull access denied",
+	"repository does not exist",
+	"unauthorized",
+	"invalid reference format",
+}
+
+// dockerPullTransientErrors are docker pull messages of network errors and 5xx responses
+var dockerPullTransientErrors = []string{
+	"timeout",
+	"timed out",
+	"connection reset",
+	"connection refused",
+	"broken pipe",
+	"unexpected eof",
+	"tls handshake",
+	"no such host",
+	"temporary failure",
+	"network is unreachable",
+	"500 internal server error",
+	"502 bad gateway",
+	"503 service unavailable",
+	"504 gateway time",
+	"unexpected http status: 5",
+}
+
+// isRetryableDockerPullError reports whether the output of a failed docker pull indicates a
+// transient network or server error. Missing images (404s) and access errors are not retried.
+func isRetryableDockerPullError(output string) bool {
+	output = strings.ToLower(output)
+	for _, message := range dockerPullPermanentErrors {
+		if strings.Contains(output, message) {
+			return false
+		}
+	}
+	for _, message := range dockerPullTransientErrors {
+		if strings.Contains(output, message) {
+			return true
+		}
+	}
+	return false
+}
+
+// pullActionlintImage pulls the actionlint image unless it is available locally, making up to
+// attempts attempts (actionlintDefaultPullAttempts if attempts is not positive). It returns an
+// error including the last underlying cause when the image cannot be pulled.
+func pullActionlintImage(attempts int, verbose bool) error {
+	image := actionlintImage()
+	if actionlintPulledImage == image || IsDockerImageAvailable(image) {
+		actionlintPulledImage = image
+		return nil
+	}
+	if attempts <= 0 {
+		attempts = actionlintDefaultPullAttempts
+	}
+
+	var lastErr error
+	backoff := actionlintPullBackoff
+	for attempt := 1; attempt <= attempts; attempt++ {
+		actionlintPullLog.Printf("Attempt %d of %d: pulling image %s", attempt, attempts, image)
+		output, err := exec.Command("docker", "pull", image).CombinedOutput()
+		if err == nil {
+			actionlintPullLog.Printf("Pulled image %s", image)
+			actionlintPulledImage = image
+			return nil
+		}
+
+		cause := strings.TrimSpace(string(output))
+		if cause == "" {
+			cause = err.Error()
+		}
+		lastErr = errors.New(cause)
+		if !isRetryableDockerPullError(cause) {
+			return fmt.Errorf("failed to pull actionlint image %s: %w", image, lastErr)
+		}
+
+		if attempt < attempts {
+			actionlintPullLog.Printf("Transient failure pulling image %s, retrying in %s: %s", image, backoff, cause)
+			if verbose {
+				fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Pulling %s failed (attempt %d/%d), retrying in %s", image, attempt, attempts, backoff)))
+			}
+			time.Sleep(backoff)
+			backoff *= 2
+		}
+	}
+
+	return fmt.Errorf("failed to pull actionlint image %s after %d attempt(s): %w", image, attempts, lastErr)
+}
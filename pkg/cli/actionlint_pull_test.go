@@ -0,0 +1,108 @@
+//go:build !integration
+
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsRetryableDockerPullError(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   bool
+	}{
+		{name: "connection reset", output: "Error response from daemon: Get \"https://registry-1.docker.io/v2/\": read tcp: connection reset by peer", want: true},
+		{name: "timeout", output: "Error response from daemon: Get \"https://registry-1.docker.io/v2/\": net/http: request canceled while waiting for connection (Client.Timeout exceeded while awaiting headers)", want: true},
+		{name: "unexpected EOF", output: "error pulling image configuration: download failed after attempts=6: unexpected EOF", want: true},
+		{name: "service unavailable", output: "Error response from daemon: received unexpected HTTP status: 503 Service Unavailable", want: true},
+		{name: "bad gateway", output: "Error response from daemon: 502 Bad Gateway", want: true},
+		{name: "unknown tag", output: "Error response from daemon: manifest for rhysd/actionlint:9.9.9 not found: manifest unknown: manifest unknown", want: false},
+		{name: "access denied", output: "Error response from daemon: pull access denied for rhysd/actionlnt, repository does not exist or may require 'docker login'", want: false},
+		{name: "unrecognized", output: "Error: something else went wrong", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, isRetryableDockerPullError(tt.output), "retryable")
+		})
+	}
+}
+
+// setupFakeDockerPull installs a fake docker whose image is missing and whose first failures
+// pulls fail with output, and returns the file counting the pull attempts
+func setupFakeDockerPull(t *testing.T, failures int, output string) string {
+	t.Helper()
+	tmpDir := testutil.TempDir(t, "actionlint-pull-*")
+	countPath := filepath.Join(tmpDir, "count")
+	script := `#!/bin/sh
+[ "$1" = "image" ] && exit 1
+count=$(cat "` + countPath + `" 2>/dev/null || echo 0)
+count=$((count + 1))
+echo "$count" > "` + countPath + `"
+if [ "$count" -le ` + strconv.Itoa(failures) + ` ]; then
+  echo "` + output + `"
+  exit 1
+fi
+exit 0
+`
+	binDir := filepath.Join(tmpDir, "bin")
+	require.NoError(t, os.MkdirAll(binDir, 0755), "should create bin dir")
+	require.NoError(t, os.WriteFile(filepath.Join(binDir, "docker"), []byte(script), 0755), "should write fake docker")
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	originalBackoff, originalPulled := actionlintPullBackoff, actionlintPulledImage
+	t.Cleanup(func() { actionlintPullBackoff, actionlintPulledImage = originalBackoff, originalPulled })
+	actionlintPullBackoff = 0
+	actionlintPulledImage = ""
+	return countPath
+}
+
+// pullAttemptCount returns the number of pull attempts counted by the fake docker
+func pullAttemptCount(t *testing.T, countPath string) int {
+	t.Helper()
+	data, err := os.ReadFile(countPath)
+	require.NoError(t, err, "fake docker should count pull attempts")
+	count, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	require.NoError(t, err, "count should be a number")
+	return count
+}
+
+func TestPullActionlintImage(t *testing.T) {
+	t.Run("retries transient failures", func(t *testing.T) {
+		countPath := setupFakeDockerPull(t, 2, "received unexpected HTTP status: 503 Service Unavailable")
+
+		require.NoError(t, pullActionlintImage(0, false), "pull should succeed on the third attempt")
+		assert.Equal(t, 3, pullAttemptCount(t, countPath), "pull should be attempted until it succeeds")
+
+		require.NoError(t, pullActionlintImage(0, false), "pulled image should not be pulled again")
+		assert.Equal(t, 3, pullAttemptCount(t, countPath), "pulled image should not be pulled again")
+	})
+
+	t.Run("gives up after the configured attempts", func(t *testing.T) {
+		countPath := setupFakeDockerPull(t, 5, "read tcp: connection reset by peer")
+
+		err := pullActionlintImage(2, false)
+		require.Error(t, err, "pull should fail when all attempts fail")
+		assert.Contains(t, err.Error(), "after 2 attempt(s)", "error should give the number of attempts")
+		assert.Contains(t, err.Error(), "connection reset by peer", "error should include the last cause")
+		assert.Equal(t, 2, pullAttemptCount(t, countPath), "pull should be attempted the configured number of times")
+	})
+
+	t.Run("does not retry missing images", func(t *testing.T) {
+		countPath := setupFakeDockerPull(t, 5, "manifest for rhysd/actionlint:9.9.9 not found: manifest unknown")
+
+		err := pullActionlintImage(3, false)
+		require.Error(t, err, "pull should fail")
+		assert.Contains(t, err.Error(), "manifest unknown", "error should include the cause")
+		assert.Equal(t, 1, pullAttemptCount(t, countPath), "missing images should not be retried")
+	})
+}
@@ -0,0 +1,176 @@
+// This file provides SARIF output for actionlint results.
+//
+// writeActionlintSARIF converts the actionlint findings collected during compilation
+// into a SARIF 2.1.0 log so they can be uploaded to GitHub code scanning. Each finding
+// becomes a result whose rule is the actionlint check kind, with the rule help URI
+// pointing at the actionlint documentation for that check.
+
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var actionlintSARIFLog = logger.New("cli:actionlint_sarif")
+
+const (
+	sarifVersion   = "2.1.0"
+	sarifSchemaURI = "https://json.schemastore.org/sarif-2.1.0.json"
+)
+
+// sarifLog is the top-level SARIF 2.1.0 document
+type sarifLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool      sarifTool       `json:"tool"`
+	Artifacts []sarifArtifact `json:"artifacts"`
+	Results   []sarifResult   `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version,omitempty"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID      string `json:"id"`
+	HelpURI string `json:"helpUri"`
+}
+
+type sarifArtifact struct {
+	Location sarifArtifactLocation `json:"location"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId,omitempty"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           sarifRegion           `json:"region"`
+}
+
+type sarifArtifactLocation struct {
+	URI   string `json:"uri"`
+	Index *int   `json:"index,omitempty"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine,omitempty"`
+	StartColumn int `json:"startColumn,omitempty"`
+	EndColumn   int `json:"endColumn,omitempty"`
+}
+
+// buildActionlintSARIF converts actionlint findings into a SARIF log with a single run.
+// Each distinct file becomes an artifact that results reference by index, and each
+// distinct kind becomes a rule. Result levels honor the --severity overrides in severities.
+// An empty findings list yields a run with zero results.
+func buildActionlintSARIF(findings []ActionlintError, version string, severities actionlintSeverities) sarifLog {
+	run := sarifRun{
+		Tool: sarifTool{Driver: sarifDriver{
+			Name:           "actionlint",
+			Version:        version,
+			InformationURI: "https://github.com/rhysd/actionlint",
+			Rules:          []sarifRule{},
+		}},
+		Artifacts: []sarifArtifact{},
+		Results:   []sarifResult{},
+	}
+
+	artifactIndex := make(map[string]int)
+	for _, finding := range findings {
+		uri := filepath.ToSlash(finding.Filepath)
+		index, ok := artifactIndex[uri]
+		if !ok {
+			index = len(run.Artifacts)
+			artifactIndex[uri] = index
+			run.Artifacts = append(run.Artifacts, sarifArtifact{Location: sarifArtifactLocation{URI: uri}})
+		}
+
+		if finding.Kind != "" && !slices.ContainsFunc(run.Tool.Driver.Rules, func(rule sarifRule) bool { return rule.ID == finding.Kind }) {
+			run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule{ID: finding.Kind, HelpURI: getActionlintDocsURL(finding.Kind)})
+		}
+
+		// Match the severity mapping used for the console output
+		level := "error"
+		if severities.isWarning(finding.Kind) {
+			level = "warning"
+		}
+
+		run.Results = append(run.Results, sarifResult{
+			RuleID:  finding.Kind,
+			Level:   level,
+			Message: sarifMessage{Text: finding.Message},
+			Locations: []sarifLocation{{PhysicalLocation: sarifPhysicalLocation{
+				ArtifactLocation: sarifArtifactLocation{URI: uri, Index: &index},
+				Region: sarifRegion{
+					StartLine:   finding.Line,
+					StartColumn: finding.Column,
+					EndColumn:   finding.EndColumn,
+				},
+			}}},
+		})
+	}
+
+	return sarifLog{Version: sarifVersion, Schema: sarifSchemaURI, Runs: []sarifRun{run}}
+}
+
+// writeActionlintSARIF writes the actionlint findings collected during this run to
+// sarifPath as a SARIF 2.1.0 log
+func writeActionlintSARIF(sarifPath string, verbose bool) error {
+	var findings []ActionlintError
+	var severities actionlintSeverities
+	if actionlintStats != nil {
+		findings = actionlintStats.findings
+		severities = actionlintStats.severities
+	}
+	actionlintSARIFLog.Printf("Writing actionlint SARIF: path=%s, results=%d", sarifPath, len(findings))
+
+	data, err := json.MarshalIndent(buildActionlintSARIF(findings, actionlintVersion, severities), "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal actionlint SARIF: %w", err)
+	}
+
+	if dir := filepath.Dir(sarifPath); dir != "" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create SARIF directory: %w", err)
+		}
+	}
+
+	if err := os.WriteFile(sarifPath, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write actionlint SARIF: %w", err)
+	}
+
+	if verbose {
+		fmt.Fprintln(os.Stderr, console.FormatSuccessMessage(fmt.Sprintf("Wrote %d actionlint result(s) to %s", len(findings), sarifPath)))
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+//go:build !integration
+
+package cli
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildActionlintSARIF(t *testing.T) {
+	findings := []ActionlintError{
+		{Message: "label \"ubuntu-slim\" is unknown", Filepath: ".github/workflows/a.lock.yml", Line: 10, Column: 14, EndColumn: 24, Kind: "runner-label"},
+		{Message: "shellcheck reported issue", Filepath: ".github/workflows/b.lock.yml", Line: 25, Column: 9, EndColumn: 12, Kind: "shellcheck"},
+		{Message: "another runner label", Filepath: ".github/workflows/a.lock.yml", Line: 30, Column: 14, EndColumn: 20, Kind: "runner-label"},
+	}
+
+	log := buildActionlintSARIF(findings, "1.7.9", nil)
+
+	assert.Equal(t, "2.1.0", log.Version, "log should declare SARIF 2.1.0")
+	require.Len(t, log.Runs, 1, "log should have a single run")
+	run := log.Runs[0]
+	assert.Equal(t, "actionlint", run.Tool.Driver.Name, "driver should be actionlint")
+	assert.Equal(t, "1.7.9", run.Tool.Driver.Version, "driver should carry the actionlint version")
+
+	assert.Equal(t, []sarifRule{
+		{ID: "runner-label", HelpURI: getActionlintDocsURL("runner-label")},
+		{ID: "shellcheck", HelpURI: getActionlintDocsURL("shellcheck")},
+	}, run.Tool.Driver.Rules, "each kind should become one rule with its docs URL")
+
+	require.Len(t, run.Artifacts, 2, "each file should become one artifact")
+	assert.Equal(t, ".github/workflows/a.lock.yml", run.Artifacts[0].Location.URI, "first artifact should be the first file")
+	assert.Equal(t, ".github/workflows/b.lock.yml", run.Artifacts[1].Location.URI, "second artifact should be the second file")
+
+	require.Len(t, run.Results, 3, "each finding should become one result")
+	first := run.Results[0]
+	assert.Equal(t, "runner-label", first.RuleID, "rule id should be the kind")
+	assert.Equal(t, "error", first.Level, "actionlint findings are errors")
+	assert.Equal(t, "label \"ubuntu-slim\" is unknown", first.Message.Text, "message should be preserved")
+	location := first.Locations[0].PhysicalLocation
+	assert.Equal(t, ".github/workflows/a.lock.yml", location.ArtifactLocation.URI, "location should reference the file")
+	assert.Equal(t, sarifRegion{StartLine: 10, StartColumn: 14, EndColumn: 24}, location.Region, "region should map line and columns")
+
+	assert.Equal(t, 1, *run.Results[1].Locations[0].PhysicalLocation.ArtifactLocation.Index, "second file should use its own artifact index")
+	assert.Equal(t, 0, *run.Results[2].Locations[0].PhysicalLocation.ArtifactLocation.Index, "repeated files should reuse their artifact index")
+}
+
+func TestWriteActionlintSARIFEmpty(t *testing.T) {
+	initActionlintStats()
+	t.Cleanup(func() { actionlintStats = nil })
+
+	sarifPath := filepath.Join(t.TempDir(), "reports", "actionlint.sarif")
+	require.NoError(t, writeActionlintSARIF(sarifPath, false), "SARIF should be written without findings")
+
+	data, err := os.ReadFile(sarifPath)
+	require.NoError(t, err, "SARIF file should exist")
+
+	var log map[string]any
+	require.NoError(t, json.Unmarshal(data, &log), "SARIF file should be valid JSON")
+	runs, ok := log["runs"].([]any)
+	require.True(t, ok, "runs should be an array")
+	require.Len(t, runs, 1, "log should have a single run")
+	run := runs[0].(map[string]any)
+	assert.Equal(t, []any{}, run["results"], "results should be an empty array, not null")
+}
+
+func TestValidateCompileConfigSARIF(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  CompileConfig
+		wantErr bool
+	}{
+		{name: "sarif with actionlint", config: CompileConfig{Actionlint: true, ActionlintSARIFPath: "actionlint.sarif"}},
+		{name: "sarif without actionlint", config: CompileConfig{ActionlintSARIFPath: "actionlint.sarif"}, wantErr: true},
+		{name: "sarif with no-emit", config: CompileConfig{Actionlint: true, NoEmit: true, ActionlintSARIFPath: "actionlint.sarif"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateCompileConfig(tt.config)
+			if tt.wantErr {
+				require.Error(t, err, "sarif should require actionlint output")
+				assert.Contains(t, err.Error(), "--sarif", "error should mention the flag")
+			} else {
+				assert.NoError(t, err, "sarif should be accepted with actionlint")
+			}
+		})
+	}
+}
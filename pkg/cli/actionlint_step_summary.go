@@ -0,0 +1,86 @@
+// This file writes the actionlint summary to the GitHub Actions job summary.
+//
+// When gh aw compile --actionlint runs in a GitHub Actions step, GITHUB_STEP_SUMMARY
+// names a file whose Markdown content is rendered on the job page. The actionlint
+// totals and the breakdowns by kind and by file are appended to it as Markdown tables,
+// in addition to the text summary printed to stderr.
+
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var actionlintStepSummaryLog = logger.New("cli:actionlint_step_summary")
+
+// writeActionlintStepSummary appends the actionlint summary to the file named by
+// GITHUB_STEP_SUMMARY, if set. The job summary is informational, so a file that
+// cannot be written only produces a warning.
+func writeActionlintStepSummary() {
+	path := os.Getenv("GITHUB_STEP_SUMMARY")
+	if path == "" || actionlintStats == nil || actionlintStats.TotalWorkflows == 0 {
+		return
+	}
+	actionlintStepSummaryLog.Printf("Writing actionlint step summary to %s", path)
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, console.FormatWarningMessage("Could not write actionlint summary to GITHUB_STEP_SUMMARY: "+err.Error()))
+		return
+	}
+	defer file.Close()
+
+	if _, err := file.WriteString(buildActionlintStepSummary(actionlintStats)); err != nil {
+		fmt.Fprintln(os.Stderr, console.FormatWarningMessage("Could not write actionlint summary to GITHUB_STEP_SUMMARY: "+err.Error()))
+	}
+}
+
+// buildActionlintStepSummary renders the actionlint statistics as Markdown
+func buildActionlintStepSummary(stats *ActionlintStats) string {
+	var sb strings.Builder
+	sb.WriteString("## Actionlint Summary\n\n")
+
+	sb.WriteString("| | Count |\n| --- | ---: |\n")
+	fmt.Fprintf(&sb, "| Workflows checked | %d |\n", stats.TotalWorkflows)
+	fmt.Fprintf(&sb, "| Errors | %d |\n", stats.TotalErrors)
+	fmt.Fprintf(&sb, "| Warnings | %d |\n", stats.TotalWarnings)
+	if duplicates := stats.totalDuplicates(); duplicates > 0 {
+		fmt.Fprintf(&sb, "| Duplicates collapsed by `--dedupe` | %d |\n", duplicates)
+	}
+	if stats.TotalSuppressed > 0 {
+		fmt.Fprintf(&sb, "| Hidden by `--filter-kind`/`--ignore-kind` | %d |\n", stats.TotalSuppressed)
+	}
+	if stats.TotalIgnored > 0 {
+		fmt.Fprintf(&sb, "| Suppressed by `actionlint.ignore` | %d |\n", stats.TotalIgnored)
+	}
+	if stats.baseline != nil {
+		fmt.Fprintf(&sb, "| Suppressed by baseline | %d |\n", stats.BaselineSuppressed)
+		fmt.Fprintf(&sb, "| New since baseline | %d |\n", stats.NewSinceBaseline)
+	}
+	if stats.IntegrationErrors > 0 {
+		fmt.Fprintf(&sb, "| Failed actionlint invocations | %d |\n", stats.IntegrationErrors)
+	}
+
+	if len(stats.ErrorsByKind) > 0 {
+		sb.WriteString("\n### Issues by kind\n\n| Kind | Count |\n| --- | ---: |\n")
+		for _, kind := range sortedActionlintKinds(stats.ErrorsByKind) {
+			fmt.Fprintf(&sb, "| [`%s`](%s) | %d |\n", kind, getActionlintDocsURL(kind), stats.ErrorsByKind[kind])
+		}
+	}
+
+	if len(stats.IssuesByFile) > 0 {
+		sb.WriteString("\n### Issues by file\n\n| File | Errors | Warnings |\n| --- | ---: | ---: |\n")
+		for _, file := range sortedActionlintIssueFiles(stats.IssuesByFile) {
+			fileStats := stats.IssuesByFile[file]
+			fmt.Fprintf(&sb, "| `%s` | %d | %d |\n", strings.ReplaceAll(file, "|", "\\|"), fileStats.Errors, fileStats.Warnings)
+		}
+	}
+
+	sb.WriteString("\n")
+	return sb.String()
+}
@@ -0,0 +1,60 @@
+//go:build !integration
+
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildActionlintStepSummary(t *testing.T) {
+	stats := &ActionlintStats{
+		TotalWorkflows: 2,
+		TotalErrors:    3,
+		TotalWarnings:  1,
+		ErrorsByKind:   map[string]int{"shellcheck": 3, "runner-label": 1},
+		IssuesByFile: map[string]ActionlintFileStats{
+			".github/workflows/a.lock.yml": {Errors: 1},
+			".github/workflows/b.lock.yml": {Errors: 2, Warnings: 1},
+		},
+	}
+
+	summary := buildActionlintStepSummary(stats)
+
+	assert.Contains(t, summary, "## Actionlint Summary", "summary should have a heading")
+	assert.Contains(t, summary, "| Workflows checked | 2 |\n| Errors | 3 |\n| Warnings | 1 |\n", "summary should include the totals")
+	assert.NotContains(t, summary, "baseline", "baseline rows should only be shown with a baseline")
+	assert.Contains(t, summary, "| [`shellcheck`]("+getActionlintDocsURL("shellcheck")+") | 3 |\n| [`runner-label`]", "kinds should be sorted by count and link to the docs")
+	assert.Contains(t, summary, "| `.github/workflows/b.lock.yml` | 2 | 1 |\n| `.github/workflows/a.lock.yml` | 1 | 0 |\n", "files should be sorted by issue count")
+}
+
+func TestWriteActionlintStepSummary(t *testing.T) {
+	originalStats := actionlintStats
+	defer func() { actionlintStats = originalStats }()
+	initActionlintStats()
+	actionlintStats.TotalWorkflows = 1
+
+	t.Run("appends to the summary file", func(t *testing.T) {
+		summaryPath := filepath.Join(testutil.TempDir(t, "step-summary-*"), "summary.md")
+		require.NoError(t, os.WriteFile(summaryPath, []byte("# Earlier step\n"), 0644), "should write summary file")
+		t.Setenv("GITHUB_STEP_SUMMARY", summaryPath)
+
+		writeActionlintStepSummary()
+
+		content, err := os.ReadFile(summaryPath)
+		require.NoError(t, err, "summary file should be readable")
+		assert.Contains(t, string(content), "# Earlier step\n## Actionlint Summary", "summary should be appended")
+	})
+
+	t.Run("unwritable path", func(t *testing.T) {
+		t.Setenv("GITHUB_STEP_SUMMARY", filepath.Join(testutil.TempDir(t, "step-summary-*"), "missing", "summary.md"))
+
+		output := testutil.CaptureStderr(t, writeActionlintStepSummary)
+		assert.Contains(t, output, "Could not write actionlint summary to GITHUB_STEP_SUMMARY", "failure should be a warning")
+	})
+}
@@ -3,9 +3,14 @@
 package cli
 
 import (
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/github/gh-aw/pkg/console"
 	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/github/gh-aw/pkg/workflow"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -93,19 +98,19 @@ func TestParseAndDisplayActionlintOutput(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			var count int
+			var findings []ActionlintError
 			var kinds map[string]int
 			var err error
 
 			output := testutil.CaptureStderr(t, func() {
-				count, kinds, err = parseAndDisplayActionlintOutput(tt.stdout, tt.verbose)
+				findings, kinds, err = parseAndDisplayActionlintOutput(tt.stdout, tt.verbose, actionlintDisplayOptions{})
 			})
 
 			if tt.expectError {
 				require.Error(t, err, "should return error for invalid input")
 			} else {
 				require.NoError(t, err, "should not return error for valid input")
-				assert.Equal(t, tt.expectedCount, count, "error count should match expected")
+				assert.Len(t, findings, tt.expectedCount, "error count should match expected")
 				if tt.expectedKinds != nil {
 					assert.Equal(t, tt.expectedKinds, kinds, "error kinds should match expected")
 				}
@@ -230,6 +235,41 @@ func TestDisplayActionlintSummary(t *testing.T) {
 				"1 actionlint invocation(s) also failed with tooling errors",
 			},
 		},
+		{
+			name: "summary with issues by file",
+			stats: &ActionlintStats{
+				TotalWorkflows: 3,
+				TotalErrors:    4,
+				TotalWarnings:  1,
+				ErrorsByKind:   map[string]int{"expression": 4},
+				IssuesByFile: map[string]ActionlintFileStats{
+					"a.lock.yml": {Errors: 1},
+					"b.lock.yml": {Errors: 3, Warnings: 1},
+				},
+			},
+			expectedContains: []string{
+				"Issues by type:",
+				"Issues by file:",
+				"b.lock.yml: 4 (3 error(s), 1 warning(s))",
+				"a.lock.yml: 1 (1 error(s), 0 warning(s))",
+			},
+		},
+		{
+			name: "summary with errors hidden by kind filter",
+			stats: &ActionlintStats{
+				TotalWorkflows:   2,
+				TotalErrors:      6,
+				ErrorsByKind:     map[string]int{"shellcheck": 2},
+				TotalSuppressed:  4,
+				SuppressedByKind: map[string]int{"expression": 4},
+			},
+			expectedContains: []string{
+				"Found 6 issue(s)",
+				"shellcheck: 2",
+				"4 issue(s) hidden by --filter-kind/--ignore-kind",
+				"expression: 4",
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -265,9 +305,75 @@ func TestInitActionlintStats(t *testing.T) {
 	assert.Zero(t, actionlintStats.IntegrationErrors, "IntegrationErrors should start at 0")
 	assert.NotNil(t, actionlintStats.ErrorsByKind, "ErrorsByKind map should be initialized")
 	assert.Empty(t, actionlintStats.ErrorsByKind, "ErrorsByKind should start empty")
+	assert.NotNil(t, actionlintStats.SuppressedByKind, "SuppressedByKind map should be initialized")
+}
+
+func TestActionlintKindFilterShows(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter actionlintKindFilter
+		kind   string
+		want   bool
+	}{
+		{name: "no filter shows everything", kind: "shellcheck", want: true},
+		{name: "filter-kind shows listed kind", filter: actionlintKindFilter{only: []string{"shellcheck"}}, kind: "shellcheck", want: true},
+		{name: "filter-kind hides other kinds", filter: actionlintKindFilter{only: []string{"shellcheck"}}, kind: "expression", want: false},
+		{name: "filter-kind hides errors without kind", filter: actionlintKindFilter{only: []string{"shellcheck"}}, kind: "", want: false},
+		{name: "ignore-kind hides listed kind", filter: actionlintKindFilter{ignore: []string{"shellcheck"}}, kind: "shellcheck", want: false},
+		{name: "ignore-kind shows other kinds", filter: actionlintKindFilter{ignore: []string{"shellcheck"}}, kind: "expression", want: true},
+		{name: "ignore-kind wins over filter-kind", filter: actionlintKindFilter{only: []string{"shellcheck"}, ignore: []string{"shellcheck"}}, kind: "shellcheck", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.filter.shows(tt.kind), "unexpected filter result for kind %q", tt.kind)
+		})
+	}
+}
+
+func TestActionlintStatsRecordFindingsWithKindFilter(t *testing.T) {
+	originalStats := actionlintStats
+	defer func() { actionlintStats = originalStats }()
+	initActionlintStats()
+	actionlintStats.kindFilter = actionlintKindFilter{only: []string{"shellcheck"}}
+
+	actionlintStats.recordFindings([]ActionlintError{
+		{Kind: "shellcheck"},
+		{Kind: "shellcheck"},
+		{Kind: "expression"},
+		{Kind: ""},
+	})
+
+	assert.Equal(t, 4, actionlintStats.TotalErrors, "hidden errors should still count towards the total")
+	assert.Equal(t, map[string]int{"shellcheck": 2}, actionlintStats.ErrorsByKind, "only displayed kinds should be counted")
+	assert.Equal(t, 2, actionlintStats.TotalSuppressed, "hidden errors should be counted")
+	assert.Equal(t, map[string]int{"expression": 1}, actionlintStats.SuppressedByKind, "hidden kinds should be counted")
+	assert.Len(t, actionlintStats.findings, 4, "all findings should be kept for SARIF output")
+}
+
+func TestParseAndDisplayActionlintOutputWithKindFilter(t *testing.T) {
+	stdout := `[
+{"message":"SC2086: Double quote to prevent globbing","filepath":"test.lock.yml","line":5,"column":9,"kind":"shellcheck","snippet":"","end_column":12},
+{"message":"undefined variable \"foo\"","filepath":"test.lock.yml","line":8,"column":3,"kind":"expression","snippet":"","end_column":6}
+]`
+
+	var findings []ActionlintError
+	var kinds map[string]int
+	var err error
+	output := testutil.CaptureStderr(t, func() {
+		findings, kinds, err = parseAndDisplayActionlintOutput(stdout, false, actionlintDisplayOptions{filter: actionlintKindFilter{only: []string{"shellcheck"}}})
+	})
+
+	require.NoError(t, err, "valid output should parse")
+	assert.Len(t, findings, 2, "all errors should be returned")
+	assert.Equal(t, map[string]int{"shellcheck": 1}, kinds, "only displayed kinds should be counted")
+	assert.Contains(t, output, "SC2086", "shellcheck errors should be displayed")
+	assert.NotContains(t, output, "undefined variable", "other kinds should be hidden")
 }
 
 func TestGetActionlintDocsURL(t *testing.T) {
+	t.Setenv("GH_AW_ACTIONLINT_DOCS_URL", "")
+
 	tests := []struct {
 		name     string
 		kind     string
@@ -317,3 +423,687 @@ func TestGetActionlintDocsURL(t *testing.T) {
 		})
 	}
 }
+
+func TestGetActionlintDocsURLCustomBase(t *testing.T) {
+	t.Setenv("GH_AW_ACTIONLINT_DOCS_URL", " https://docs.example.internal/actionlint/checks.html ")
+
+	assert.Equal(t, "https://docs.example.internal/actionlint/checks.html", getActionlintDocsURL(""), "empty kind should return the custom base URL")
+	assert.Equal(t, "https://docs.example.internal/actionlint/checks.html#check-runner-labels", getActionlintDocsURL("runner-label"), "special-cased anchors should be appended to the custom base URL")
+	assert.Equal(t, "https://docs.example.internal/actionlint/checks.html#check-job-deps", getActionlintDocsURL("job-deps"), "standard anchors should be appended to the custom base URL")
+
+	t.Setenv("GH_AW_ACTIONLINT_DOCS_URL", "")
+	assert.Equal(t, "https://github.com/rhysd/actionlint/blob/main/docs/checks.md#check-shellcheck-integ", getActionlintDocsURL("shellcheck"), "unset variable should use the default URL")
+}
+
+func TestActionlintStatsHasFindings(t *testing.T) {
+	tests := []struct {
+		name     string
+		stats    *ActionlintStats
+		expected bool
+	}{
+		{name: "nil stats", stats: nil, expected: false},
+		{name: "clean run", stats: &ActionlintStats{TotalWorkflows: 3}, expected: false},
+		{name: "errors", stats: &ActionlintStats{TotalWorkflows: 3, TotalErrors: 1}, expected: true},
+		{name: "warnings", stats: &ActionlintStats{TotalWorkflows: 3, TotalWarnings: 2}, expected: true},
+		{name: "integration failures", stats: &ActionlintStats{TotalWorkflows: 3, IntegrationErrors: 1}, expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.stats.hasFindings(), "hasFindings should match")
+		})
+	}
+}
+
+func TestActionlintStatsCheckFailOn(t *testing.T) {
+	findings := []ActionlintError{
+		{Kind: "shellcheck"},
+		{Kind: "deprecated-warning"},
+	}
+
+	tests := []struct {
+		name          string
+		findings      []ActionlintError
+		filter        actionlintKindFilter
+		policy        string
+		includeHidden bool
+		wantErr       string
+	}{
+		{name: "no policy", findings: findings},
+		{name: "never", findings: findings, policy: actionlintFailOnNever},
+		{name: "error with errors", findings: findings, policy: actionlintFailOnError, wantErr: "actionlint found 1 error(s)"},
+		{name: "error with only warnings", findings: []ActionlintError{{Kind: "deprecated-warning"}}, policy: actionlintFailOnError},
+		{name: "warning with only warnings", findings: []ActionlintError{{Kind: "deprecated-warning"}}, policy: actionlintFailOnWarning, wantErr: "0 error(s) and 1 warning(s)"},
+		{name: "warning without findings", policy: actionlintFailOnWarning},
+		{
+			name:     "hidden errors are ignored",
+			findings: findings,
+			filter:   actionlintKindFilter{ignore: []string{"shellcheck"}},
+			policy:   actionlintFailOnError,
+		},
+		{
+			name:          "hidden errors count with includeHidden",
+			findings:      findings,
+			filter:        actionlintKindFilter{ignore: []string{"shellcheck"}},
+			policy:        actionlintFailOnError,
+			includeHidden: true,
+			wantErr:       "actionlint found 1 error(s)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			originalStats := actionlintStats
+			defer func() { actionlintStats = originalStats }()
+			initActionlintStats()
+			actionlintStats.kindFilter = tt.filter
+			actionlintStats.recordFindings(tt.findings)
+
+			err := actionlintStats.checkFailOn(tt.policy, tt.includeHidden)
+			if tt.wantErr != "" {
+				require.Error(t, err, "policy should fail")
+				assert.Contains(t, err.Error(), tt.wantErr, "error should report the counts")
+			} else {
+				assert.NoError(t, err, "policy should not fail")
+			}
+		})
+	}
+}
+
+func TestActionlintStatsRecordFindingsSeverity(t *testing.T) {
+	originalStats := actionlintStats
+	defer func() { actionlintStats = originalStats }()
+	initActionlintStats()
+
+	actionlintStats.recordFindings([]ActionlintError{{Kind: "expression"}, {Kind: "deprecated-warning"}})
+
+	assert.Equal(t, 1, actionlintStats.TotalErrors, "error kinds should count as errors")
+	assert.Equal(t, 1, actionlintStats.TotalWarnings, "warning kinds should count as warnings")
+}
+
+func TestParseAndDisplayActionlintOutputWithSourceMap(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "actionlint-source-map-*")
+	markdownPath := filepath.Join(tmpDir, "test.md")
+	lockPath := filepath.Join(tmpDir, "test.lock.yml")
+	markdown := "---\non: push\nsteps:\n  - name: Prepare\n    run: |\n      ls $RUNNER_TEMP/data\n---\n# Test\n"
+	lock := "name: Test\njobs:\n  agent:\n    steps:\n      - name: Prepare\n        run: |\n          ls $RUNNER_TEMP/data\n      - name: Generated\n        run: echo generated\n"
+	require.NoError(t, os.WriteFile(markdownPath, []byte(markdown), 0644), "should write markdown")
+	require.NoError(t, os.WriteFile(lockPath, []byte(lock), 0644), "should write lock file")
+
+	opts := actionlintDisplayOptions{}
+	stats := &ActionlintStats{}
+	stats.addSourceMap(lockPath, workflow.BuildSourceMap(markdownPath, markdown, lock))
+	opts.sourceMaps = stats.sourceMaps
+
+	stdout := `[
+{"message":"SC2086: Double quote to prevent globbing","filepath":"` + lockPath + `","line":7,"column":14,"kind":"shellcheck","snippet":"","end_column":26},
+{"message":"generated problem","filepath":"` + lockPath + `","line":9,"column":14,"kind":"expression","snippet":"","end_column":20}
+]`
+
+	output := testutil.CaptureStderr(t, func() {
+		_, _, err := parseAndDisplayActionlintOutput(stdout, false, opts)
+		require.NoError(t, err, "valid output should parse")
+	})
+
+	assert.Contains(t, output, markdownPath+":6:10", "mapped error should be shown at the markdown location")
+	assert.Contains(t, output, "reported by actionlint at "+lockPath+":7:14", "mapped error should note the lock location")
+	assert.Contains(t, output, lockPath+":9:14", "unmapped error should be shown at the lock location")
+	assert.Contains(t, output, "in code generated by gh-aw; no matching line in "+markdownPath, "unmapped error should explain the fallback")
+}
+
+func TestActionlintStatsIssuesByFile(t *testing.T) {
+	originalStats := actionlintStats
+	defer func() { actionlintStats = originalStats }()
+	initActionlintStats()
+	actionlintStats.kindFilter = actionlintKindFilter{ignore: []string{"pyflakes"}}
+
+	actionlintStats.recordFindings([]ActionlintError{
+		{Filepath: "a.lock.yml", Kind: "expression"},
+		{Filepath: "b.lock.yml", Kind: "shellcheck"},
+		{Filepath: "b.lock.yml", Kind: "shellcheck"},
+		{Filepath: "b.lock.yml", Kind: "deprecated-warning"},
+		{Filepath: "c.lock.yml", Kind: "runner-label"},
+		{Filepath: "c.lock.yml", Kind: "deprecated-warning"},
+		{Filepath: "c.lock.yml", Kind: "pyflakes"},
+	})
+
+	assert.Equal(t, map[string]ActionlintFileStats{
+		"a.lock.yml": {Errors: 1},
+		"b.lock.yml": {Errors: 2, Warnings: 1},
+		"c.lock.yml": {Errors: 1, Warnings: 1},
+	}, actionlintStats.IssuesByFile, "displayed issues should be counted per file")
+	assert.Equal(t, []string{"b.lock.yml", "c.lock.yml", "a.lock.yml"}, sortedActionlintIssueFiles(actionlintStats.IssuesByFile),
+		"files should be sorted by descending issue count, then by name")
+
+	output := testutil.CaptureStderr(t, func() {
+		actionlintStats.TotalWorkflows = 3
+		displayActionlintSummary()
+	})
+	byFile := output[strings.Index(output, "Issues by file:"):]
+	assert.Less(t, strings.Index(byFile, "b.lock.yml: 3"), strings.Index(byFile, "c.lock.yml: 2"), "worst offender should be listed first")
+	assert.Less(t, strings.Index(byFile, "c.lock.yml: 2"), strings.Index(byFile, "a.lock.yml: 1"), "least issues should be listed last")
+}
+
+func TestSplitActionlintChunks(t *testing.T) {
+	files := []string{"a", "b", "c", "d", "e"}
+
+	tests := []struct {
+		name     string
+		jobs     int
+		expected [][]string
+	}{
+		{name: "single job", jobs: 1, expected: [][]string{{"a", "b", "c", "d", "e"}}},
+		{name: "remainder spread over first chunks", jobs: 2, expected: [][]string{{"a", "b", "c"}, {"d", "e"}}},
+		{name: "more jobs than files", jobs: 8, expected: [][]string{{"a"}, {"b"}, {"c"}, {"d"}, {"e"}}},
+		{name: "zero jobs", jobs: 0, expected: [][]string{{"a", "b", "c", "d", "e"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chunks := splitActionlintChunks(files, files, tt.jobs)
+			var got [][]string
+			for i, chunk := range chunks {
+				assert.Equal(t, i, chunk.index, "chunks should be numbered in order")
+				assert.Equal(t, chunk.lockFiles, chunk.relPaths, "relative paths should stay paired with lock files")
+				got = append(got, chunk.lockFiles)
+			}
+			assert.Equal(t, tt.expected, got, "chunks should cover the files in order")
+		})
+	}
+}
+
+func TestRunActionlintOnFileParallel(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "actionlint-parallel-*")
+
+	// Fake docker: report one error per file, finishing the chunk with a.lock.yml last
+	binDir := filepath.Join(tmpDir, "bin")
+	require.NoError(t, os.MkdirAll(binDir, 0755), "should create bin dir")
+	script := `#!/bin/sh
+[ "$1" = "image" ] && exit 0
+out="["
+sep=""
+for arg in "$@"; do
+  case "$arg" in
+    *a.lock.yml) sleep 0.3 ;;
+  esac
+  case "$arg" in
+    *.lock.yml)
+      out="$out$sep{\"message\":\"problem in $arg\",\"filepath\":\"$arg\",\"line\":1,\"column\":1,\"kind\":\"expression\",\"snippet\":\"\",\"end_column\":2}"
+      sep="," ;;
+  esac
+done
+echo "$out]"
+exit 1
+`
+	require.NoError(t, os.WriteFile(filepath.Join(binDir, "docker"), []byte(script), 0755), "should write fake docker")
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	var lockFiles []string
+	for _, name := range []string{"a", "b", "c", "d"} {
+		lockFile := filepath.Join(tmpDir, name+".lock.yml")
+		require.NoError(t, os.WriteFile(lockFile, []byte("name: "+name+"\n"), 0644), "should write lock file")
+		lockFiles = append(lockFiles, lockFile)
+	}
+
+	originalStats, originalVersion := actionlintStats, actionlintVersion
+	defer func() { actionlintStats, actionlintVersion = originalStats, originalVersion }()
+	initActionlintStats()
+	actionlintVersion = "1.7.9"
+
+	var err error
+	output := testutil.CaptureStderr(t, func() {
+		err = runActionlintOnFile(lockFiles, false, false, 2)
+	})
+
+	require.NoError(t, err, "findings should not fail outside strict mode")
+	assert.Equal(t, 4, actionlintStats.TotalWorkflows, "all chunks should be counted")
+	assert.Equal(t, 4, actionlintStats.TotalErrors, "findings of all chunks should be merged")
+
+	positions := make([]int, 0, 4)
+	for _, name := range []string{"a", "b", "c", "d"} {
+		position := strings.Index(output, name+".lock.yml:1:1")
+		require.GreaterOrEqual(t, position, 0, "output should contain the finding for %s", name)
+		positions = append(positions, position)
+	}
+	assert.IsIncreasing(t, positions, "findings should be displayed sorted by file path")
+
+	err = runActionlintOnFile(lockFiles, false, true, 2)
+	require.Error(t, err, "findings should fail in strict mode")
+	assert.Contains(t, err.Error(), "found 4 errors", "strict error should count the findings of all chunks")
+}
+
+func TestResolveActionlintImageVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		flag    string
+		env     string
+		want    string
+		wantErr string
+	}{
+		{name: "unpinned"},
+		{name: "flag", flag: "1.7.7", want: "1.7.7"},
+		{name: "flag with v prefix", flag: "v1.7.7", want: "1.7.7"},
+		{name: "env", env: "1.6.27", want: "1.6.27"},
+		{name: "flag overrides env", flag: "1.7.7", env: "1.6.27", want: "1.7.7"},
+		{name: "invalid flag", flag: "latest", wantErr: "invalid --actionlint-version value \"latest\""},
+		{name: "invalid env", env: "1.7", wantErr: "invalid GH_AW_ACTIONLINT_VERSION value \"1.7\""},
+		{name: "image injection", flag: "1.7.7 --help", wantErr: "must be an actionlint release version"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(actionlintVersionEnvVar, tt.env)
+
+			got, err := resolveActionlintImageVersion(tt.flag)
+			if tt.wantErr != "" {
+				require.Error(t, err, "version should be rejected")
+				assert.Contains(t, err.Error(), tt.wantErr, "error should name the source and value")
+				return
+			}
+			require.NoError(t, err, "version should be accepted")
+			assert.Equal(t, tt.want, got, "resolved version")
+		})
+	}
+}
+
+func TestActionlintImagePinned(t *testing.T) {
+	defer setActionlintImageVersion("")
+
+	assert.Equal(t, ActionlintImage, actionlintImage(), "unpinned image should be latest")
+
+	actionlintVersion = "1.7.1"
+	setActionlintImageVersion("1.7.7")
+	assert.Equal(t, "rhysd/actionlint:1.7.7", actionlintImage(), "pinned image should use the release tag")
+	assert.Empty(t, actionlintVersion, "changing the pinned version should discard the cached version")
+}
+
+func TestFormatActionlintAnnotation(t *testing.T) {
+	finding := ActionlintError{Message: "SC2086: Double quote\nto prevent globbing", Filepath: ".github/workflows/test.lock.yml", Line: 7, Column: 14, Kind: "shellcheck"}
+
+	t.Run("lock file location", func(t *testing.T) {
+		position := console.ErrorPosition{File: finding.Filepath, Line: finding.Line, Column: finding.Column}
+		got := formatActionlintAnnotation(finding, position, false, "error")
+		assert.Equal(t, "::error file=.github/workflows/test.lock.yml,line=7,col=14::[shellcheck] SC2086: Double quote%0Ato prevent globbing", got)
+	})
+
+	t.Run("markdown location", func(t *testing.T) {
+		markdownPath := filepath.Join(testutil.TempDir(t, "actionlint-annotation-*"), "test.md")
+		position := console.ErrorPosition{File: markdownPath, Line: 6, Column: 10}
+		got := formatActionlintAnnotation(finding, position, true, "warning")
+		assert.Equal(t, "::warning file=test.md,line=6,col=10::[shellcheck] SC2086: Double quote%0Ato prevent globbing", got,
+			"mapped annotations should point at the repository-relative markdown file")
+	})
+}
+
+func TestActionlintAnnotationsEnabled(t *testing.T) {
+	tests := []struct {
+		name          string
+		config        CompileConfig
+		githubActions string
+		want          bool
+	}{
+		{name: "default", config: CompileConfig{Actionlint: true}},
+		{name: "forced", config: CompileConfig{Actionlint: true, ActionlintAnnotations: true}, want: true},
+		{name: "github actions", config: CompileConfig{Actionlint: true}, githubActions: "true", want: true},
+		{name: "github actions with json output", config: CompileConfig{Actionlint: true, JSONOutput: true}, githubActions: "true"},
+		{name: "github actions with json format", config: CompileConfig{Actionlint: true, ActionlintFormat: "json"}, githubActions: "true"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("GITHUB_ACTIONS", tt.githubActions)
+			assert.Equal(t, tt.want, actionlintAnnotationsEnabled(tt.config), "annotations enabled")
+		})
+	}
+}
+
+func TestMergeActionlintChunkResultFrontmatterIgnore(t *testing.T) {
+	originalStats := actionlintStats
+	defer func() { actionlintStats = originalStats }()
+	initActionlintStats()
+
+	lockFile := filepath.Join(testutil.TempDir(t, "actionlint-ignore-*"), "a.lock.yml")
+	actionlintStats.addIgnoredKinds(lockFile, []string{"runner-label"})
+
+	result := actionlintChunkResult{
+		chunk: actionlintChunk{lockFiles: []string{lockFile, "b.lock.yml"}, relPaths: []string{".github/workflows/a.lock.yml", ".github/workflows/b.lock.yml"}},
+		findings: []ActionlintError{
+			{Message: "label \"self-hosted-gpu\" is unknown", Filepath: ".github/workflows/a.lock.yml", Kind: "runner-label"},
+			{Message: "SC2086", Filepath: ".github/workflows/a.lock.yml", Kind: "shellcheck"},
+			{Message: "label \"self-hosted-gpu\" is unknown", Filepath: ".github/workflows/b.lock.yml", Kind: "runner-label"},
+		},
+	}
+	mergeActionlintChunkResult(&result)
+
+	require.Len(t, result.findings, 2, "ignored findings should be removed from the result")
+	assert.Equal(t, ".github/workflows/b.lock.yml", result.findings[1].Filepath, "kinds should only be ignored for the workflow that ignores them")
+	assert.Equal(t, 2, actionlintStats.TotalErrors, "ignored findings should not count as errors")
+	assert.Equal(t, 1, actionlintStats.TotalIgnored, "ignored findings should be counted")
+	assert.Equal(t, map[string]int{"runner-label": 1}, actionlintStats.IgnoredByKind, "ignored findings should be counted by kind")
+	require.NoError(t, actionlintStats.checkFailOn(actionlintFailOnNever, true), "never should not fail")
+	require.Error(t, actionlintStats.checkFailOn(actionlintFailOnError, true), "remaining errors should still fail")
+
+	output := testutil.CaptureStderr(t, displayActionlintSummary)
+	assert.Contains(t, output, "1 issue(s) suppressed by actionlint.ignore in workflow frontmatter:", "summary should report ignored findings")
+	assert.Contains(t, output, "runner-label: 1", "summary should break ignored findings down by kind")
+}
+
+func TestRunActionlintOnFileSelfHostedRunnerLabels(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "actionlint-runner-labels-*")
+	recordPath := filepath.Join(tmpDir, "config-path")
+
+	// Fake docker: report the custom label as unknown unless a mounted config lists it
+	binDir := filepath.Join(tmpDir, "bin")
+	require.NoError(t, os.MkdirAll(binDir, 0755), "should create bin dir")
+	script := `#!/bin/sh
+[ "$1" = "image" ] && exit 0
+config=""
+prev=""
+for arg in "$@"; do
+  if [ "$prev" = "-v" ]; then
+    case "$arg" in
+      *:/gh-aw-actionlint-config:ro) config="${arg%:/gh-aw-actionlint-config:ro}/actionlint.yaml" ;;
+    esac
+  fi
+  prev="$arg"
+done
+echo "$config" > "` + recordPath + `"
+if [ -n "$config" ] && grep -q "gpu-runner" "$config"; then
+  echo "[]"
+  exit 0
+fi
+echo '[{"message":"label \"gpu-runner\" is unknown","filepath":"a.lock.yml","line":1,"column":1,"kind":"runner-label","snippet":"","end_column":2}]'
+exit 1
+`
+	require.NoError(t, os.WriteFile(filepath.Join(binDir, "docker"), []byte(script), 0755), "should write fake docker")
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	lockFile := filepath.Join(tmpDir, "a.lock.yml")
+	require.NoError(t, os.WriteFile(lockFile, []byte("name: a\n"), 0644), "should write lock file")
+
+	originalStats, originalVersion := actionlintStats, actionlintVersion
+	defer func() { actionlintStats, actionlintVersion = originalStats, originalVersion }()
+	actionlintVersion = "1.7.9"
+
+	t.Run("without labels", func(t *testing.T) {
+		initActionlintStats()
+		testutil.CaptureStderr(t, func() {
+			require.NoError(t, runActionlintOnFile([]string{lockFile}, false, false, 1), "findings should not fail outside strict mode")
+		})
+		assert.Equal(t, 1, actionlintStats.ErrorsByKind["runner-label"], "unknown custom label should be reported")
+	})
+
+	t.Run("with labels", func(t *testing.T) {
+		initActionlintStats()
+		actionlintStats.addRunnerLabels([]string{"gpu-runner"})
+		testutil.CaptureStderr(t, func() {
+			require.NoError(t, runActionlintOnFile([]string{lockFile}, false, true, 1), "no findings should pass in strict mode")
+		})
+		assert.Zero(t, actionlintStats.ErrorsByKind["runner-label"], "declared custom label should not be reported")
+		assert.Zero(t, actionlintStats.TotalErrors, "no errors should be reported")
+
+		configPath, err := os.ReadFile(recordPath)
+		require.NoError(t, err, "fake docker should record the config path")
+		require.NotEmpty(t, strings.TrimSpace(string(configPath)), "config should be mounted")
+		assert.NoFileExists(t, strings.TrimSpace(string(configPath)), "generated config should be removed after the run")
+	})
+}
+
+func TestDisplayActionlintKindBreakdown(t *testing.T) {
+	byKind := map[string]int{"shellcheck": 5, "expression": 3, "action": 3, "glob": 1, "id": 1}
+
+	t.Run("top kinds", func(t *testing.T) {
+		output := testutil.CaptureStderr(t, func() { displayActionlintKindBreakdown(byKind, 3) })
+		assert.Equal(t, "  • shellcheck: 5\n  • action: 3\n  • expression: 3\n  • other (2 kinds): 2\n", output,
+			"top kinds should be sorted by count with an alphabetical tiebreak, and the rest collapsed")
+	})
+
+	t.Run("full breakdown", func(t *testing.T) {
+		output := testutil.CaptureStderr(t, func() { displayActionlintKindBreakdown(byKind, 0) })
+		assert.Equal(t, "  • shellcheck: 5\n  • action: 3\n  • expression: 3\n  • glob: 1\n  • id: 1\n", output, "all kinds should be listed")
+	})
+
+	t.Run("fewer kinds than top", func(t *testing.T) {
+		output := testutil.CaptureStderr(t, func() { displayActionlintKindBreakdown(byKind, 5) })
+		assert.NotContains(t, output, "other", "nothing should be collapsed")
+	})
+}
+
+func TestActionlintSummaryTop(t *testing.T) {
+	assert.Equal(t, actionlintDefaultSummaryTop, actionlintSummaryTop(0, false), "default")
+	assert.Equal(t, 3, actionlintSummaryTop(3, false), "--summary-top")
+	assert.Equal(t, 0, actionlintSummaryTop(0, true), "--summary-full")
+}
+
+func TestParseActionlintSeverities(t *testing.T) {
+	severities, err := parseActionlintSeverities([]string{"shellcheck=warning", "runner-label=error"})
+	require.NoError(t, err, "valid overrides should parse")
+	assert.Equal(t, actionlintSeverities{"shellcheck": "warning", "runner-label": "error"}, severities, "overrides by kind")
+
+	for _, value := range []string{"shellcheck", "=warning", "shellcheck=info"} {
+		_, err := parseActionlintSeverities([]string{value})
+		require.Error(t, err, "%q should be rejected", value)
+		assert.Contains(t, err.Error(), "invalid --severity value", "error should name the flag")
+	}
+}
+
+func TestActionlintStatsSeverityOverride(t *testing.T) {
+	originalStats := actionlintStats
+	defer func() { actionlintStats = originalStats }()
+	findings := []ActionlintError{
+		{Message: "SC2086", Filepath: "a.lock.yml", Kind: "shellcheck"},
+		{Message: "SC2046", Filepath: "a.lock.yml", Kind: "shellcheck"},
+		{Message: "label \"gpu\" is unknown", Filepath: "a.lock.yml", Kind: "runner-label"},
+	}
+
+	initActionlintStats()
+	actionlintStats.recordFindings(findings)
+	assert.Equal(t, 3, actionlintStats.TotalErrors, "all kinds should be errors by default")
+	assert.Zero(t, actionlintStats.TotalWarnings, "no kind should be a warning by default")
+
+	initActionlintStats()
+	actionlintStats.severities = actionlintSeverities{"shellcheck": actionlintSeverityWarning}
+	actionlintStats.recordFindings(findings)
+	assert.Equal(t, 1, actionlintStats.TotalErrors, "only runner-label should remain an error")
+	assert.Equal(t, 2, actionlintStats.TotalWarnings, "shellcheck should move to warnings")
+	assert.Equal(t, ActionlintFileStats{Errors: 1, Warnings: 2}, actionlintStats.IssuesByFile["a.lock.yml"], "per-file counts should follow the override")
+	require.Error(t, actionlintStats.checkFailOn(actionlintFailOnError, false), "runner-label should still fail --fail-on error")
+
+	initActionlintStats()
+	actionlintStats.severities = actionlintSeverities{"shellcheck": actionlintSeverityWarning, "runner-label": actionlintSeverityWarning}
+	actionlintStats.recordFindings(findings)
+	require.NoError(t, actionlintStats.checkFailOn(actionlintFailOnError, false), "warnings should not fail --fail-on error")
+	require.Error(t, actionlintStats.checkFailOn(actionlintFailOnWarning, false), "warnings should fail --fail-on warning")
+
+	output := testutil.CaptureStderr(t, func() {
+		actionlintStats.TotalWorkflows = 1
+		displayActionlintSummary()
+	})
+	assert.Contains(t, output, "Found 3 issue(s) (3 warning(s))", "summary should reflect the reclassification")
+}
+
+func TestParseActionlintOutputExported(t *testing.T) {
+	stdout := `[{"message":"label \"gpu\" is unknown","filepath":".github/workflows/test.lock.yml","line":10,"column":14,"kind":"runner-label","snippet":"runs-on: gpu","end_column":16}]`
+
+	errors, err := ParseActionlintOutput(stdout)
+	require.NoError(t, err, "valid output should parse")
+	assert.Equal(t, []ActionlintError{{
+		Message:   "label \"gpu\" is unknown",
+		Filepath:  ".github/workflows/test.lock.yml",
+		Line:      10,
+		Column:    14,
+		Kind:      "runner-label",
+		Snippet:   "runs-on: gpu",
+		EndColumn: 16,
+	}}, errors, "all fields should be parsed")
+
+	errors, err = ParseActionlintOutput("  \n")
+	require.NoError(t, err, "empty output should parse")
+	assert.Empty(t, errors, "empty output should have no errors")
+
+	_, err = ParseActionlintOutput("not json")
+	require.Error(t, err, "invalid output should fail to parse")
+}
+
+func TestActionlintOutputNoColor(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+	originalStats := actionlintStats
+	defer func() { actionlintStats = originalStats }()
+	initActionlintStats()
+	actionlintStats.TotalWorkflows = 1
+
+	stdout := `[{"message":"undefined variable","filepath":".github/workflows/test.lock.yml","line":3,"column":7,"kind":"expression","snippet":"","end_column":9}]`
+	output := testutil.CaptureStderr(t, func() {
+		findings, _, err := parseAndDisplayActionlintOutput(stdout, false, actionlintDisplayOptions{})
+		require.NoError(t, err, "valid output should parse")
+		actionlintStats.recordFindings(findings)
+		displayActionlintSummary()
+	})
+
+	assert.Contains(t, output, "undefined variable", "findings should be displayed")
+	assert.Contains(t, output, "Actionlint Summary", "summary should be displayed")
+	assert.NotContains(t, output, "\x1b[", "output should contain no ANSI escape sequences")
+}
+
+func TestResolveActionlintBinaryPath(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "actionlint-path-*")
+	binary := filepath.Join(tmpDir, "actionlint")
+	require.NoError(t, os.WriteFile(binary, []byte("#!/bin/sh\n"), 0755), "should write binary")
+	other := filepath.Join(tmpDir, "other-actionlint")
+	require.NoError(t, os.WriteFile(other, []byte("#!/bin/sh\n"), 0755), "should write binary")
+	notExecutable := filepath.Join(tmpDir, "not-executable")
+	require.NoError(t, os.WriteFile(notExecutable, []byte("#!/bin/sh\n"), 0644), "should write file")
+
+	tests := []struct {
+		name    string
+		flag    string
+		env     string
+		want    string
+		wantErr string
+	}{
+		{name: "docker"},
+		{name: "flag", flag: binary, want: binary},
+		{name: "env", env: other, want: other},
+		{name: "flag overrides env", flag: binary, env: other, want: binary},
+		{name: "missing binary", flag: filepath.Join(tmpDir, "missing"), wantErr: "invalid --actionlint-path value"},
+		{name: "not executable", env: notExecutable, wantErr: "invalid GH_AW_ACTIONLINT_PATH value"},
+		{name: "directory", flag: tmpDir, wantErr: "must be an executable actionlint binary"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(actionlintPathEnvVar, tt.env)
+
+			got, err := resolveActionlintBinaryPath(tt.flag)
+			if tt.wantErr != "" {
+				require.Error(t, err, "path should be rejected")
+				assert.Contains(t, err.Error(), tt.wantErr, "error should explain the problem")
+				return
+			}
+			require.NoError(t, err, "path should be accepted")
+			assert.Equal(t, tt.want, got, "resolved path")
+		})
+	}
+}
+
+func TestRunActionlintOnFileBinaryPath(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "actionlint-binary-*")
+	recordPath := filepath.Join(tmpDir, "args")
+
+	// Fake docker that fails the test if it is used at all
+	binDir := filepath.Join(tmpDir, "bin")
+	require.NoError(t, os.MkdirAll(binDir, 0755), "should create bin dir")
+	require.NoError(t, os.WriteFile(filepath.Join(binDir, "docker"), []byte("#!/bin/sh\nexit 2\n"), 0755), "should write fake docker")
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	// Fake actionlint binary: record the working directory and arguments, and report a finding
+	binary := filepath.Join(tmpDir, "actionlint")
+	script := `#!/bin/sh
+pwd > "` + recordPath + `"
+echo "$@" >> "` + recordPath + `"
+echo '[{"message":"undefined variable","filepath":"a.lock.yml","line":1,"column":1,"kind":"expression","snippet":"","end_column":2}]'
+exit 1
+`
+	require.NoError(t, os.WriteFile(binary, []byte(script), 0755), "should write fake actionlint")
+
+	lockFile := filepath.Join(tmpDir, "a.lock.yml")
+	require.NoError(t, os.WriteFile(lockFile, []byte("name: a\n"), 0644), "should write lock file")
+
+	originalStats, originalVersion, originalPath := actionlintStats, actionlintVersion, actionlintBinaryPath
+	defer func() {
+		actionlintStats, actionlintVersion, actionlintBinaryPath = originalStats, originalVersion, originalPath
+	}()
+	setActionlintBinaryPath(binary)
+	initActionlintStats()
+
+	testutil.CaptureStderr(t, func() {
+		require.NoError(t, runActionlintOnFile([]string{lockFile}, false, false, 1), "findings should not fail outside strict mode")
+	})
+
+	assert.Equal(t, 1, actionlintStats.ErrorsByKind["expression"], "findings of the binary should be reported")
+	assert.Zero(t, actionlintStats.IntegrationErrors, "docker should not be invoked")
+	assert.Empty(t, actionlintVersion, "the version of the binary should not be resolved")
+
+	gitRoot, err := findGitRoot()
+	require.NoError(t, err, "should find git root")
+	relPath, err := filepath.Rel(gitRoot, lockFile)
+	require.NoError(t, err, "should get relative path")
+	record, err := os.ReadFile(recordPath)
+	require.NoError(t, err, "fake actionlint should record its invocation")
+	lines := strings.Split(strings.TrimSpace(string(record)), "\n")
+	require.Len(t, lines, 2, "fake actionlint should record the directory and arguments")
+	assert.Equal(t, gitRoot, lines[0], "binary should run from the git root")
+	assert.Equal(t, "-format {{json .}} "+relPath, lines[1], "binary should get the repository-relative lock files")
+}
+
+func TestRunActionlintOnFileStrictCountsShownErrors(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "actionlint-strict-*")
+
+	// Fake actionlint binary: report one shellcheck finding
+	binary := filepath.Join(tmpDir, "actionlint")
+	script := `#!/bin/sh
+echo '[{"message":"SC2086","filepath":"a.lock.yml","line":1,"column":1,"kind":"shellcheck","snippet":"","end_column":2}]'
+exit 1
+`
+	require.NoError(t, os.WriteFile(binary, []byte(script), 0755), "should write fake actionlint")
+
+	lockFile := filepath.Join(tmpDir, "a.lock.yml")
+	require.NoError(t, os.WriteFile(lockFile, []byte("name: a\n"), 0644), "should write lock file")
+
+	originalStats, originalVersion, originalPath := actionlintStats, actionlintVersion, actionlintBinaryPath
+	defer func() {
+		actionlintStats, actionlintVersion, actionlintBinaryPath = originalStats, originalVersion, originalPath
+	}()
+	setActionlintBinaryPath(binary)
+
+	tests := []struct {
+		name       string
+		severities actionlintSeverities
+		filter     actionlintKindFilter
+		wantErr    bool
+	}{
+		{name: "error by default", wantErr: true},
+		{name: "reclassified as warning", severities: actionlintSeverities{"shellcheck": actionlintSeverityWarning}},
+		{name: "hidden by ignore-kind", filter: actionlintKindFilter{ignore: []string{"shellcheck"}}},
+		{name: "hidden by filter-kind", filter: actionlintKindFilter{only: []string{"expression"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			initActionlintStats()
+			actionlintStats.severities = tt.severities
+			actionlintStats.kindFilter = tt.filter
+
+			var err error
+			testutil.CaptureStderr(t, func() {
+				err = runActionlintOnFile([]string{lockFile}, false, true, 1)
+			})
+			if tt.wantErr {
+				require.Error(t, err, "shown errors should fail strict mode")
+				assert.Contains(t, err.Error(), "found 1 errors", "strict error should count the shown errors")
+				return
+			}
+			require.NoError(t, err, "warnings and hidden findings should not fail strict mode")
+			assert.Equal(t, 1, actionlintStats.TotalErrors+actionlintStats.TotalWarnings, "the finding should still be recorded")
+		})
+	}
+}
@@ -59,9 +59,12 @@ func runBatchLockFileTool(toolName string, lockFiles []string, verbose bool, str
 	return nil
 }
 
-// runBatchActionlint runs actionlint on all lock files in batch
-func runBatchActionlint(lockFiles []string, verbose bool, strict bool) error {
-	return runBatchLockFileTool("actionlint", lockFiles, verbose, strict, RunActionlintOnFiles)
+// runBatchActionlint runs actionlint on all lock files in batch, using up to jobs
+// concurrent actionlint invocations (GOMAXPROCS when jobs is 0)
+func runBatchActionlint(lockFiles []string, verbose bool, strict bool, jobs int) error {
+	return runBatchLockFileTool("actionlint", lockFiles, verbose, strict, func(lockFiles []string, verbose bool, strict bool) error {
+		return runActionlintOnFilesWithJobs(lockFiles, verbose, strict, jobs)
+	})
 }
 
 // runBatchZizmor runs zizmor security scanner on all lock files in batch
@@ -86,13 +89,13 @@ func runBatchPoutine(workflowDir string, verbose bool, strict bool) error {
 	return nil
 }
 
-// purgeOrphanedLockFiles removes orphaned .lock.yml files
+// purgeOrphanedLockFiles removes orphaned lock files (.lock.yml unless --lock-suffix is set)
 // These are lock files that exist but don't have a corresponding .md file
-func purgeOrphanedLockFiles(workflowsDir string, expectedLockFiles []string, verbose bool) error {
-	compileBatchOperationsLog.Printf("Purging orphaned lock files in %s", workflowsDir)
+func purgeOrphanedLockFiles(workflowsDir string, expectedLockFiles []string, lockFileSuffix string, verbose bool) error {
+	compileBatchOperationsLog.Printf("Purging orphaned %s files in %s", lockFileSuffix, workflowsDir)
 
-	// Find all existing .lock.yml files
-	existingLockFiles, err := filepath.Glob(filepath.Join(workflowsDir, "*.lock.yml"))
+	// Find all existing lock files
+	existingLockFiles, err := filepath.Glob(filepath.Join(workflowsDir, "*"+lockFileSuffix))
 	if err != nil {
 		return fmt.Errorf("failed to find existing lock files: %w", err)
 	}
@@ -103,7 +106,7 @@ func purgeOrphanedLockFiles(workflowsDir string, expectedLockFiles []string, ver
 	}
 
 	if verbose {
-		fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("Found %d existing .lock.yml files", len(existingLockFiles))))
+		fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("Found %d existing %s files", len(existingLockFiles), lockFileSuffix)))
 	}
 
 	// Build a set of expected lock files
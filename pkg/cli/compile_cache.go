@@ -0,0 +1,111 @@
+// This file provides the persistent compile cache integration for the compile command.
+//
+// This file contains functions that open the compile cache requested with
+// --cache-dir and use it to skip recompiling unchanged workflows.
+//
+// # Organization Rationale
+//
+// These functions are grouped here because they:
+//   - Handle a single optional optimization (the persistent compile cache)
+//   - Keep cache concerns out of the per-file processing and orchestration loops
+//
+// # Key Functions
+//
+//   - openCompileCache() - Open the compile cache for a compile run
+//   - compileCacheOptions() - Fingerprint the compile options that affect output
+//   - restoreCachedLockFile() - Write cached lock content for an unchanged workflow
+//   - storeCompiledLockFile() - Store a freshly compiled lock file in the cache
+
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/workflow"
+)
+
+var compileCacheLog = logger.New("cli:compile_cache")
+
+// openCompileCache opens the compile cache configured by --cache-dir. Returns nil when
+// no cache directory is set or when the run needs fresh compilation of every workflow
+// (--no-emit, --validate, --refresh-stop-time, --force-refresh-action-pins,
+// --check-secrets-exist). A cache that
+// cannot be opened is reported as a warning and compilation proceeds without it.
+func openCompileCache(compiler *workflow.Compiler, config CompileConfig) *workflow.CompileCache {
+	if config.CacheDir == "" {
+		return nil
+	}
+	if config.NoEmit || config.Validate || config.RefreshStopTime || config.ForceRefreshActionPins || config.CheckSecretsExist {
+		compileCacheLog.Print("Compile cache disabled for this run by compile options")
+		return nil
+	}
+
+	cache, err := workflow.NewCompileCache(config.CacheDir, compileCacheVersion(compiler.GetVersion()), compileCacheOptions(compiler, config))
+	if err != nil {
+		if !config.JSONOutput {
+			fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Compile cache disabled: %v", err)))
+		}
+		return nil
+	}
+	return cache
+}
+
+// compileCacheVersion returns the version used to invalidate the compile cache. Development
+// builds all report the same version, so the executable's size and modification time are
+// appended to invalidate the cache whenever the binary is rebuilt.
+func compileCacheVersion(version string) string {
+	if version != "dev" {
+		return version
+	}
+	executable, err := os.Executable()
+	if err != nil {
+		return version
+	}
+	info, err := os.Stat(executable)
+	if err != nil {
+		return version
+	}
+	return fmt.Sprintf("%s+%d.%d", version, info.Size(), info.ModTime().UnixNano())
+}
+
+// compileCacheOptions fingerprints the compile options that affect the generated lock files
+func compileCacheOptions(compiler *workflow.Compiler, config CompileConfig) string {
+	return fmt.Sprintf("engine=%s;action-mode=%s;action-tag=%s;strict=%t;secret-naming=%s;trial=%t;logical-repo=%s;lock-suffix=%s",
+		config.EngineOverride, compiler.GetActionMode(), compiler.GetActionTag(), config.Strict, compiler.GetSecretNamingPolicy(), config.TrialMode, config.TrialLogicalRepoSlug, compiler.LockFileSuffix())
+}
+
+// restoreCachedLockFile writes the cached lock content for key to lockFile when present.
+// The lock file is only rewritten when its content differs. Returns true on a cache hit.
+func restoreCachedLockFile(cache *workflow.CompileCache, key string, lockFile string) bool {
+	lockContent, ok := cache.Get(key)
+	if !ok {
+		return false
+	}
+	if existing, err := os.ReadFile(lockFile); err == nil && bytes.Equal(existing, []byte(lockContent)) {
+		compileCacheLog.Printf("Lock file is up to date: %s", lockFile)
+		return true
+	}
+	if err := os.WriteFile(lockFile, []byte(lockContent), 0644); err != nil {
+		compileCacheLog.Printf("Failed to restore cached lock file %s: %v", lockFile, err)
+		return false
+	}
+	compileCacheLog.Printf("Restored lock file from compile cache: %s", lockFile)
+	return true
+}
+
+// storeCompiledLockFile stores the freshly compiled lockFile in the cache under key.
+// Failures are logged and otherwise ignored since the cache is only an optimization.
+func storeCompiledLockFile(cache *workflow.CompileCache, key string, lockFile string) {
+	lockContent, err := os.ReadFile(lockFile)
+	if err != nil {
+		compileCacheLog.Printf("Failed to read compiled lock file for caching %s: %v", lockFile, err)
+		return
+	}
+	if err := cache.Put(key, string(lockContent)); err != nil {
+		compileCacheLog.Printf("Failed to store compile cache entry for %s: %v", lockFile, err)
+	}
+}
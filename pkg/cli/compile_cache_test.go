@@ -0,0 +1,93 @@
+//go:build !integration
+
+package cli
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCompileWithCacheDir tests that --cache-dir restores unchanged lock files from the cache
+func TestCompileWithCacheDir(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "test-*")
+	testFile := filepath.Join(tmpDir, "cached-workflow.md")
+	lockFile := filepath.Join(tmpDir, "cached-workflow.lock.yml")
+	cacheDir := filepath.Join(tmpDir, "cache")
+
+	workflowContent := `---
+on:
+  issues:
+    types: [opened]
+permissions:
+  contents: read
+  issues: read
+engine: copilot
+---
+
+# Cached Workflow
+
+This is a test workflow for the compile cache.
+`
+	require.NoError(t, os.WriteFile(testFile, []byte(workflowContent), 0644), "should write test workflow")
+
+	config := CompileConfig{
+		MarkdownFiles: []string{testFile},
+		CacheDir:      cacheDir,
+	}
+
+	_, err := CompileWorkflows(context.Background(), config)
+	require.NoError(t, err, "first compilation should succeed")
+	compiled, err := os.ReadFile(lockFile)
+	require.NoError(t, err, "lock file should be written")
+
+	entries, err := filepath.Glob(filepath.Join(cacheDir, "*.lock.yml"))
+	require.NoError(t, err, "should list cache entries")
+	require.Len(t, entries, 1, "compiled lock file should be cached")
+
+	// Remove the lock file; the second run should restore it from the cache entry
+	require.NoError(t, os.Remove(lockFile), "should remove lock file")
+	require.NoError(t, os.WriteFile(entries[0], append(compiled, []byte("# cached\n")...), 0644), "should mark cache entry")
+
+	_, err = CompileWorkflows(context.Background(), config)
+	require.NoError(t, err, "second compilation should succeed")
+	restored, err := os.ReadFile(lockFile)
+	require.NoError(t, err, "lock file should be restored")
+	assert.Equal(t, string(compiled)+"# cached\n", string(restored), "unchanged workflow should be restored from the cache")
+
+	// Changing the workflow should bypass the stale entry
+	require.NoError(t, os.WriteFile(testFile, []byte(workflowContent+"\nMore instructions.\n"), 0644), "should update workflow")
+	_, err = CompileWorkflows(context.Background(), config)
+	require.NoError(t, err, "third compilation should succeed")
+	recompiled, err := os.ReadFile(lockFile)
+	require.NoError(t, err, "lock file should be rewritten")
+	assert.NotContains(t, string(recompiled), "# cached", "changed workflow should be recompiled")
+}
+
+// TestOpenCompileCacheDisabled tests that the cache is not used for runs that must recompile
+func TestOpenCompileCacheDisabled(t *testing.T) {
+	cacheDir := filepath.Join(testutil.TempDir(t, "test-*"), "cache")
+
+	tests := []struct {
+		name   string
+		config CompileConfig
+	}{
+		{name: "no cache dir", config: CompileConfig{}},
+		{name: "no emit", config: CompileConfig{CacheDir: cacheDir, NoEmit: true}},
+		{name: "validate", config: CompileConfig{CacheDir: cacheDir, Validate: true}},
+		{name: "refresh stop time", config: CompileConfig{CacheDir: cacheDir, RefreshStopTime: true}},
+		{name: "force refresh action pins", config: CompileConfig{CacheDir: cacheDir, ForceRefreshActionPins: true}},
+		{name: "check secrets exist", config: CompileConfig{CacheDir: cacheDir, CheckSecretsExist: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Nil(t, openCompileCache(nil, tt.config), "compile cache should be disabled")
+		})
+	}
+}
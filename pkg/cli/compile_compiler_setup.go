@@ -21,6 +21,7 @@
 //   - configureCompilerFlags() - Sets validation, strict mode, trial mode flags
 //   - setupActionMode() - Configures action script inlining mode
 //   - setupRepositoryContext() - Sets repository slug for schedule scattering
+//   - validateSecretNamingConfig() - Validates the --secret-naming policy
 //
 // These functions abstract compiler setup, allowing the main compile
 // orchestrator to focus on coordination while these handle configuration.
@@ -95,6 +96,7 @@ func createAndConfigureCompiler(config CompileConfig) *workflow.Compiler {
 		workflow.WithVerbose(config.Verbose),
 		workflow.WithEngineOverride(config.EngineOverride),
 		workflow.WithFailFast(config.FailFast),
+		workflow.WithLockFileSuffix(config.LockFileSuffix),
 	)
 	compileCompilerSetupLog.Print("Created compiler instance")
 
@@ -127,6 +129,9 @@ func configureCompilerFlags(compiler *workflow.Compiler, config CompileConfig) {
 	// Set strict mode if specified
 	compiler.SetStrictMode(config.Strict)
 
+	// Record lock file source maps so actionlint findings can be shown at their markdown location
+	compiler.SetBuildSourceMaps(config.Actionlint && !config.NoEmit)
+
 	// Set trial mode if specified
 	if config.TrialMode {
 		compileCompilerSetupLog.Printf("Enabling trial mode: repoSlug=%s", config.TrialLogicalRepoSlug)
@@ -147,6 +152,18 @@ func configureCompilerFlags(compiler *workflow.Compiler, config CompileConfig) {
 	if config.ForceRefreshActionPins {
 		compileCompilerSetupLog.Print("Force refresh action pins enabled: will clear cache and resolve all actions from GitHub API")
 	}
+
+	// Set check secrets exist flag
+	compiler.SetCheckSecretsExist(config.CheckSecretsExist)
+	if config.CheckSecretsExist {
+		compileCompilerSetupLog.Print("Secrets existence check enabled: will list repository and organization secret names from GitHub API")
+	}
+
+	// Set secret naming policy (validated by validateSecretNamingConfig)
+	if config.SecretNaming != "" {
+		compiler.SetSecretNamingPolicy(workflow.SecretNamingPolicy(config.SecretNaming))
+		compileCompilerSetupLog.Printf("Secret naming policy set to: %s", config.SecretNaming)
+	}
 }
 
 // setupActionMode configures the action script inlining mode
@@ -206,3 +223,17 @@ func validateActionModeConfig(actionMode string) error {
 
 	return nil
 }
+
+// validateSecretNamingConfig validates the secret naming policy configuration
+func validateSecretNamingConfig(secretNaming string) error {
+	if secretNaming == "" {
+		return nil
+	}
+
+	policy := workflow.SecretNamingPolicy(secretNaming)
+	if !policy.IsValid() {
+		return fmt.Errorf("invalid secret naming policy '%s'. Must be 'strict' or 'permissive'", secretNaming)
+	}
+
+	return nil
+}
@@ -0,0 +1,32 @@
+//go:build !integration
+
+package cli
+
+import (
+	"testing"
+
+	"github.com/github/gh-aw/pkg/workflow"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateSecretNamingConfig(t *testing.T) {
+	require.NoError(t, validateSecretNamingConfig(""), "empty policy should be accepted")
+	require.NoError(t, validateSecretNamingConfig("strict"), "strict should be accepted")
+	require.NoError(t, validateSecretNamingConfig("permissive"), "permissive should be accepted")
+
+	err := validateSecretNamingConfig("lenient")
+	require.Error(t, err, "unknown policy should be rejected")
+	assert.Contains(t, err.Error(), "'strict' or 'permissive'", "error should list the valid policies")
+}
+
+func TestConfigureCompilerFlagsSecretNaming(t *testing.T) {
+	compiler := workflow.NewCompiler()
+	configureCompilerFlags(compiler, CompileConfig{})
+	assert.Equal(t, workflow.SecretNamingStrict, compiler.GetSecretNamingPolicy(), "policy should default to strict")
+	assert.False(t, compiler.HasSecretNamingPolicy(), "policy should not be explicit without --secret-naming")
+
+	configureCompilerFlags(compiler, CompileConfig{SecretNaming: "permissive"})
+	assert.Equal(t, workflow.SecretNamingPermissive, compiler.GetSecretNamingPolicy(), "--secret-naming should set the policy")
+	assert.True(t, compiler.HasSecretNamingPolicy(), "--secret-naming should make the policy explicit")
+}
@@ -10,30 +10,56 @@ var compileConfigLog = logger.New("cli:compile_config")
 
 // CompileConfig holds configuration options for compiling workflows
 type CompileConfig struct {
-	MarkdownFiles          []string // Files to compile (empty for all files)
-	Verbose                bool     // Enable verbose output
-	EngineOverride         string   // Override AI engine setting
-	Validate               bool     // Enable schema validation
-	Watch                  bool     // Enable watch mode
-	WorkflowDir            string   // Custom workflow directory
-	SkipInstructions       bool     // Deprecated: Instructions are no longer written during compilation
-	NoEmit                 bool     // Validate without generating lock files
-	Purge                  bool     // Remove orphaned lock files
-	TrialMode              bool     // Enable trial mode (suppress safe outputs)
-	TrialLogicalRepoSlug   string   // Target repository for trial mode
-	Strict                 bool     // Enable strict mode validation
-	Dependabot             bool     // Generate Dependabot manifests for npm dependencies
-	ForceOverwrite         bool     // Force overwrite of existing files (dependabot.yml)
-	RefreshStopTime        bool     // Force regeneration of stop-after times instead of preserving existing ones
-	ForceRefreshActionPins bool     // Force refresh of action pins by clearing cache and resolving from GitHub API
-	Zizmor                 bool     // Run zizmor security scanner on generated .lock.yml files
-	Poutine                bool     // Run poutine security scanner on generated .lock.yml files
-	Actionlint             bool     // Run actionlint linter on generated .lock.yml files
-	JSONOutput             bool     // Output validation results as JSON
-	ActionMode             string   // Action script inlining mode: inline, dev, or release
-	ActionTag              string   // Override action SHA or tag for actions/setup (overrides action-mode to release)
-	Stats                  bool     // Display statistics table sorted by file size
-	FailFast               bool     // Stop at first error instead of collecting all errors
+	MarkdownFiles           []string // Files to compile (empty for all files)
+	Verbose                 bool     // Enable verbose output
+	EngineOverride          string   // Override AI engine setting
+	Validate                bool     // Enable schema validation
+	Watch                   bool     // Enable watch mode
+	WorkflowDir             string   // Custom workflow directory
+	SkipInstructions        bool     // Deprecated: Instructions are no longer written during compilation
+	NoEmit                  bool     // Validate without generating lock files
+	Purge                   bool     // Remove orphaned lock files
+	TrialMode               bool     // Enable trial mode (suppress safe outputs)
+	TrialLogicalRepoSlug    string   // Target repository for trial mode
+	Strict                  bool     // Enable strict mode validation
+	Dependabot              bool     // Generate Dependabot manifests for npm dependencies
+	ForceOverwrite          bool     // Force overwrite of existing files (dependabot.yml)
+	RefreshStopTime         bool     // Force regeneration of stop-after times instead of preserving existing ones
+	ForceRefreshActionPins  bool     // Force refresh of action pins by clearing cache and resolving from GitHub API
+	CheckSecretsExist       bool     // Warn about referenced secrets missing from the repository and its organization (queries the GitHub API)
+	SecretNaming            string   // Naming policy for referenced secrets: "strict" or "permissive" (empty means strict rules, reported as warnings)
+	Zizmor                  bool     // Run zizmor security scanner on generated .lock.yml files
+	Poutine                 bool     // Run poutine security scanner on generated .lock.yml files
+	Actionlint              bool     // Run actionlint linter on generated .lock.yml files
+	JSONOutput              bool     // Output validation results as JSON
+	ActionMode              string   // Action script inlining mode: inline, dev, or release
+	ActionTag               string   // Override action SHA or tag for actions/setup (overrides action-mode to release)
+	Stats                   bool     // Display statistics table sorted by file size
+	FailFast                bool     // Stop at first error instead of collecting all errors
+	ManifestPath            string   // Write a machine-readable JSON compile manifest to this path
+	CacheDir                string   // Persistent compile cache directory for reusing unchanged lock files across invocations
+	SummaryOnIssuesOnly     bool     // Only display the actionlint summary when actionlint reports issues
+	ActionlintSARIFPath     string   // Write actionlint results as a SARIF 2.1.0 log to this path
+	ActionlintFormat        string   // Output format for actionlint results: "text" (default) or "json"
+	ActionlintFilterKinds   []string // Only display actionlint errors of these kinds
+	ActionlintIgnoreKinds   []string // Hide actionlint errors of these kinds
+	ActionlintFailOn        string   // Fail when actionlint reports findings at this severity: "error", "warning", or "never"
+	ActionlintFailOnHidden  bool     // Count findings hidden by the kind filters towards ActionlintFailOn
+	ActionlintJobs          int      // Maximum number of concurrent actionlint invocations (0 means GOMAXPROCS)
+	ActionlintVersion       string   // actionlint release to run (overrides GH_AW_ACTIONLINT_VERSION; empty means latest)
+	ActionlintAnnotations   bool     // Write actionlint findings as GitHub Actions annotations (automatic when GITHUB_ACTIONS=true)
+	ActionlintChangedOnly   bool     // Only run actionlint on workflows changed relative to ActionlintBaseRef
+	ActionlintBaseRef       string   // Git base ref for ActionlintChangedOnly (defaults to origin/$GITHUB_BASE_REF)
+	ActionlintBaseline      string   // Suppress the actionlint findings recorded in this baseline file
+	ActionlintWriteBaseline string   // Write the current actionlint findings to this baseline file
+	ActionlintSummaryTop    int      // Number of kinds listed in the actionlint summary (0 means the default of 10)
+	ActionlintSummaryFull   bool     // List all kinds in the actionlint summary
+	ActionlintSeverities    []string // Severity overrides for actionlint kinds, as kind=error or kind=warning
+	ActionlintDedupe        bool     // Collapse identical actionlint findings across files
+	ActionlintPath          string   // Pre-installed actionlint binary to run instead of Docker (overrides GH_AW_ACTIONLINT_PATH)
+	ActionlintPullAttempts  int      // Attempts at pulling the actionlint image on transient failures (0 means the default of 3)
+	LockFileSuffix          string   // File suffix of generated lock files (empty for the default .lock.yml)
+	LintReport              bool     // Print an aggregate report of diagnostics grouped by file instead of the compilation summary
 }
 
 // WorkflowFailure represents a failed workflow with its error count
@@ -66,6 +92,9 @@ type ValidationResult struct {
 	Errors       []CompileValidationError `json:"errors"`
 	Warnings     []CompileValidationError `json:"warnings"`
 	CompiledFile string                   `json:"compiled_file,omitempty"`
+
+	// path is the workflow file path, used to key lint report entries
+	path string
 }
 
 // sanitizeValidationResults creates a sanitized copy of validation results with all
@@ -92,6 +121,7 @@ func sanitizeValidationResults(results []ValidationResult) []ValidationResult {
 			Workflow:     result.Workflow,
 			Valid:        result.Valid,
 			CompiledFile: result.CompiledFile,
+			path:         result.path,
 			Errors:       sliceutil.Map(result.Errors, sanitizeError),
 			Warnings:     sliceutil.Map(result.Warnings, sanitizeError),
 		}
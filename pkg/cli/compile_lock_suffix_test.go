@@ -0,0 +1,78 @@
+//go:build !integration
+
+package cli
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCompileWorkflows_LockSuffixValidation tests --lock-suffix validation
+// Uses the fast validateCompileConfig function instead of full compilation
+func TestCompileWorkflows_LockSuffixValidation(t *testing.T) {
+	tests := []struct {
+		name        string
+		suffix      string
+		expectError bool
+		errorMsg    string
+	}{
+		{name: "default empty suffix", suffix: ""},
+		{name: "custom yml suffix", suffix: ".gen.yml"},
+		{name: "custom yaml suffix", suffix: ".lock.yaml"},
+		{name: "non-yaml extension", suffix: ".lock.json", expectError: true, errorMsg: "must end in .yml or .yaml"},
+		{name: "bare extension", suffix: ".yml", expectError: true, errorMsg: "name the lock files before the extension"},
+		{name: "missing leading dot", suffix: "gen.yml", expectError: true, errorMsg: "must start with '.'"},
+		{name: "path separator", suffix: ".gen/x.yml", expectError: true, errorMsg: "must not contain path separators"},
+		{name: "glob character", suffix: ".*.yml", expectError: true, errorMsg: "glob characters"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateCompileConfig(CompileConfig{LockFileSuffix: tt.suffix})
+			if !tt.expectError {
+				assert.NoError(t, err, "suffix %q should be accepted", tt.suffix)
+				return
+			}
+			require.Error(t, err, "suffix %q should be rejected", tt.suffix)
+			assert.Contains(t, err.Error(), tt.errorMsg, "error should explain why the suffix is rejected")
+		})
+	}
+}
+
+// TestCompileWorkflows_LockSuffix tests that --lock-suffix changes the generated lock file name
+func TestCompileWorkflows_LockSuffix(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "test-*")
+	testFile := filepath.Join(tmpDir, "suffix-workflow.md")
+	workflowContent := `---
+on:
+  issues:
+    types: [opened]
+permissions:
+  contents: read
+  issues: read
+engine: copilot
+---
+
+# Suffix Workflow
+
+This is a test workflow for a custom lock file suffix.
+`
+	require.NoError(t, os.WriteFile(testFile, []byte(workflowContent), 0644), "should write test workflow")
+
+	_, err := CompileWorkflows(context.Background(), CompileConfig{
+		MarkdownFiles:  []string{testFile},
+		LockFileSuffix: ".gen.yml",
+	})
+	require.NoError(t, err, "compilation should succeed")
+
+	compiled, err := os.ReadFile(filepath.Join(tmpDir, "suffix-workflow.gen.yml"))
+	require.NoError(t, err, "lock file should be written with the custom suffix")
+	assert.Contains(t, string(compiled), `GH_AW_WORKFLOW_FILE: "suffix-workflow.gen.yml"`, "activation job should reference the custom lock file name")
+	assert.NoFileExists(t, filepath.Join(tmpDir, "suffix-workflow.lock.yml"), "default lock file should not be written")
+}
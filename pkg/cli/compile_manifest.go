@@ -0,0 +1,86 @@
+// This file provides compile manifest generation for workflow compilation.
+//
+// This file contains functions that collect manifest entries for successfully
+// compiled workflows and write them as a JSON document when --manifest is set.
+//
+// # Organization Rationale
+//
+// These functions are grouped here because they:
+//   - Handle a single optional output artifact (the compile manifest)
+//   - Are invoked from both specific-file and directory-wide compilation
+//   - Keep manifest concerns out of the main orchestration loops
+//
+// # Key Functions
+//
+// Manifest Generation:
+//   - buildManifestEntry() - Build a manifest entry for a compiled workflow
+//   - writeCompileManifest() - Write collected entries to the manifest file
+
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/workflow"
+)
+
+var compileManifestLog = logger.New("cli:compile_manifest")
+
+// buildManifestEntry builds a manifest entry for a successfully compiled workflow file.
+// Paths are recorded relative to the repository root when possible.
+func buildManifestEntry(fileResult compileWorkflowFileResult, resolvedFile string) workflow.WorkflowManifestEntry {
+	sourcePath, err := getRepositoryRelativePath(resolvedFile)
+	if err != nil {
+		sourcePath = resolvedFile
+	}
+
+	lockPath := ""
+	lockContent := ""
+	if fileResult.lockFile != "" {
+		lockPath, err = getRepositoryRelativePath(fileResult.lockFile)
+		if err != nil {
+			lockPath = fileResult.lockFile
+		}
+		// The lock file is absent in --no-emit mode; secrets are simply left empty
+		if content, readErr := os.ReadFile(fileResult.lockFile); readErr == nil {
+			lockContent = string(content)
+		}
+	}
+
+	return workflow.BuildWorkflowManifestEntry(fileResult.workflowData, sourcePath, lockPath, lockContent)
+}
+
+// writeCompileManifest writes the compile manifest JSON to manifestPath
+func writeCompileManifest(manifestPath string, entries []workflow.WorkflowManifestEntry, verbose bool) error {
+	compileManifestLog.Printf("Writing compile manifest: path=%s, workflows=%d", manifestPath, len(entries))
+
+	if entries == nil {
+		entries = []workflow.WorkflowManifestEntry{}
+	}
+	manifest := workflow.CompileManifest{Workflows: entries}
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal compile manifest: %w", err)
+	}
+
+	if dir := filepath.Dir(manifestPath); dir != "" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create manifest directory: %w", err)
+		}
+	}
+
+	if err := os.WriteFile(manifestPath, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write compile manifest: %w", err)
+	}
+
+	if verbose {
+		fmt.Fprintln(os.Stderr, console.FormatSuccessMessage(fmt.Sprintf("Wrote compile manifest for %d workflow(s) to %s", len(entries), manifestPath)))
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+//go:build !integration
+
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/github/gh-aw/pkg/workflow"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCompileManifestOutput tests that --manifest writes a JSON manifest for compiled workflows
+func TestCompileManifestOutput(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "test-*")
+	testFile := filepath.Join(tmpDir, "manifest-workflow.md")
+	manifestPath := filepath.Join(tmpDir, "out", "manifest.json")
+
+	workflowContent := `---
+on:
+  issues:
+    types: [opened]
+permissions:
+  contents: read
+  issues: read
+engine: copilot
+features:
+  mcp-gateway: true
+---
+
+# Manifest Workflow
+
+This is a test workflow for manifest output.
+`
+	require.NoError(t, os.WriteFile(testFile, []byte(workflowContent), 0644), "should write test workflow")
+
+	config := CompileConfig{
+		MarkdownFiles: []string{testFile},
+		JSONOutput:    true,
+		ManifestPath:  manifestPath,
+	}
+
+	// Silence JSON validation output on stdout
+	oldStdout := os.Stdout
+	devNull, err := os.Open(os.DevNull)
+	require.NoError(t, err, "should open dev null")
+	os.Stdout = devNull
+	_, err = CompileWorkflows(context.Background(), config)
+	os.Stdout = oldStdout
+	devNull.Close()
+	require.NoError(t, err, "compilation should succeed")
+
+	content, err := os.ReadFile(manifestPath)
+	require.NoError(t, err, "manifest should be written")
+
+	var manifest workflow.CompileManifest
+	require.NoError(t, json.Unmarshal(content, &manifest), "manifest should be valid JSON")
+	require.Len(t, manifest.Workflows, 1, "manifest should describe one workflow")
+
+	entry := manifest.Workflows[0]
+	assert.Contains(t, entry.Source, "manifest-workflow.md", "source path should be recorded")
+	assert.Contains(t, entry.LockFile, "manifest-workflow.lock.yml", "lock path should be recorded")
+	assert.Contains(t, entry.Triggers, "issues", "issues trigger should be listed")
+	assert.Contains(t, entry.ConcurrencyGroup, "github.event.issue.number", "concurrency group should be resolved")
+	assert.Contains(t, entry.Features, "mcp-gateway", "feature keys should be listed")
+	assert.Equal(t, "read", entry.Permissions["contents"], "permissions should be computed")
+	assert.NotEmpty(t, entry.Secrets, "secrets referenced by the lock file should be listed")
+}
+
+// TestWriteCompileManifestEmpty tests that an empty manifest has an empty workflows array
+func TestWriteCompileManifestEmpty(t *testing.T) {
+	manifestPath := filepath.Join(testutil.TempDir(t, "test-*"), "manifest.json")
+
+	require.NoError(t, writeCompileManifest(manifestPath, nil, false), "writing an empty manifest should succeed")
+
+	content, err := os.ReadFile(manifestPath)
+	require.NoError(t, err, "manifest should be written")
+	assert.JSONEq(t, `{"workflows": []}`, string(content), "empty manifest should contain an empty workflows array")
+}
@@ -57,6 +57,9 @@ func compileSpecificFiles(
 	var errorCount int
 	var lockFilesForActionlint []string
 	var lockFilesForZizmor []string
+	var manifestEntries []workflow.WorkflowManifestEntry
+	compileCache := openCompileCache(compiler, config)
+	changedFiles := openActionlintChangedFiles(config)
 
 	// Compile each specified file
 	for _, markdownFile := range config.MarkdownFiles {
@@ -96,7 +99,7 @@ func compileSpecificFiles(
 		fileResult := compileWorkflowFile(
 			compiler, resolvedFile, config.Verbose, config.JSONOutput,
 			config.NoEmit, false, false, false, // Disable per-file security tools
-			config.Strict, shouldValidate,
+			config.Strict, shouldValidate, compileCache,
 		)
 
 		if !fileResult.success {
@@ -111,12 +114,20 @@ func compileSpecificFiles(
 		} else {
 			compiledCount++
 			workflowDataList = append(workflowDataList, fileResult.workflowData)
+			if config.ManifestPath != "" {
+				manifestEntries = append(manifestEntries, buildManifestEntry(fileResult, resolvedFile))
+			}
 
 			// Collect lock files for batch security tools
 			if !config.NoEmit && fileResult.lockFile != "" {
 				if _, err := os.Stat(fileResult.lockFile); err == nil {
-					if config.Actionlint {
+					if config.Actionlint && changedFiles.includes(resolvedFile, fileResult.lockFile) {
 						lockFilesForActionlint = append(lockFilesForActionlint, fileResult.lockFile)
+						actionlintStats.addSourceMap(fileResult.lockFile, compiler.SourceMap(fileResult.lockFile))
+						if fileResult.workflowData != nil {
+							actionlintStats.addIgnoredKinds(fileResult.lockFile, fileResult.workflowData.ActionlintIgnore)
+							actionlintStats.addRunnerLabels(fileResult.workflowData.SelfHostedRunnerLabels)
+						}
 					}
 					if config.Zizmor {
 						lockFilesForZizmor = append(lockFilesForZizmor, fileResult.lockFile)
@@ -130,7 +141,7 @@ func compileSpecificFiles(
 
 	// Run batch actionlint on all collected lock files
 	if config.Actionlint && !config.NoEmit && len(lockFilesForActionlint) > 0 {
-		if err := runBatchActionlint(lockFilesForActionlint, config.Verbose && !config.JSONOutput, config.Strict); err != nil {
+		if err := runBatchActionlint(lockFilesForActionlint, config.Verbose && !config.JSONOutput, config.Strict, config.ActionlintJobs); err != nil {
 			if config.Strict {
 				return workflowDataList, err
 			}
@@ -163,13 +174,23 @@ func compileSpecificFiles(
 	// Display schedule warnings
 	displayScheduleWarnings(compiler, config.JSONOutput)
 
+	// Emit compiler warnings as annotations when running in GitHub Actions
+	displayWarningAnnotations(compiler, config.JSONOutput)
+
 	// Post-processing
 	if err := runPostProcessing(compiler, workflowDataList, config, compiledCount); err != nil {
 		return workflowDataList, err
 	}
 
+	// Write compile manifest if requested
+	if config.ManifestPath != "" {
+		if err := writeCompileManifest(config.ManifestPath, manifestEntries, config.Verbose && !config.JSONOutput); err != nil {
+			return workflowDataList, err
+		}
+	}
+
 	// Output results
-	if err := outputResults(stats, validationResults, config); err != nil {
+	if err := outputResults(compiler, stats, validationResults, config); err != nil {
 		return workflowDataList, err
 	}
 
@@ -227,7 +248,7 @@ func compileAllFilesInDirectory(
 	// Handle purge logic: collect existing files before compilation
 	var purgeData *purgeTrackingData
 	if config.Purge {
-		purgeData = collectPurgeData(workflowsDir, mdFiles, config.Verbose)
+		purgeData = collectPurgeData(workflowsDir, mdFiles, config.LockFileSuffix, config.Verbose)
 	}
 
 	// Enable validation automatically when force-refresh-action-pins is used
@@ -243,6 +264,10 @@ func compileAllFilesInDirectory(
 	var errorCount int
 	var lockFilesForActionlint []string
 	var lockFilesForZizmor []string
+	var manifestEntries []workflow.WorkflowManifestEntry
+	var workflowNames []workflow.WorkflowNameEntry
+	compileCache := openCompileCache(compiler, config)
+	changedFiles := openActionlintChangedFiles(config)
 
 	for _, file := range mdFiles {
 		stats.Total++
@@ -251,7 +276,7 @@ func compileAllFilesInDirectory(
 		fileResult := compileWorkflowFile(
 			compiler, file, config.Verbose, config.JSONOutput,
 			config.NoEmit, false, false, false, // Disable per-file security tools
-			config.Strict, shouldValidate,
+			config.Strict, shouldValidate, compileCache,
 		)
 
 		if !fileResult.success {
@@ -266,12 +291,23 @@ func compileAllFilesInDirectory(
 		} else {
 			successCount++
 			workflowDataList = append(workflowDataList, fileResult.workflowData)
+			if config.ManifestPath != "" {
+				manifestEntries = append(manifestEntries, buildManifestEntry(fileResult, file))
+			}
+			if fileResult.workflowData != nil {
+				workflowNames = append(workflowNames, workflow.WorkflowNameEntry{Path: file, Name: fileResult.workflowData.Name})
+			}
 
 			// Collect lock files for batch security tools
 			if !config.NoEmit && fileResult.lockFile != "" {
 				if _, err := os.Stat(fileResult.lockFile); err == nil {
-					if config.Actionlint {
+					if config.Actionlint && changedFiles.includes(file, fileResult.lockFile) {
 						lockFilesForActionlint = append(lockFilesForActionlint, fileResult.lockFile)
+						actionlintStats.addSourceMap(fileResult.lockFile, compiler.SourceMap(fileResult.lockFile))
+						if fileResult.workflowData != nil {
+							actionlintStats.addIgnoredKinds(fileResult.lockFile, fileResult.workflowData.ActionlintIgnore)
+							actionlintStats.addRunnerLabels(fileResult.workflowData.SelfHostedRunnerLabels)
+						}
 					}
 					if config.Zizmor {
 						lockFilesForZizmor = append(lockFilesForZizmor, fileResult.lockFile)
@@ -283,9 +319,17 @@ func compileAllFilesInDirectory(
 		*validationResults = append(*validationResults, fileResult.validationResult)
 	}
 
+	// Workflows sharing a name share ${{ github.workflow }}-based concurrency groups
+	if err := workflow.ValidateUniqueWorkflowNames(workflowNames); err != nil {
+		compileOrchestrationLog.Printf("Duplicate workflow names: %v", err)
+		fmt.Fprintln(os.Stderr, console.FormatErrorMessage(err.Error()))
+		errorCount++
+		stats.Errors++
+	}
+
 	// Run batch actionlint
 	if config.Actionlint && !config.NoEmit && len(lockFilesForActionlint) > 0 {
-		if err := runBatchActionlint(lockFilesForActionlint, config.Verbose && !config.JSONOutput, config.Strict); err != nil {
+		if err := runBatchActionlint(lockFilesForActionlint, config.Verbose && !config.JSONOutput, config.Strict, config.ActionlintJobs); err != nil {
 			if config.Strict {
 				return workflowDataList, err
 			}
@@ -316,6 +360,9 @@ func compileAllFilesInDirectory(
 	// Display schedule warnings
 	displayScheduleWarnings(compiler, config.JSONOutput)
 
+	// Emit compiler warnings as annotations when running in GitHub Actions
+	displayWarningAnnotations(compiler, config.JSONOutput)
+
 	if config.Verbose {
 		fmt.Fprintln(os.Stderr, console.FormatSuccessMessage(fmt.Sprintf("Successfully compiled %d out of %d workflow files", successCount, len(mdFiles))))
 	}
@@ -330,8 +377,15 @@ func compileAllFilesInDirectory(
 		return workflowDataList, err
 	}
 
+	// Write compile manifest if requested
+	if config.ManifestPath != "" {
+		if err := writeCompileManifest(config.ManifestPath, manifestEntries, config.Verbose && !config.JSONOutput); err != nil {
+			return workflowDataList, err
+		}
+	}
+
 	// Output results
-	if err := outputResults(stats, validationResults, config); err != nil {
+	if err := outputResults(compiler, stats, validationResults, config); err != nil {
 		return workflowDataList, err
 	}
 
@@ -348,25 +402,26 @@ type purgeTrackingData struct {
 	existingLockFiles    []string
 	existingInvalidFiles []string
 	expectedLockFiles    []string
+	lockFileSuffix       string
 }
 
 // collectPurgeData collects existing files for purge operations
-func collectPurgeData(workflowsDir string, mdFiles []string, verbose bool) *purgeTrackingData {
-	data := &purgeTrackingData{}
+func collectPurgeData(workflowsDir string, mdFiles []string, lockFileSuffix string, verbose bool) *purgeTrackingData {
+	data := &purgeTrackingData{lockFileSuffix: lockFileSuffix}
 
 	// Find all existing files
-	data.existingLockFiles, _ = filepath.Glob(filepath.Join(workflowsDir, "*.lock.yml"))
+	data.existingLockFiles, _ = filepath.Glob(filepath.Join(workflowsDir, "*"+lockFileSuffix))
 	data.existingInvalidFiles, _ = filepath.Glob(filepath.Join(workflowsDir, "*.invalid.yml"))
 
 	// Create expected files list
 	for _, mdFile := range mdFiles {
-		lockFile := stringutil.MarkdownToLockFile(mdFile)
+		lockFile := stringutil.MarkdownToLockFileWithSuffix(mdFile, lockFileSuffix)
 		data.expectedLockFiles = append(data.expectedLockFiles, lockFile)
 	}
 
 	if verbose {
 		if len(data.existingLockFiles) > 0 {
-			fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("Found %d existing .lock.yml files", len(data.existingLockFiles))))
+			fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("Found %d existing %s files", len(data.existingLockFiles), lockFileSuffix)))
 		}
 		if len(data.existingInvalidFiles) > 0 {
 			fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("Found %d existing .invalid.yml files", len(data.existingInvalidFiles))))
@@ -379,7 +434,7 @@ func collectPurgeData(workflowsDir string, mdFiles []string, verbose bool) *purg
 // runPurgeOperations runs all purge operations
 func runPurgeOperations(workflowsDir string, data *purgeTrackingData, verbose bool) {
 	// Errors from purge operations are logged but don't stop compilation
-	_ = purgeOrphanedLockFiles(workflowsDir, data.expectedLockFiles, verbose)
+	_ = purgeOrphanedLockFiles(workflowsDir, data.expectedLockFiles, data.lockFileSuffix, verbose)
 	_ = purgeInvalidFiles(workflowsDir, verbose)
 }
 
@@ -393,6 +448,24 @@ func displayScheduleWarnings(compiler *workflow.Compiler, jsonOutput bool) {
 	}
 }
 
+// displayWarningAnnotations prints compiler warnings as GitHub Actions workflow
+// annotations when running in GitHub Actions. Annotations are written to stdout,
+// so they are skipped in JSON output mode to keep stdout machine-readable.
+func displayWarningAnnotations(compiler *workflow.Compiler, jsonOutput bool) {
+	if jsonOutput || os.Getenv("GITHUB_ACTIONS") != "true" {
+		return
+	}
+
+	warnings := compiler.Warnings()
+	compileOrchestrationLog.Printf("Emitting %d compiler warning annotations", len(warnings))
+	for _, warning := range warnings {
+		if relPath, err := getRepositoryRelativePath(warning.Position.File); err == nil {
+			warning.Position.File = relPath
+		}
+		fmt.Fprintln(os.Stdout, console.FormatGitHubAnnotation(warning))
+	}
+}
+
 // runPostProcessing runs post-processing for specific files compilation
 func runPostProcessing(
 	compiler *workflow.Compiler,
@@ -476,15 +549,22 @@ func runPostProcessingForDirectory(
 
 // outputResults outputs compilation results in the requested format
 func outputResults(
+	compiler *workflow.Compiler,
 	stats *CompilationStats,
 	validationResults *[]ValidationResult,
 	config CompileConfig,
 ) error {
+	// The lint report replaces the summary and JSON validation output
+	if config.LintReport {
+		gitRoot, _ := findGitRoot()
+		return printLintReport(buildLintReport(*validationResults, compiler.Warnings(), gitRoot), config.JSONOutput)
+	}
+
 	// Collect and display stats if requested
 	if config.Stats && !config.NoEmit && !config.JSONOutput {
 		var statsList []*WorkflowStats
 		if len(config.MarkdownFiles) > 0 {
-			statsList = collectWorkflowStatisticsWrapper(config.MarkdownFiles)
+			statsList = collectWorkflowStatisticsWrapper(config.MarkdownFiles, config.LockFileSuffix)
 		}
 		displayStatsTable(statsList)
 	}
@@ -501,9 +581,42 @@ func outputResults(
 		printCompilationSummary(stats)
 	}
 
-	// Display actionlint summary if enabled
-	if config.Actionlint && !config.NoEmit && !config.JSONOutput {
-		displayActionlintSummary()
+	// Display actionlint results if enabled: as JSON with --format json, otherwise as
+	// the text summary, skipping clean runs with --summary-on-issues-only
+	if config.Actionlint && !config.NoEmit && config.ActionlintFormat == "json" {
+		if err := printActionlintJSON(); err != nil {
+			return err
+		}
+	} else if config.Actionlint && !config.NoEmit && !config.JSONOutput {
+		if !config.SummaryOnIssuesOnly || actionlintStats.hasFindings() {
+			displayActionlintSummary()
+		}
+	}
+
+	// Append the actionlint summary to the GitHub Actions job summary when running in a step
+	if config.Actionlint && !config.NoEmit {
+		writeActionlintStepSummary()
+	}
+
+	// Write actionlint results as SARIF if requested
+	if config.Actionlint && !config.NoEmit && config.ActionlintSARIFPath != "" {
+		if err := writeActionlintSARIF(config.ActionlintSARIFPath, config.Verbose && !config.JSONOutput); err != nil {
+			return err
+		}
+	}
+
+	// Write the actionlint findings as a baseline if requested
+	if config.Actionlint && !config.NoEmit && config.ActionlintWriteBaseline != "" {
+		if err := writeActionlintBaseline(config.ActionlintWriteBaseline, config.Verbose && !config.JSONOutput); err != nil {
+			return err
+		}
+	}
+
+	// Fail on actionlint findings according to --fail-on
+	if config.Actionlint && !config.NoEmit && config.ActionlintFailOn != "" {
+		if err := actionlintStats.checkFailOn(config.ActionlintFailOn, config.ActionlintFailOnHidden); err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -8,6 +8,7 @@ import (
 
 	"github.com/github/gh-aw/pkg/console"
 	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/stringutil"
 	"github.com/github/gh-aw/pkg/workflow"
 )
 
@@ -36,9 +37,56 @@ func CompileWorkflows(ctx context.Context, config CompileConfig) ([]*workflow.Wo
 		return nil, err
 	}
 
+	// Validate secret naming policy if specified
+	if err := validateSecretNamingConfig(config.SecretNaming); err != nil {
+		return nil, err
+	}
+
+	// Use the default lock file suffix unless --lock-suffix is set
+	if config.LockFileSuffix == "" {
+		config.LockFileSuffix = stringutil.DefaultLockFileSuffix
+	}
+
 	// Initialize actionlint statistics if actionlint is enabled
 	if config.Actionlint && !config.NoEmit {
+		// Resolve the pre-installed actionlint binary, or else the pinned actionlint version
+		// before any Docker image is pulled
+		binaryPath, err := resolveActionlintBinaryPath(config.ActionlintPath)
+		if err != nil {
+			return nil, err
+		}
+		setActionlintBinaryPath(binaryPath)
+		if binaryPath == "" {
+			version, err := resolveActionlintImageVersion(config.ActionlintVersion)
+			if err != nil {
+				return nil, err
+			}
+			setActionlintImageVersion(version)
+		}
+
 		initActionlintStats()
+		actionlintStats.jsonFormat = config.ActionlintFormat == "json"
+		actionlintStats.annotations = actionlintAnnotationsEnabled(config)
+		actionlintStats.summaryTop = actionlintSummaryTop(config.ActionlintSummaryTop, config.ActionlintSummaryFull)
+		severities, err := parseActionlintSeverities(config.ActionlintSeverities)
+		if err != nil {
+			return nil, err
+		}
+		actionlintStats.severities = severities
+		actionlintStats.dedupe = config.ActionlintDedupe
+		actionlintStats.pullAttempts = config.ActionlintPullAttempts
+		if config.ActionlintBaseline != "" {
+			// A baseline that is about to be written for the first time may not exist yet
+			baseline, err := loadActionlintBaseline(config.ActionlintBaseline, config.ActionlintWriteBaseline != "")
+			if err != nil {
+				return nil, err
+			}
+			actionlintStats.baseline = baseline
+		}
+		actionlintStats.kindFilter = actionlintKindFilter{
+			only:   config.ActionlintFilterKinds,
+			ignore: config.ActionlintIgnoreKinds,
+		}
 	}
 
 	// Track compilation statistics
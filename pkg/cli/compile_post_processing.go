@@ -81,7 +81,7 @@ func generateMaintenanceWorkflowWrapper(
 }
 
 // collectWorkflowStatisticsWrapper collects and returns workflow statistics
-func collectWorkflowStatisticsWrapper(markdownFiles []string) []*WorkflowStats {
+func collectWorkflowStatisticsWrapper(markdownFiles []string, lockFileSuffix string) []*WorkflowStats {
 	compilePostProcessingLog.Printf("Collecting workflow statistics for %d files", len(markdownFiles))
 
 	var statsList []*WorkflowStats
@@ -90,7 +90,7 @@ func collectWorkflowStatisticsWrapper(markdownFiles []string) []*WorkflowStats {
 		if err != nil {
 			continue // Skip files that couldn't be resolved
 		}
-		lockFile := stringutil.MarkdownToLockFile(resolvedFile)
+		lockFile := stringutil.MarkdownToLockFileWithSuffix(resolvedFile, lockFileSuffix)
 		if workflowStats, err := collectWorkflowStats(lockFile); err == nil {
 			statsList = append(statsList, workflowStats)
 		}
@@ -5,22 +5,33 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"runtime"
+	"strings"
 
 	"github.com/github/gh-aw/pkg/logger"
-	"github.com/github/gh-aw/pkg/stringutil"
 	"github.com/github/gh-aw/pkg/workflow"
 	"github.com/goccy/go-yaml"
 )
 
 var compileValidationLog = logger.New("cli:compile_validation")
 
-// RunActionlintOnFiles runs actionlint on multiple lock files in a single batch
+// RunActionlintOnFiles runs actionlint on multiple lock files in batch, split across
+// GOMAXPROCS concurrent actionlint invocations
 // This is more efficient than running actionlint once per file
 func RunActionlintOnFiles(lockFiles []string, verbose bool, strict bool) error {
+	return runActionlintOnFilesWithJobs(lockFiles, verbose, strict, 0)
+}
+
+// runActionlintOnFilesWithJobs runs actionlint on multiple lock files in batch, split across
+// at most jobs concurrent actionlint invocations (GOMAXPROCS when jobs is 0)
+func runActionlintOnFilesWithJobs(lockFiles []string, verbose bool, strict bool, jobs int) error {
 	if len(lockFiles) == 0 {
 		return nil
 	}
-	return runActionlintOnFile(lockFiles, verbose, strict)
+	if jobs <= 0 {
+		jobs = runtime.GOMAXPROCS(0)
+	}
+	return runActionlintOnFile(lockFiles, verbose, strict, jobs)
 }
 
 // RunZizmorOnFiles runs zizmor on multiple lock files in a single batch
@@ -65,7 +76,7 @@ func CompileWorkflowWithValidation(compiler *workflow.Compiler, filePath string,
 	}
 
 	// Always validate that the generated lock file is valid YAML (CLI requirement)
-	lockFile := stringutil.MarkdownToLockFile(filePath)
+	lockFile := compiler.LockFilePath(filePath)
 	if _, err := os.Stat(lockFile); err != nil {
 		compileValidationLog.Print("Lock file not found, skipping validation (likely no-emit mode)")
 		// Lock file doesn't exist (likely due to no-emit), skip YAML validation
@@ -113,7 +124,7 @@ func CompileWorkflowWithValidation(compiler *workflow.Compiler, filePath string,
 	// Run actionlint on the generated lock file if requested
 	// Note: For batch processing, use RunActionlintOnFiles instead
 	if runActionlintPerFile {
-		if err := runActionlintOnFile([]string{lockFile}, verbose, strict); err != nil {
+		if err := runActionlintOnFile([]string{lockFile}, verbose, strict, 1); err != nil {
 			return fmt.Errorf("actionlint linter failed: %w", err)
 		}
 	}
@@ -133,7 +144,7 @@ func CompileWorkflowDataWithValidation(compiler *workflow.Compiler, workflowData
 	}
 
 	// Always validate that the generated lock file is valid YAML (CLI requirement)
-	lockFile := stringutil.MarkdownToLockFile(filePath)
+	lockFile := compiler.LockFilePath(filePath)
 	if _, err := os.Stat(lockFile); err != nil {
 		compileValidationLog.Print("Lock file not found, skipping validation (likely no-emit mode)")
 		// Lock file doesn't exist (likely due to no-emit), skip YAML validation
@@ -181,7 +192,8 @@ func CompileWorkflowDataWithValidation(compiler *workflow.Compiler, workflowData
 	// Run actionlint on the generated lock file if requested
 	// Note: For batch processing, use RunActionlintOnFiles instead
 	if runActionlintPerFile {
-		if err := runActionlintOnFile([]string{lockFile}, verbose, strict); err != nil {
+		actionlintStats.addRunnerLabels(workflowData.SelfHostedRunnerLabels)
+		if err := runActionlintOnFile([]string{lockFile}, verbose, strict, 1); err != nil {
 			return fmt.Errorf("actionlint linter failed: %w", err)
 		}
 	}
@@ -189,6 +201,24 @@ func CompileWorkflowDataWithValidation(compiler *workflow.Compiler, workflowData
 	return nil
 }
 
+// validateLockFileSuffix checks a custom lock file suffix. The suffix must end in .yml or
+// .yaml so that actionlint and GitHub Actions treat the generated files as workflows, and it
+// needs a name part before the extension (e.g. ".gen.yml") so that --purge cannot mistake
+// hand-written workflows for orphaned lock files.
+func validateLockFileSuffix(suffix string) error {
+	extension := filepath.Ext(suffix)
+	if extension != ".yml" && extension != ".yaml" {
+		return fmt.Errorf("--lock-suffix must end in .yml or .yaml, got: %s", suffix)
+	}
+	if name := strings.TrimSuffix(suffix, extension); !strings.HasPrefix(name, ".") || len(name) < 2 {
+		return fmt.Errorf("--lock-suffix must start with '.' and name the lock files before the extension (e.g. .gen.yml), got: %s", suffix)
+	}
+	if strings.ContainsAny(suffix, `/\*?[`) {
+		return fmt.Errorf("--lock-suffix must not contain path separators or glob characters, got: %s", suffix)
+	}
+	return nil
+}
+
 // validateCompileConfig validates the configuration flags before compilation
 // This is extracted for faster testing without full compilation
 func validateCompileConfig(config CompileConfig) error {
@@ -218,6 +248,153 @@ func validateCompileConfig(config CompileConfig) error {
 		return fmt.Errorf("--dir must be a relative path, got: %s", config.WorkflowDir)
 	}
 
+	// Validate sarif flag usage; results are only collected when actionlint runs
+	if config.ActionlintSARIFPath != "" && (!config.Actionlint || config.NoEmit) {
+		compileValidationLog.Print("Config validation failed: sarif flag without actionlint")
+		return errors.New("--sarif flag requires --actionlint and cannot be used with --no-emit")
+	}
+
+	// Validate actionlint kind filters; they only apply to actionlint results
+	if (len(config.ActionlintFilterKinds) > 0 || len(config.ActionlintIgnoreKinds) > 0) && (!config.Actionlint || config.NoEmit) {
+		compileValidationLog.Print("Config validation failed: kind filter without actionlint")
+		return errors.New("--filter-kind and --ignore-kind require --actionlint and cannot be used with --no-emit")
+	}
+
+	// Validate actionlint exit-code policy
+	switch config.ActionlintFailOn {
+	case "":
+		if config.ActionlintFailOnHidden {
+			compileValidationLog.Print("Config validation failed: fail-on-hidden without fail-on")
+			return errors.New("--fail-on-hidden requires --fail-on")
+		}
+	case actionlintFailOnError, actionlintFailOnWarning, actionlintFailOnNever:
+		if !config.Actionlint || config.NoEmit {
+			compileValidationLog.Print("Config validation failed: fail-on without actionlint")
+			return errors.New("--fail-on requires --actionlint and cannot be used with --no-emit")
+		}
+	default:
+		compileValidationLog.Printf("Config validation failed: invalid fail-on: %s", config.ActionlintFailOn)
+		return fmt.Errorf("invalid --fail-on value %q: must be 'error', 'warning', or 'never'", config.ActionlintFailOn)
+	}
+
+	// Validate actionlint concurrency
+	if config.ActionlintJobs < 0 {
+		compileValidationLog.Printf("Config validation failed: negative jobs: %d", config.ActionlintJobs)
+		return fmt.Errorf("--jobs must be a positive number, got: %d", config.ActionlintJobs)
+	}
+
+	// Validate actionlint version pinning; the version itself is validated before any image is pulled
+	if config.ActionlintVersion != "" && (!config.Actionlint || config.NoEmit) {
+		compileValidationLog.Print("Config validation failed: actionlint-version without actionlint")
+		return errors.New("--actionlint-version requires --actionlint and cannot be used with --no-emit")
+	}
+
+	// Validate the pre-installed actionlint binary; the path itself is checked before actionlint runs
+	if config.ActionlintPath != "" {
+		if !config.Actionlint || config.NoEmit {
+			compileValidationLog.Print("Config validation failed: actionlint-path without actionlint")
+			return errors.New("--actionlint-path requires --actionlint and cannot be used with --no-emit")
+		}
+		if config.ActionlintVersion != "" {
+			compileValidationLog.Print("Config validation failed: actionlint-path with actionlint-version")
+			return errors.New("--actionlint-path cannot be used with --actionlint-version; the version of the binary is used")
+		}
+	}
+
+	// Validate actionlint image pull attempts
+	if config.ActionlintPullAttempts != 0 {
+		if !config.Actionlint || config.NoEmit {
+			compileValidationLog.Print("Config validation failed: actionlint-pull-attempts without actionlint")
+			return errors.New("--actionlint-pull-attempts requires --actionlint and cannot be used with --no-emit")
+		}
+		if config.ActionlintPullAttempts < 0 {
+			compileValidationLog.Printf("Config validation failed: negative actionlint-pull-attempts: %d", config.ActionlintPullAttempts)
+			return fmt.Errorf("--actionlint-pull-attempts must be a positive number, got: %d", config.ActionlintPullAttempts)
+		}
+	}
+
+	// Validate actionlint annotations; they are written to stdout, so they cannot be mixed with JSON output
+	if config.ActionlintAnnotations {
+		if !config.Actionlint || config.NoEmit {
+			compileValidationLog.Print("Config validation failed: annotations without actionlint")
+			return errors.New("--annotations requires --actionlint and cannot be used with --no-emit")
+		}
+		if config.JSONOutput || config.ActionlintFormat == "json" {
+			compileValidationLog.Print("Config validation failed: annotations with json output")
+			return errors.New("--annotations cannot be used with --json or --format json")
+		}
+	}
+
+	// Validate changed-only actionlint runs
+	if config.ActionlintChangedOnly && (!config.Actionlint || config.NoEmit) {
+		compileValidationLog.Print("Config validation failed: changed-only without actionlint")
+		return errors.New("--changed-only requires --actionlint and cannot be used with --no-emit")
+	}
+	if config.ActionlintBaseRef != "" && !config.ActionlintChangedOnly {
+		compileValidationLog.Print("Config validation failed: base-ref without changed-only")
+		return errors.New("--base-ref requires --changed-only")
+	}
+
+	// Validate actionlint baselines
+	if (config.ActionlintBaseline != "" || config.ActionlintWriteBaseline != "") && (!config.Actionlint || config.NoEmit) {
+		compileValidationLog.Print("Config validation failed: baseline without actionlint")
+		return errors.New("--baseline and --write-baseline require --actionlint and cannot be used with --no-emit")
+	}
+
+	// Validate actionlint summary breakdown size
+	if config.ActionlintSummaryTop < 0 {
+		compileValidationLog.Printf("Config validation failed: negative summary-top: %d", config.ActionlintSummaryTop)
+		return fmt.Errorf("--summary-top must be a positive number, got: %d", config.ActionlintSummaryTop)
+	}
+	if config.ActionlintSummaryTop > 0 && config.ActionlintSummaryFull {
+		compileValidationLog.Print("Config validation failed: summary-top with summary-full")
+		return errors.New("--summary-top cannot be used with --summary-full")
+	}
+
+	// Validate actionlint severity overrides
+	if len(config.ActionlintSeverities) > 0 {
+		if !config.Actionlint || config.NoEmit {
+			compileValidationLog.Print("Config validation failed: severity without actionlint")
+			return errors.New("--severity requires --actionlint and cannot be used with --no-emit")
+		}
+		if _, err := parseActionlintSeverities(config.ActionlintSeverities); err != nil {
+			compileValidationLog.Printf("Config validation failed: %v", err)
+			return err
+		}
+	}
+
+	// Validate actionlint deduplication
+	if config.ActionlintDedupe && (!config.Actionlint || config.NoEmit) {
+		compileValidationLog.Print("Config validation failed: dedupe without actionlint")
+		return errors.New("--dedupe requires --actionlint and cannot be used with --no-emit")
+	}
+
+	// Validate actionlint output format; JSON results are printed to stdout, so they
+	// cannot be mixed with the JSON validation output
+	switch config.ActionlintFormat {
+	case "", "text":
+	case "json":
+		if !config.Actionlint || config.NoEmit {
+			compileValidationLog.Print("Config validation failed: json format without actionlint")
+			return errors.New("--format json requires --actionlint and cannot be used with --no-emit")
+		}
+		if config.JSONOutput {
+			compileValidationLog.Print("Config validation failed: json format with json output")
+			return errors.New("--format json cannot be used with --json")
+		}
+	default:
+		compileValidationLog.Printf("Config validation failed: invalid format: %s", config.ActionlintFormat)
+		return fmt.Errorf("invalid --format value %q: must be 'text' or 'json'", config.ActionlintFormat)
+	}
+
+	// Validate the lock file suffix so actionlint still recognizes the generated files
+	if config.LockFileSuffix != "" {
+		if err := validateLockFileSuffix(config.LockFileSuffix); err != nil {
+			compileValidationLog.Printf("Config validation failed: invalid lock suffix: %s", config.LockFileSuffix)
+			return err
+		}
+	}
+
 	compileValidationLog.Print("Config validation successful")
 	return nil
 }
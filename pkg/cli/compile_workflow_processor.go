@@ -29,7 +29,6 @@ import (
 
 	"github.com/github/gh-aw/pkg/console"
 	"github.com/github/gh-aw/pkg/logger"
-	"github.com/github/gh-aw/pkg/stringutil"
 	"github.com/github/gh-aw/pkg/workflow"
 )
 
@@ -56,6 +55,7 @@ func compileWorkflowFile(
 	actionlint bool,
 	strict bool,
 	validate bool,
+	compileCache *workflow.CompileCache,
 ) compileWorkflowFileResult {
 	compileWorkflowProcessorLog.Printf("Processing workflow file: %s", resolvedFile)
 
@@ -65,12 +65,13 @@ func compileWorkflowFile(
 			Valid:    true,
 			Errors:   []CompileValidationError{},
 			Warnings: []CompileValidationError{},
+			path:     resolvedFile,
 		},
 		success: false,
 	}
 
 	// Generate lock file name
-	lockFile := stringutil.MarkdownToLockFile(resolvedFile)
+	lockFile := compiler.LockFilePath(resolvedFile)
 	result.lockFile = lockFile
 	if !noEmit {
 		result.validationResult.CompiledFile = lockFile
@@ -126,6 +127,19 @@ func compileWorkflowFile(
 	}
 	result.workflowData = workflowData
 
+	// Reuse the cached lock file when nothing that affects it has changed
+	var cacheKey string
+	if compileCache != nil {
+		cacheKey = compileCache.Key(resolvedFile, workflowData)
+		if restoreCachedLockFile(compileCache, cacheKey, lockFile) {
+			if verbose && !jsonOutput {
+				fmt.Fprintln(os.Stderr, console.FormatInfoMessage("Unchanged, using cached lock file: "+lockFile))
+			}
+			result.success = true
+			return result
+		}
+	}
+
 	compileWorkflowProcessorLog.Printf("Starting compilation of %s", resolvedFile)
 
 	// Compile the workflow
@@ -141,6 +155,10 @@ func compileWorkflowFile(
 		return result
 	}
 
+	if compileCache != nil {
+		storeCompiledLockFile(compileCache, cacheKey, lockFile)
+	}
+
 	result.success = true
 	compileWorkflowProcessorLog.Printf("Successfully processed workflow file: %s", resolvedFile)
 	return result
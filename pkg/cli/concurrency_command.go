@@ -0,0 +1,238 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/workflow"
+	"github.com/goccy/go-yaml"
+	"github.com/spf13/cobra"
+)
+
+var concurrencyCommandLog = logger.New("cli:concurrency_command")
+
+// ConcurrencyConfig holds configuration for the concurrency command.
+type ConcurrencyConfig struct {
+	WorkflowPath string
+	JSONOutput   bool
+	Verbose      bool
+}
+
+// ConcurrencySettings is a resolved concurrency block.
+type ConcurrencySettings struct {
+	Group            string `json:"group"`
+	CancelInProgress string `json:"cancel_in_progress"`
+}
+
+// ConcurrencyPreview is the normalized output for the concurrency command.
+type ConcurrencyPreview struct {
+	WorkflowPath string `json:"workflow_path"`
+	// Disabled is set when the frontmatter disables workflow-level concurrency (concurrency: none)
+	Disabled bool `json:"disabled"`
+	// Generated is set when the workflow-level group was derived from the triggers
+	// rather than taken from an explicit concurrency block
+	Generated     bool                                  `json:"generated"`
+	Workflow      *ConcurrencySettings                  `json:"workflow,omitempty"`
+	TriggerKey    *workflow.ConcurrencyGroupFragment    `json:"trigger_key,omitempty"`
+	Fragments     []workflow.ConcurrencyGroupFragment   `json:"fragments,omitempty"`
+	KeyResolution []workflow.ConcurrencyEventResolution `json:"key_resolution,omitempty"`
+	AgentJob      *ConcurrencySettings                  `json:"agent_job,omitempty"`
+}
+
+// NewConcurrencyCommand creates the concurrency command.
+func NewConcurrencyCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "concurrency <workflow>",
+		Short: "Preview the concurrency configuration generated for a workflow",
+		Long: `Preview the concurrency configuration generated for a workflow without compiling it.
+
+Shows the workflow-level concurrency group and cancel-in-progress value, the
+trigger branch that selected the group key, how the key resolves for each
+trigger event, and the agent job concurrency group.
+
+Examples:
+  ` + string(constants.CLIExtensionPrefix) + ` concurrency my-workflow.md                         # Preview concurrency
+  ` + string(constants.CLIExtensionPrefix) + ` concurrency .github/workflows/triage.md --json     # Output in JSON format`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			jsonOutput, _ := cmd.Flags().GetBool("json")
+			verbose, _ := cmd.Flags().GetBool("verbose")
+
+			config := ConcurrencyConfig{
+				WorkflowPath: args[0],
+				JSONOutput:   jsonOutput,
+				Verbose:      verbose,
+			}
+
+			return RunConcurrencyPreview(config)
+		},
+	}
+
+	addJSONFlag(cmd)
+
+	return cmd
+}
+
+// RunConcurrencyPreview executes the concurrency command with the given configuration.
+func RunConcurrencyPreview(config ConcurrencyConfig) error {
+	concurrencyCommandLog.Printf("Previewing concurrency: workflow=%s", config.WorkflowPath)
+
+	if _, err := os.Stat(config.WorkflowPath); os.IsNotExist(err) {
+		fmt.Fprintln(os.Stderr, console.FormatErrorMessage("workflow file not found: "+config.WorkflowPath))
+		return fmt.Errorf("workflow file not found: %s", config.WorkflowPath)
+	}
+
+	compiler := workflow.NewCompiler(workflow.WithVerbose(config.Verbose))
+	workflowData, err := compiler.ParseWorkflowFile(config.WorkflowPath)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, console.FormatErrorMessage(fmt.Sprintf("failed to parse workflow file: %v", err)))
+		return fmt.Errorf("failed to parse workflow file: %w", err)
+	}
+
+	preview, err := BuildConcurrencyPreview(workflowData, config.WorkflowPath)
+	if err != nil {
+		return err
+	}
+
+	if config.JSONOutput {
+		return printConcurrencyPreviewJSON(preview)
+	}
+
+	printConcurrencyPreviewText(preview)
+	return nil
+}
+
+// BuildConcurrencyPreview resolves the workflow-level and agent job concurrency of a parsed workflow.
+// This function is exported for use in tests and other packages.
+func BuildConcurrencyPreview(workflowData *workflow.WorkflowData, workflowPath string) (*ConcurrencyPreview, error) {
+	isCommandTrigger := len(workflowData.Command) > 0
+	preview := &ConcurrencyPreview{
+		WorkflowPath: workflowPath,
+		Disabled:     workflowData.ConcurrencyDisabled,
+	}
+
+	if !workflowData.ConcurrencyDisabled {
+		settings, err := parseConcurrencySettings(workflow.GenerateConcurrencyConfig(workflowData, isCommandTrigger))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse workflow concurrency: %w", err)
+		}
+		preview.Workflow = settings
+
+		// The explanation only applies when the group is the compiler-generated one
+		generatedGroup, _ := workflow.BuildConcurrencyGroup(workflowData, isCommandTrigger)
+		if settings != nil && settings.Group == generatedGroup {
+			preview.Generated = true
+			explanation := workflow.ExplainConcurrencyGroup(workflowData, isCommandTrigger)
+			preview.Fragments = explanation.Fragments
+			// The trigger-based key follows the gh-aw prefix and the workflow identity
+			if len(explanation.Fragments) > 2 {
+				preview.TriggerKey = &explanation.Fragments[2]
+			}
+			preview.KeyResolution = workflow.ExplainConcurrencyKeyResolution(workflowData, isCommandTrigger)
+		}
+	}
+
+	agentJob, err := parseConcurrencySettings(workflow.GenerateJobConcurrencyConfig(workflowData))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse agent job concurrency: %w", err)
+	}
+	preview.AgentJob = agentJob
+
+	concurrencyCommandLog.Printf("Built concurrency preview: generated=%t, disabled=%t", preview.Generated, preview.Disabled)
+	return preview, nil
+}
+
+// parseConcurrencySettings parses a "concurrency:" YAML block in either its string or
+// object form. An empty block yields nil. cancel-in-progress defaults to "false", as in
+// GitHub Actions.
+func parseConcurrencySettings(config string) (*ConcurrencySettings, error) {
+	if config == "" {
+		return nil, nil
+	}
+
+	var parsed struct {
+		Concurrency any `yaml:"concurrency"`
+	}
+	if err := yaml.Unmarshal([]byte(config), &parsed); err != nil {
+		return nil, err
+	}
+
+	settings := &ConcurrencySettings{CancelInProgress: "false"}
+	switch value := parsed.Concurrency.(type) {
+	case string:
+		settings.Group = value
+	case map[string]any:
+		if group, ok := value["group"]; ok {
+			settings.Group = fmt.Sprint(group)
+		}
+		if cancel, ok := value["cancel-in-progress"]; ok {
+			settings.CancelInProgress = fmt.Sprint(cancel)
+		}
+	default:
+		return nil, fmt.Errorf("unexpected concurrency value: %v", parsed.Concurrency)
+	}
+	return settings, nil
+}
+
+// printConcurrencyPreviewJSON prints the preview as JSON to stdout.
+func printConcurrencyPreviewJSON(preview *ConcurrencyPreview) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(preview); err != nil {
+		return fmt.Errorf("failed to encode JSON output: %w", err)
+	}
+	return nil
+}
+
+// printConcurrencyPreviewText prints the preview in human-readable form to stderr.
+// Only the --json output is written to stdout.
+func printConcurrencyPreviewText(preview *ConcurrencyPreview) {
+	fmt.Fprintln(os.Stderr, console.FormatSectionHeader("Workflow concurrency:"))
+	switch {
+	case preview.Disabled:
+		fmt.Fprintln(os.Stderr, console.FormatListItem("disabled (concurrency: none)"))
+	case preview.Workflow == nil:
+		fmt.Fprintln(os.Stderr, console.FormatListItem("none"))
+	default:
+		fmt.Fprintln(os.Stderr, console.FormatListItem("group: "+preview.Workflow.Group))
+		fmt.Fprintln(os.Stderr, console.FormatListItem("cancel-in-progress: "+preview.Workflow.CancelInProgress))
+		if !preview.Generated {
+			fmt.Fprintln(os.Stderr, console.FormatListItem("source: explicit concurrency block in frontmatter"))
+		}
+	}
+
+	if preview.TriggerKey != nil {
+		fmt.Fprintln(os.Stderr)
+		fmt.Fprintln(os.Stderr, console.FormatSectionHeader("Trigger branch: "+preview.TriggerKey.Description))
+		fmt.Fprintln(os.Stderr, console.FormatListItem("key: "+preview.TriggerKey.Key))
+	}
+
+	if len(preview.Fragments) > 0 {
+		fmt.Fprintln(os.Stderr)
+		fmt.Fprintln(os.Stderr, console.FormatSectionHeader("Group fragments:"))
+		for _, fragment := range preview.Fragments {
+			fmt.Fprintln(os.Stderr, console.FormatListItem(fmt.Sprintf("%s: %s", fragment.Key, fragment.Description)))
+		}
+	}
+
+	if len(preview.KeyResolution) > 0 {
+		fmt.Fprintln(os.Stderr)
+		fmt.Fprintln(os.Stderr, console.FormatSectionHeader("Key resolution by event:"))
+		for _, resolution := range preview.KeyResolution {
+			fmt.Fprintln(os.Stderr, console.FormatListItem(fmt.Sprintf("%s: %s (%s)", resolution.Event, resolution.Identifier, resolution.Description)))
+		}
+	}
+
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, console.FormatSectionHeader("Agent job concurrency:"))
+	if preview.AgentJob == nil {
+		fmt.Fprintln(os.Stderr, console.FormatListItem("none"))
+		return
+	}
+	fmt.Fprintln(os.Stderr, console.FormatListItem("group: "+preview.AgentJob.Group))
+	fmt.Fprintln(os.Stderr, console.FormatListItem("cancel-in-progress: "+preview.AgentJob.CancelInProgress))
+}
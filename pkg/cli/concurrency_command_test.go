@@ -0,0 +1,92 @@
+//go:build !integration
+
+package cli
+
+import (
+	"testing"
+
+	"github.com/github/gh-aw/pkg/workflow"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildConcurrencyPreview(t *testing.T) {
+	tests := []struct {
+		name              string
+		on                string
+		concurrency       string
+		disabled          bool
+		wantGenerated     bool
+		wantGroup         string
+		wantCancel        string
+		wantTriggerKey    string
+		wantResolutionLen int
+	}{
+		{
+			name:              "pull request workflow",
+			on:                "on:\n  pull_request:\n    types: [opened]",
+			wantGenerated:     true,
+			wantGroup:         "gh-aw-${{ github.workflow }}-${{ github.event.pull_request.number || github.ref || github.run_id }}",
+			wantCancel:        "true",
+			wantTriggerKey:    "${{ github.event.pull_request.number || github.ref || github.run_id }}",
+			wantResolutionLen: 1,
+		},
+		{
+			name:          "explicit concurrency block",
+			on:            "on:\n  push:",
+			concurrency:   "concurrency:\n  group: custom-group\n  cancel-in-progress: false",
+			wantGenerated: false,
+			wantGroup:     "custom-group",
+			wantCancel:    "false",
+		},
+		{
+			name:     "concurrency disabled",
+			on:       "on:\n  push:",
+			disabled: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			workflowData := &workflow.WorkflowData{
+				On:                  tt.on,
+				Concurrency:         tt.concurrency,
+				ConcurrencyDisabled: tt.disabled,
+			}
+
+			preview, err := BuildConcurrencyPreview(workflowData, "test.md")
+			require.NoError(t, err, "preview should build")
+
+			assert.Equal(t, tt.disabled, preview.Disabled, "disabled should reflect the frontmatter")
+			assert.Equal(t, tt.wantGenerated, preview.Generated, "generated should reflect the group source")
+			if tt.disabled {
+				assert.Nil(t, preview.Workflow, "disabled concurrency should have no workflow settings")
+				return
+			}
+			require.NotNil(t, preview.Workflow, "workflow settings should be resolved")
+			assert.Equal(t, tt.wantGroup, preview.Workflow.Group, "group should match the generated config")
+			assert.Equal(t, tt.wantCancel, preview.Workflow.CancelInProgress, "cancel-in-progress should match the generated config")
+			if tt.wantTriggerKey == "" {
+				assert.Nil(t, preview.TriggerKey, "explicit groups should not be explained")
+				return
+			}
+			require.NotNil(t, preview.TriggerKey, "generated groups should name the trigger key")
+			assert.Equal(t, tt.wantTriggerKey, preview.TriggerKey.Key, "trigger key should follow the workflow identity")
+			assert.Len(t, preview.KeyResolution, tt.wantResolutionLen, "each trigger should resolve the key")
+		})
+	}
+}
+
+func TestParseConcurrencySettings(t *testing.T) {
+	settings, err := parseConcurrencySettings("")
+	require.NoError(t, err, "empty config should parse")
+	assert.Nil(t, settings, "empty config should yield no settings")
+
+	settings, err = parseConcurrencySettings("concurrency: my-group")
+	require.NoError(t, err, "string form should parse")
+	assert.Equal(t, &ConcurrencySettings{Group: "my-group", CancelInProgress: "false"}, settings, "string form should default cancel-in-progress")
+
+	settings, err = parseConcurrencySettings("concurrency:\n  group: \"gh-aw-${{ github.workflow }}\"\n  cancel-in-progress: true")
+	require.NoError(t, err, "object form should parse")
+	assert.Equal(t, &ConcurrencySettings{Group: "gh-aw-${{ github.workflow }}", CancelInProgress: "true"}, settings, "object form should keep both values")
+}
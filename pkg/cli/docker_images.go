@@ -3,6 +3,7 @@ package cli
 import (
 	"context"
 	"errors"
+	"os"
 	"os/exec"
 	"strings"
 	"sync"
@@ -172,6 +173,9 @@ func CheckAndPrepareDockerImages(ctx context.Context, useZizmor, usePoutine, use
 	var missingImages []string
 	var downloadingImages []string
 
+	// A pre-installed actionlint binary needs no image
+	usesActionlintBinary := os.Getenv(actionlintPathEnvVar) != ""
+
 	// Check which images are needed and their availability
 	imagesToCheck := []struct {
 		use   bool
@@ -180,7 +184,7 @@ func CheckAndPrepareDockerImages(ctx context.Context, useZizmor, usePoutine, use
 	}{
 		{useZizmor, ZizmorImage, "zizmor"},
 		{usePoutine, PoutineImage, "poutine"},
-		{useActionlint, ActionlintImage, "actionlint"},
+		{useActionlint && !usesActionlintBinary, actionlintImage(), "actionlint"},
 	}
 
 	for _, img := range imagesToCheck {
@@ -0,0 +1,242 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/stringutil"
+	"github.com/spf13/cobra"
+)
+
+var lintLog = logger.New("cli:lint_command")
+
+// LintDiagnostic is a single error or warning reported for a workflow file
+type LintDiagnostic struct {
+	Type    string `json:"type"`
+	Message string `json:"message"`
+	Line    int    `json:"line,omitempty"`
+}
+
+// LintFileReport holds the diagnostics reported for a single file, grouped by severity
+type LintFileReport struct {
+	File     string           `json:"file"`
+	Errors   []LintDiagnostic `json:"errors"`
+	Warnings []LintDiagnostic `json:"warnings"`
+}
+
+// LintReport is the aggregate report produced by the lint command
+type LintReport struct {
+	Valid        bool             `json:"valid"`
+	Workflows    int              `json:"workflows"`
+	ErrorCount   int              `json:"error_count"`
+	WarningCount int              `json:"warning_count"`
+	Files        []LintFileReport `json:"files"`
+}
+
+// NewLintCommand creates the lint command
+func NewLintCommand(validateEngine func(string) error) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "lint [workflow]...",
+		Short: "Validate all agentic workflows and print an aggregate report",
+		Long: `Run the full compiler validation suite (frontmatter schema, features, secrets,
+triggers, concurrency, permissions, and schedules) across agentic workflows without
+generating lock files, and print an aggregate report of all errors and warnings
+grouped by file and severity.
+
+Every workflow is validated even when an earlier one fails. The command exits with
+a nonzero status if any workflow has errors; warnings alone do not fail the run.
+
+If no workflows are specified, all Markdown files in .github/workflows are linted.
+
+` + WorkflowIDExplanation + `
+
+Examples:
+  ` + string(constants.CLIExtensionPrefix) + ` lint                         # Lint all workflows
+  ` + string(constants.CLIExtensionPrefix) + ` lint ci-doctor daily         # Lint specific workflows
+  ` + string(constants.CLIExtensionPrefix) + ` lint --dir custom/workflows  # Lint from custom directory
+  ` + string(constants.CLIExtensionPrefix) + ` lint --strict                # Enforce strict mode validation
+  ` + string(constants.CLIExtensionPrefix) + ` lint --secret-naming permissive  # Accept lowercase secret names
+  ` + string(constants.CLIExtensionPrefix) + ` lint --json                  # Output the report in JSON format`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			engineOverride, _ := cmd.Flags().GetString("engine")
+			dir, _ := cmd.Flags().GetString("dir")
+			strict, _ := cmd.Flags().GetBool("strict")
+			secretNaming, _ := cmd.Flags().GetString("secret-naming")
+			jsonOutput, _ := cmd.Flags().GetBool("json")
+			verbose, _ := cmd.Flags().GetBool("verbose")
+
+			if err := validateEngine(engineOverride); err != nil {
+				return err
+			}
+
+			lintLog.Printf("Running lint command: workflows=%v, dir=%s", args, dir)
+
+			config := CompileConfig{
+				MarkdownFiles:  args,
+				Verbose:        verbose,
+				EngineOverride: engineOverride,
+				Validate:       true,
+				NoEmit:         true,
+				WorkflowDir:    dir,
+				Strict:         strict,
+				SecretNaming:   secretNaming,
+				JSONOutput:     jsonOutput,
+				LintReport:     true,
+			}
+			if _, err := CompileWorkflows(context.Background(), config); err != nil {
+				return err
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringP("engine", "e", "", "Override AI engine (claude, codex, copilot, custom)")
+	cmd.Flags().StringP("dir", "d", "", "Workflow directory (default: .github/workflows)")
+	cmd.Flags().Bool("strict", false, "Enforce strict mode validation for all workflows")
+	cmd.Flags().String("secret-naming", "", "Fail on invalid referenced secret names under this naming policy: strict (uppercase letters, digits, and underscores) or permissive (any name GitHub accepts). Without it, strict violations only warn")
+	addJSONFlag(cmd)
+
+	// Register completions
+	cmd.ValidArgsFunction = CompleteWorkflowNames
+	RegisterEngineFlagCompletion(cmd)
+	RegisterDirFlagCompletion(cmd, "dir")
+
+	return cmd
+}
+
+// buildLintReport aggregates per-workflow validation results and compiler warning
+// diagnostics into a report grouped by file. Every linted workflow is listed, even
+// without diagnostics; warnings reported against other files (e.g. imports) add
+// entries for those files. Files are keyed by their path relative to gitRoot so that
+// same-named files in different directories stay apart. Messages are sanitized to
+// remove potential secret names.
+func buildLintReport(results []ValidationResult, warnings []console.CompilerError, gitRoot string) LintReport {
+	report := LintReport{Workflows: len(results)}
+	files := make(map[string]*LintFileReport)
+	fileReport := func(file string) *LintFileReport {
+		if files[file] == nil {
+			files[file] = &LintFileReport{File: file, Errors: []LintDiagnostic{}, Warnings: []LintDiagnostic{}}
+		}
+		return files[file]
+	}
+	toDiagnostic := func(e CompileValidationError) LintDiagnostic {
+		return LintDiagnostic{Type: e.Type, Message: e.Message, Line: e.Line}
+	}
+
+	for _, result := range sanitizeValidationResults(results) {
+		file := result.Workflow
+		if result.path != "" {
+			file = lintReportPath(result.path, gitRoot)
+		}
+		entry := fileReport(file)
+		for _, e := range result.Errors {
+			entry.Errors = append(entry.Errors, toDiagnostic(e))
+		}
+		for _, w := range result.Warnings {
+			entry.Warnings = append(entry.Warnings, toDiagnostic(w))
+		}
+	}
+
+	for _, warning := range warnings {
+		entry := fileReport(lintReportPath(warning.Position.File, gitRoot))
+		entry.Warnings = append(entry.Warnings, LintDiagnostic{
+			Type:    "warning",
+			Message: stringutil.SanitizeErrorMessage(warning.Message),
+			Line:    warning.Position.Line,
+		})
+	}
+
+	report.Files = make([]LintFileReport, 0, len(files))
+	for _, entry := range files {
+		report.ErrorCount += len(entry.Errors)
+		report.WarningCount += len(entry.Warnings)
+		report.Files = append(report.Files, *entry)
+	}
+	slices.SortFunc(report.Files, func(a, b LintFileReport) int {
+		return strings.Compare(a.File, b.File)
+	})
+	report.Valid = report.ErrorCount == 0
+
+	lintLog.Printf("Built lint report: files=%d, errors=%d, warnings=%d", len(report.Files), report.ErrorCount, report.WarningCount)
+	return report
+}
+
+// lintReportPath returns path relative to gitRoot using forward slashes, or the
+// cleaned path when it cannot be made relative (e.g. it lies outside the repository)
+func lintReportPath(path, gitRoot string) string {
+	if gitRoot == "" {
+		return filepath.ToSlash(filepath.Clean(path))
+	}
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return filepath.ToSlash(filepath.Clean(path))
+	}
+	rel, err := filepath.Rel(gitRoot, absPath)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return filepath.ToSlash(filepath.Clean(path))
+	}
+	return filepath.ToSlash(rel)
+}
+
+// printLintReport prints the report as JSON to stdout or as text to stderr, and
+// returns an error if the report contains any errors.
+func printLintReport(report LintReport, jsonOutput bool) error {
+	if jsonOutput {
+		jsonBytes, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal JSON: %w", err)
+		}
+		fmt.Println(string(jsonBytes))
+	} else {
+		printLintReportText(report)
+	}
+
+	if !report.Valid {
+		return fmt.Errorf("lint found %d error(s)", report.ErrorCount)
+	}
+	return nil
+}
+
+// printLintReportText prints the files with diagnostics followed by a summary line
+func printLintReportText(report LintReport) {
+	for _, entry := range report.Files {
+		if len(entry.Errors) == 0 && len(entry.Warnings) == 0 {
+			continue
+		}
+		fmt.Fprintln(os.Stderr, console.FormatInfoMessage(entry.File))
+		printLintDiagnostics("errors", entry.Errors)
+		printLintDiagnostics("warnings", entry.Warnings)
+		fmt.Fprintln(os.Stderr)
+	}
+
+	summary := fmt.Sprintf("Linted %d workflow(s): %d error(s), %d warning(s)",
+		report.Workflows, report.ErrorCount, report.WarningCount)
+	switch {
+	case report.ErrorCount > 0:
+		fmt.Fprintln(os.Stderr, console.FormatErrorMessage(summary))
+	case report.WarningCount > 0:
+		fmt.Fprintln(os.Stderr, console.FormatWarningMessage(summary))
+	default:
+		fmt.Fprintln(os.Stderr, console.FormatSuccessMessage(summary))
+	}
+}
+
+// printLintDiagnostics prints one severity group, indenting multi-line messages
+func printLintDiagnostics(severity string, diagnostics []LintDiagnostic) {
+	if len(diagnostics) == 0 {
+		return
+	}
+	fmt.Fprintf(os.Stderr, "  %s (%d):\n", severity, len(diagnostics))
+	for _, diagnostic := range diagnostics {
+		message := strings.ReplaceAll(strings.TrimSpace(diagnostic.Message), "\n", "\n      ")
+		fmt.Fprintf(os.Stderr, "    - [%s] %s\n", diagnostic.Type, message)
+	}
+}
@@ -0,0 +1,93 @@
+//go:build !integration
+
+package cli
+
+import (
+	"testing"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildLintReport(t *testing.T) {
+	results := []ValidationResult{
+		{Workflow: "zeta.md", Valid: true, path: "/repo/.github/workflows/zeta.md"},
+		{
+			Workflow: "alpha.md",
+			Errors:   []CompileValidationError{{Type: "compilation_error", Message: "concurrency validation failed"}},
+			Warnings: []CompileValidationError{{Type: "shared_workflow", Message: "Skipped"}},
+			path:     "/repo/.github/workflows/alpha.md",
+		},
+	}
+	warnings := []console.CompilerError{
+		{Position: console.ErrorPosition{File: "/repo/.github/workflows/zeta.md", Line: 1}, Type: "warning", Message: "Schedule uses fixed weekly time"},
+		{Position: console.ErrorPosition{File: "/repo/.github/workflows/shared/tools.md", Line: 1}, Type: "warning", Message: "Unused import"},
+	}
+
+	report := buildLintReport(results, warnings, "/repo")
+
+	assert.False(t, report.Valid, "report with errors should be invalid")
+	assert.Equal(t, 2, report.Workflows, "workflow count should match the linted workflows")
+	assert.Equal(t, 1, report.ErrorCount, "error count should aggregate all files")
+	assert.Equal(t, 3, report.WarningCount, "warning count should include compiler warnings")
+	require.Len(t, report.Files, 3, "report should have an entry per file")
+
+	assert.Equal(t, ".github/workflows/alpha.md", report.Files[0].File, "files should be sorted by path")
+	assert.Len(t, report.Files[0].Errors, 1, "alpha.md should keep its error")
+	assert.Len(t, report.Files[0].Warnings, 1, "alpha.md should keep its warning")
+	assert.Equal(t, ".github/workflows/shared/tools.md", report.Files[1].File, "warnings against other files should add entries")
+	assert.Equal(t, ".github/workflows/zeta.md", report.Files[2].File, "files should be sorted by path")
+	assert.Empty(t, report.Files[2].Errors, "zeta.md should have no errors")
+	require.Len(t, report.Files[2].Warnings, 1, "compiler warnings should be grouped under their file")
+	assert.Equal(t, "Schedule uses fixed weekly time", report.Files[2].Warnings[0].Message, "warning message should be preserved")
+}
+
+func TestBuildLintReportKeepsSameNamedFilesApart(t *testing.T) {
+	results := []ValidationResult{
+		{Workflow: "tools.md", Valid: true, path: "/repo/.github/workflows/tools.md"},
+	}
+	warnings := []console.CompilerError{
+		{Position: console.ErrorPosition{File: "/repo/.github/workflows/tools.md", Line: 3}, Type: "warning", Message: "Workflow warning"},
+		{Position: console.ErrorPosition{File: "/repo/.github/workflows/shared/tools.md", Line: 5}, Type: "warning", Message: "Import warning"},
+	}
+
+	report := buildLintReport(results, warnings, "/repo")
+
+	require.Len(t, report.Files, 2, "same-named files in different directories should get separate entries")
+	assert.Equal(t, ".github/workflows/shared/tools.md", report.Files[0].File, "shared import should be keyed by its relative path")
+	require.Len(t, report.Files[0].Warnings, 1, "shared import should only hold its own warning")
+	assert.Equal(t, "Import warning", report.Files[0].Warnings[0].Message, "shared import warning should be preserved")
+	assert.Equal(t, ".github/workflows/tools.md", report.Files[1].File, "workflow should be keyed by its relative path")
+	require.Len(t, report.Files[1].Warnings, 1, "workflow should only hold its own warning")
+	assert.Equal(t, "Workflow warning", report.Files[1].Warnings[0].Message, "workflow warning should be preserved")
+}
+
+func TestLintReportPath(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     string
+		gitRoot  string
+		expected string
+	}{
+		{name: "inside repository", path: "/repo/.github/workflows/ci.md", gitRoot: "/repo", expected: ".github/workflows/ci.md"},
+		{name: "outside repository", path: "/other/ci.md", gitRoot: "/repo", expected: "/other/ci.md"},
+		{name: "no git root", path: "/repo/.github/workflows/ci.md", gitRoot: "", expected: "/repo/.github/workflows/ci.md"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, lintReportPath(tt.path, tt.gitRoot), "relative path should match")
+		})
+	}
+}
+
+func TestBuildLintReportClean(t *testing.T) {
+	report := buildLintReport([]ValidationResult{{Workflow: "ok.md", Valid: true}}, nil, "")
+
+	assert.True(t, report.Valid, "report without errors should be valid")
+	require.Len(t, report.Files, 1, "clean workflows should still be listed")
+	assert.NotNil(t, report.Files[0].Errors, "errors should encode as an empty list")
+	assert.NotNil(t, report.Files[0].Warnings, "warnings should encode as an empty list")
+	assert.NoError(t, printLintReport(report, true), "clean report should not fail the run")
+}
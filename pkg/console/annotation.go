@@ -0,0 +1,48 @@
+package console
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FormatGitHubAnnotation formats a compiler diagnostic as a GitHub Actions workflow
+// command (e.g., "::warning file=a.md,line=1,col=1::message") so it is rendered as an
+// annotation when printed to stdout in a GitHub Actions run.
+func FormatGitHubAnnotation(err CompilerError) string {
+	command := err.Type
+	if command == "info" {
+		command = "notice"
+	} else if command != "warning" {
+		command = "error"
+	}
+
+	var props []string
+	if err.Position.File != "" {
+		props = append(props, "file="+escapeAnnotationProperty(err.Position.File))
+	}
+	if err.Position.Line > 0 {
+		props = append(props, fmt.Sprintf("line=%d", err.Position.Line))
+	}
+	if err.Position.Column > 0 {
+		props = append(props, fmt.Sprintf("col=%d", err.Position.Column))
+	}
+
+	if len(props) == 0 {
+		return fmt.Sprintf("::%s::%s", command, escapeAnnotationData(err.Message))
+	}
+	return fmt.Sprintf("::%s %s::%s", command, strings.Join(props, ","), escapeAnnotationData(err.Message))
+}
+
+// escapeAnnotationData escapes a workflow command message
+func escapeAnnotationData(s string) string {
+	s = strings.ReplaceAll(s, "%", "%25")
+	s = strings.ReplaceAll(s, "\r", "%0D")
+	return strings.ReplaceAll(s, "\n", "%0A")
+}
+
+// escapeAnnotationProperty escapes a workflow command property value
+func escapeAnnotationProperty(s string) string {
+	s = escapeAnnotationData(s)
+	s = strings.ReplaceAll(s, ":", "%3A")
+	return strings.ReplaceAll(s, ",", "%2C")
+}
@@ -0,0 +1,52 @@
+//go:build !integration
+
+package console
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatGitHubAnnotation(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      CompilerError
+		expected string
+	}{
+		{
+			name: "warning with position",
+			err: CompilerError{
+				Position: ErrorPosition{File: ".github/workflows/test.md", Line: 3, Column: 5},
+				Type:     "warning",
+				Message:  "Using experimental feature: plugins",
+			},
+			expected: "::warning file=.github/workflows/test.md,line=3,col=5::Using experimental feature: plugins",
+		},
+		{
+			name:     "error without position",
+			err:      CompilerError{Type: "error", Message: "compilation failed"},
+			expected: "::error::compilation failed",
+		},
+		{
+			name:     "info maps to notice",
+			err:      CompilerError{Position: ErrorPosition{File: "a.md"}, Type: "info", Message: "note"},
+			expected: "::notice file=a.md::note",
+		},
+		{
+			name: "message and property escaping",
+			err: CompilerError{
+				Position: ErrorPosition{File: "dir,a:b.md", Line: 1},
+				Type:     "warning",
+				Message:  "100% done\nnext line",
+			},
+			expected: "::warning file=dir%2Ca%3Ab.md,line=1::100%25 done%0Anext line",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, FormatGitHubAnnotation(tt.err), "annotation should match")
+		})
+	}
+}
@@ -0,0 +1,18 @@
+package console
+
+import "os"
+
+// noColor is set by the --no-color flag
+var noColor bool
+
+// SetNoColor disables all ANSI styling of console output when disabled is true, as
+// setting the NO_COLOR environment variable does
+func SetNoColor(disabled bool) {
+	noColor = disabled
+}
+
+// IsColorDisabled reports whether ANSI styling is disabled by --no-color or by the
+// NO_COLOR environment variable (https://no-color.org), regardless of the terminal
+func IsColorDisabled() bool {
+	return noColor || os.Getenv("NO_COLOR") != ""
+}
@@ -0,0 +1,26 @@
+//go:build !integration
+
+package console
+
+import (
+	"testing"
+
+	"github.com/github/gh-aw/pkg/styles"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsColorDisabled(t *testing.T) {
+	t.Cleanup(func() { SetNoColor(false) })
+
+	t.Setenv("NO_COLOR", "")
+	assert.False(t, IsColorDisabled(), "colors should be enabled by default")
+
+	SetNoColor(true)
+	assert.True(t, IsColorDisabled(), "--no-color should disable colors")
+	assert.Equal(t, "text", applyStyle(styles.Error, "text"), "styles should not be applied with --no-color")
+	SetNoColor(false)
+
+	t.Setenv("NO_COLOR", "1")
+	assert.True(t, IsColorDisabled(), "NO_COLOR should disable colors")
+	assert.Equal(t, "text", applyStyle(styles.Error, "text"), "styles should not be applied with NO_COLOR")
+}
@@ -22,9 +22,14 @@ func isTTY() bool {
 	return tty.IsStdoutTerminal()
 }
 
-// applyStyle conditionally applies styling based on TTY status
+// isStderrStyled checks if styled output should be written to stderr
+func isStderrStyled() bool {
+	return tty.IsStderrTerminal() && !IsColorDisabled()
+}
+
+// applyStyle conditionally applies styling based on TTY status, unless colors are disabled
 func applyStyle(style lipgloss.Style, text string) string {
-	if isTTY() {
+	if isTTY() && !IsColorDisabled() {
 		return style.Render(text)
 	}
 	return text
@@ -164,7 +169,7 @@ func RenderTable(config TableConfig) string {
 	dataRowCount := len(config.Rows)
 
 	styleFunc := func(row, col int) lipgloss.Style {
-		if !isTTY() {
+		if !isTTY() || IsColorDisabled() {
 			return lipgloss.NewStyle()
 		}
 		if row == table.HeaderRow {
@@ -239,7 +244,7 @@ func FormatSectionHeader(header string) string {
 
 // RenderTitleBox renders a title with a double border box in TTY mode
 func RenderTitleBox(title string, width int) []string {
-	if tty.IsStderrTerminal() {
+	if isStderrStyled() {
 		box := lipgloss.NewStyle().
 			Bold(true).
 			Foreground(styles.ColorInfo).
@@ -257,7 +262,7 @@ func RenderTitleBox(title string, width int) []string {
 
 // RenderErrorBox renders an error/warning message with a rounded border box
 func RenderErrorBox(title string) []string {
-	if tty.IsStderrTerminal() {
+	if isStderrStyled() {
 		box := lipgloss.NewStyle().
 			Border(styles.RoundedBorder).
 			BorderForeground(styles.ColorError).
@@ -274,7 +279,7 @@ func RenderErrorBox(title string) []string {
 
 // RenderInfoSection renders an info section with left border emphasis
 func RenderInfoSection(content string) []string {
-	if tty.IsStderrTerminal() {
+	if isStderrStyled() {
 		section := lipgloss.NewStyle().
 			Border(lipgloss.NormalBorder(), false, false, false, true).
 			BorderForeground(styles.ColorInfo).
@@ -293,7 +298,7 @@ func RenderInfoSection(content string) []string {
 
 // RenderComposedSections composes and outputs a slice of sections to stderr
 func RenderComposedSections(sections []string) {
-	if tty.IsStderrTerminal() {
+	if isStderrStyled() {
 		plan := lipgloss.JoinVertical(lipgloss.Left, sections...)
 		fmt.Fprintln(os.Stderr, "")
 		fmt.Fprintln(os.Stderr, plan)
@@ -24,17 +24,23 @@ var mainWorkflowSchema string
 //go:embed schemas/mcp_config_schema.json
 var mcpConfigSchema string
 
+//go:embed schemas/repo_config_schema.json
+var repoConfigSchema string
+
 // validateWithSchema validates frontmatter against a JSON schema
 // Cached compiled schemas to avoid recompiling on every validation
 var (
 	mainWorkflowSchemaOnce sync.Once
 	mcpConfigSchemaOnce    sync.Once
+	repoConfigSchemaOnce   sync.Once
 
 	compiledMainWorkflowSchema *jsonschema.Schema
 	compiledMcpConfigSchema    *jsonschema.Schema
+	compiledRepoConfigSchema   *jsonschema.Schema
 
 	mainWorkflowSchemaError error
 	mcpConfigSchemaError    error
+	repoConfigSchemaError   error
 )
 
 // getCompiledMainWorkflowSchema returns the compiled main workflow schema, compiling it once and caching
@@ -53,6 +59,14 @@ func getCompiledMcpConfigSchema() (*jsonschema.Schema, error) {
 	return compiledMcpConfigSchema, mcpConfigSchemaError
 }
 
+// getCompiledRepoConfigSchema returns the compiled repository config schema, compiling it once and caching
+func getCompiledRepoConfigSchema() (*jsonschema.Schema, error) {
+	repoConfigSchemaOnce.Do(func() {
+		compiledRepoConfigSchema, repoConfigSchemaError = compileSchema(repoConfigSchema, "http://contoso.com/repo-config-schema.json")
+	})
+	return compiledRepoConfigSchema, repoConfigSchemaError
+}
+
 // compileSchema compiles a JSON schema from a JSON string
 func compileSchema(schemaJSON, schemaURL string) (*jsonschema.Schema, error) {
 	schemaCompilerLog.Printf("Compiling JSON schema: %s", schemaURL)
@@ -150,6 +164,9 @@ func validateWithSchema(frontmatter map[string]any, schemaJSON, context string)
 	case mcpConfigSchema:
 		schemaCompilerLog.Print("Using cached MCP config schema")
 		schema, err = getCompiledMcpConfigSchema()
+	case repoConfigSchema:
+		schemaCompilerLog.Print("Using cached repository config schema")
+		schema, err = getCompiledRepoConfigSchema()
 	default:
 		// Fallback for unknown schemas (shouldn't happen in normal operation)
 		// Compile the schema on-the-fly
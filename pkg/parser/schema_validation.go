@@ -1,9 +1,12 @@
 package parser
 
 import (
+	"errors"
 	"fmt"
 	"maps"
+	"strings"
 
+	"github.com/github/gh-aw/pkg/console"
 	"github.com/github/gh-aw/pkg/constants"
 	"github.com/github/gh-aw/pkg/logger"
 )
@@ -100,3 +103,28 @@ func ValidateIncludedFileFrontmatterWithSchemaAndLocation(frontmatter map[string
 }
 
 // ValidateMCPConfigWithSchema validates MCP configuration using JSON schema
+
+// ValidateRepoConfigWithSchema validates a repository-level gh-aw configuration file
+// (e.g. .github/gh-aw.yml) against the repository config schema. Errors are reported
+// with the config file path so users can locate the offending file.
+func ValidateRepoConfigWithSchema(config map[string]any, filePath string) error {
+	schemaValidationLog.Printf("Validating repository config: file=%s, fields=%d", filePath, len(config))
+	err := validateWithSchema(config, repoConfigSchema, "repository config")
+	if err == nil {
+		return nil
+	}
+
+	message := err.Error()
+	if strings.Contains(message, "jsonschema validation failed") {
+		message = cleanJSONSchemaErrorMessage(message)
+	}
+	return errors.New(console.FormatError(console.CompilerError{
+		Position: console.ErrorPosition{
+			File:   filePath,
+			Line:   1,
+			Column: 1,
+		},
+		Type:    "error",
+		Message: "invalid repository config: " + message,
+	}))
+}
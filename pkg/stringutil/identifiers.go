@@ -60,6 +60,9 @@ func NormalizeSafeOutputIdentifier(identifier string) string {
 	return strings.ReplaceAll(identifier, "-", "_")
 }
 
+// DefaultLockFileSuffix is the file suffix of compiled workflow lock files
+const DefaultLockFileSuffix = ".lock.yml"
+
 // MarkdownToLockFile converts a workflow markdown file path to its compiled lock file path.
 // This is the standard transformation for agentic workflow files.
 //
@@ -73,13 +76,24 @@ func NormalizeSafeOutputIdentifier(identifier string) string {
 //	MarkdownToLockFile("workflow.lock.yml")                     // returns "workflow.lock.yml" (unchanged)
 //	MarkdownToLockFile("my.workflow.md")                        // returns "my.workflow.lock.yml"
 func MarkdownToLockFile(mdPath string) string {
+	return MarkdownToLockFileWithSuffix(mdPath, DefaultLockFileSuffix)
+}
+
+// MarkdownToLockFileWithSuffix is like MarkdownToLockFile but uses suffix (e.g. ".gen.yml")
+// instead of .lock.yml for the compiled lock file.
+//
+// Examples:
+//
+//	MarkdownToLockFileWithSuffix("weekly-research.md", ".gen.yml")  // returns "weekly-research.gen.yml"
+//	MarkdownToLockFileWithSuffix("workflow.gen.yml", ".gen.yml")    // returns "workflow.gen.yml" (unchanged)
+func MarkdownToLockFileWithSuffix(mdPath string, suffix string) string {
 	// If already a lock file, return unchanged
-	if strings.HasSuffix(mdPath, ".lock.yml") {
+	if strings.HasSuffix(mdPath, suffix) {
 		return mdPath
 	}
 
 	cleaned := filepath.Clean(mdPath)
-	lockPath := strings.TrimSuffix(cleaned, ".md") + ".lock.yml"
+	lockPath := strings.TrimSuffix(cleaned, ".md") + suffix
 	identifiersLog.Printf("MarkdownToLockFile: %s -> %s", mdPath, lockPath)
 	return lockPath
 }
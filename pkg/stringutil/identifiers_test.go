@@ -214,6 +214,49 @@ func TestMarkdownToLockFile(t *testing.T) {
 	}
 }
 
+func TestMarkdownToLockFileWithSuffix(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		suffix   string
+		expected string
+	}{
+		{
+			name:     "custom suffix",
+			input:    ".github/workflows/test.md",
+			suffix:   ".gen.yml",
+			expected: ".github/workflows/test.gen.yml",
+		},
+		{
+			name:     "yaml extension",
+			input:    "weekly-research.md",
+			suffix:   ".lock.yaml",
+			expected: "weekly-research.lock.yaml",
+		},
+		{
+			name:     "already a lock file with custom suffix",
+			input:    "workflow.gen.yml",
+			suffix:   ".gen.yml",
+			expected: "workflow.gen.yml",
+		},
+		{
+			name:     "default suffix",
+			input:    "workflow.md",
+			suffix:   DefaultLockFileSuffix,
+			expected: "workflow.lock.yml",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := MarkdownToLockFileWithSuffix(tt.input, tt.suffix)
+			if result != tt.expected {
+				t.Errorf("MarkdownToLockFileWithSuffix(%q, %q) = %q, expected %q", tt.input, tt.suffix, result, tt.expected)
+			}
+		})
+	}
+}
+
 func TestLockFileToMarkdown(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -0,0 +1,134 @@
+// This file provides the actionlint frontmatter configuration.
+//
+// The actionlint.ignore frontmatter key lists actionlint rule kinds whose findings
+// should be suppressed for the workflow when it is compiled with --actionlint, e.g.
+// runner-label findings for self-hosted runner labels that actionlint does not know:
+//
+//	actionlint:
+//	  ignore: [runner-label]
+//
+// The compiler only records the list on WorkflowData; the CLI applies it when it
+// displays and counts actionlint results for the workflow's lock file.
+//
+// The compiler also records the custom runner labels declared in runs-on fields, so
+// that the CLI can pass them to actionlint as self-hosted runner labels instead of
+// reporting them as unknown.
+
+package workflow
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var actionlintConfigLog = logger.New("workflow:actionlint_config")
+
+// KnownActionlintKinds lists the rule kinds that actionlint reports findings under
+var KnownActionlintKinds = []string{
+	"action",
+	"credentials",
+	"deprecated-commands",
+	"env-var",
+	"events",
+	"expression",
+	"glob",
+	"id",
+	"if-cond",
+	"job-needs",
+	"matrix",
+	"permissions",
+	"pyflakes",
+	"runner-label",
+	"shell-name",
+	"shellcheck",
+	"syntax-check",
+	"workflow-call",
+}
+
+// extractActionlintIgnore extracts the actionlint.ignore list from frontmatter.
+// Unknown kinds are kept, since newer actionlint releases may add kinds, but a
+// warning is emitted so that typos do not go unnoticed.
+func (c *Compiler) extractActionlintIgnore(frontmatter map[string]any) []string {
+	config, ok := frontmatter["actionlint"].(map[string]any)
+	if !ok {
+		return nil
+	}
+	ignoreList, ok := config["ignore"].([]any)
+	if !ok {
+		return nil
+	}
+
+	var kinds []string
+	for _, item := range ignoreList {
+		kind, ok := item.(string)
+		if !ok || kind == "" || slices.Contains(kinds, kind) {
+			continue
+		}
+		if !slices.Contains(KnownActionlintKinds, kind) {
+			c.emitWarning(fmt.Sprintf("actionlint.ignore: unknown actionlint kind %q (known kinds: %s)", kind, strings.Join(KnownActionlintKinds, ", ")))
+		}
+		kinds = append(kinds, kind)
+	}
+
+	actionlintConfigLog.Printf("Extracted %d actionlint ignore kind(s)", len(kinds))
+	return kinds
+}
+
+// githubHostedRunnerLabelPattern matches labels that actionlint already knows: GitHub-hosted
+// runner images and the default labels of self-hosted runners
+var githubHostedRunnerLabelPattern = regexp.MustCompile(`(?i)^((ubuntu|windows|macos)-.+|self-hosted|linux|windows|macos|x64|arm|arm64)$`)
+
+// extractSelfHostedRunnerLabels extracts the custom runner labels declared in the top-level,
+// safe-outputs, and custom job runs-on fields. GitHub-hosted labels, runner group names,
+// and expressions are left out.
+func extractSelfHostedRunnerLabels(frontmatter map[string]any) []string {
+	runsOnValues := []any{frontmatter["runs-on"]}
+	if safeOutputs, ok := frontmatter["safe-outputs"].(map[string]any); ok {
+		runsOnValues = append(runsOnValues, safeOutputs["runs-on"])
+	}
+	if jobs, ok := frontmatter["jobs"].(map[string]any); ok {
+		for _, job := range jobs {
+			if jobConfig, ok := job.(map[string]any); ok {
+				runsOnValues = append(runsOnValues, jobConfig["runs-on"])
+			}
+		}
+	}
+
+	var labels []string
+	for _, runsOn := range runsOnValues {
+		for _, label := range runnerLabels(runsOn) {
+			if strings.Contains(label, "${{") || githubHostedRunnerLabelPattern.MatchString(label) || slices.Contains(labels, label) {
+				continue
+			}
+			labels = append(labels, label)
+		}
+	}
+	slices.Sort(labels)
+
+	actionlintConfigLog.Printf("Extracted %d self-hosted runner label(s)", len(labels))
+	return labels
+}
+
+// runnerLabels returns the labels of a runs-on value: a label, a list of labels, or an
+// object with a labels field
+func runnerLabels(runsOn any) []string {
+	switch value := runsOn.(type) {
+	case string:
+		return []string{value}
+	case []any:
+		var labels []string
+		for _, item := range value {
+			if label, ok := item.(string); ok {
+				labels = append(labels, label)
+			}
+		}
+		return labels
+	case map[string]any:
+		return runnerLabels(value["labels"])
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtractActionlintIgnore(t *testing.T) {
+	tests := []struct {
+		name         string
+		frontmatter  map[string]any
+		want         []string
+		wantWarnings int
+	}{
+		{name: "not configured", frontmatter: map[string]any{}},
+		{name: "no ignore list", frontmatter: map[string]any{"actionlint": map[string]any{}}},
+		{
+			name:        "known kinds",
+			frontmatter: map[string]any{"actionlint": map[string]any{"ignore": []any{"runner-label", "shellcheck", "runner-label"}}},
+			want:        []string{"runner-label", "shellcheck"},
+		},
+		{
+			name:         "unknown kind",
+			frontmatter:  map[string]any{"actionlint": map[string]any{"ignore": []any{"runner-labels"}}},
+			want:         []string{"runner-labels"},
+			wantWarnings: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			compiler := NewCompiler()
+			var got []string
+			output := testutil.CaptureStderr(t, func() {
+				got = compiler.extractActionlintIgnore(tt.frontmatter)
+			})
+
+			assert.Equal(t, tt.want, got, "ignored kinds")
+			assert.Equal(t, tt.wantWarnings, compiler.GetWarningCount(), "warning count")
+			if tt.wantWarnings > 0 {
+				assert.Contains(t, output, `unknown actionlint kind "runner-labels"`, "warning should name the unknown kind")
+			}
+		})
+	}
+}
+
+func TestExtractSelfHostedRunnerLabels(t *testing.T) {
+	tests := []struct {
+		name        string
+		frontmatter map[string]any
+		want        []string
+	}{
+		{name: "no runs-on", frontmatter: map[string]any{}},
+		{name: "github-hosted", frontmatter: map[string]any{"runs-on": "ubuntu-latest"}},
+		{name: "custom label", frontmatter: map[string]any{"runs-on": "gpu-runner"}, want: []string{"gpu-runner"}},
+		{
+			name:        "label list",
+			frontmatter: map[string]any{"runs-on": []any{"self-hosted", "linux", "X64", "gpu-runner"}},
+			want:        []string{"gpu-runner"},
+		},
+		{
+			name:        "runner group",
+			frontmatter: map[string]any{"runs-on": map[string]any{"group": "large-runners", "labels": []any{"gpu-runner"}}},
+			want:        []string{"gpu-runner"},
+		},
+		{
+			name:        "expression",
+			frontmatter: map[string]any{"runs-on": "${{ inputs.runner }}"},
+		},
+		{
+			name: "safe-outputs and custom jobs",
+			frontmatter: map[string]any{
+				"runs-on":      "gpu-runner",
+				"safe-outputs": map[string]any{"runs-on": "arm-runner"},
+				"jobs":         map[string]any{"build": map[string]any{"runs-on": []any{"self-hosted", "gpu-runner"}}},
+			},
+			want: []string{"arm-runner", "gpu-runner"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, extractSelfHostedRunnerLabels(tt.frontmatter), "self-hosted runner labels")
+		})
+	}
+}
@@ -268,6 +268,49 @@ Do something useful.
 		assert.Contains(t, lockStr, "github-token: ${{ secrets.MY_TOKEN }}", "Token should be used in the reaction step")
 	})
 
+	t.Run("github_token_from_env_accepted", func(t *testing.T) {
+		workflowContent := `---
+on:
+  issue_comment:
+    types: [created]
+  github-token: ${{ secrets.MY_TOKEN || env.ACTIVATION_TOKEN }}
+  reaction: eyes
+engine: copilot
+---
+Do something useful.
+`
+		mdPath := filepath.Join(tmpDir, "env-token-workflow.md")
+		err := os.WriteFile(mdPath, []byte(workflowContent), 0600)
+		require.NoError(t, err)
+
+		err = compiler.CompileWorkflow(mdPath)
+		require.NoError(t, err, "Compilation should accept env references in on.github-token")
+
+		lockContent, err := os.ReadFile(filepath.Join(tmpDir, "env-token-workflow.lock.yml"))
+		require.NoError(t, err)
+		assert.Contains(t, string(lockContent), "github-token: ${{ secrets.MY_TOKEN || env.ACTIVATION_TOKEN }}", "Token should be used in the reaction step")
+	})
+
+	t.Run("github_token_invalid_expression_rejected", func(t *testing.T) {
+		workflowContent := `---
+on:
+  issue_comment:
+    types: [created]
+  github-token: ${{ github.token }}
+  reaction: eyes
+engine: copilot
+---
+Do something useful.
+`
+		mdPath := filepath.Join(tmpDir, "invalid-token-workflow.md")
+		err := os.WriteFile(mdPath, []byte(workflowContent), 0600)
+		require.NoError(t, err)
+
+		err = compiler.CompileWorkflow(mdPath)
+		require.Error(t, err, "Compilation should reject on.github-token values that are not secrets, vars or env references")
+		assert.Contains(t, err.Error(), "on.github-token validation failed", "Error should name the field")
+	})
+
 	t.Run("github_token_commented_when_no_reaction", func(t *testing.T) {
 		workflowContent := `---
 on:
@@ -161,8 +161,7 @@ func (c *Compiler) validateWebSearchSupport(tools map[string]any, engine CodingA
 	// web-search is specified, check if the engine supports it
 	if !engine.SupportsWebSearch() {
 		agentValidationLog.Printf("Engine %s does not natively support web-search tool, emitting warning", engine.GetID())
-		fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Engine '%s' does not support the web-search tool. See https://github.github.com/gh-aw/guides/web-search/ for alternatives.", engine.GetID())))
-		c.IncrementWarningCount()
+		c.emitWarning(fmt.Sprintf("Engine '%s' does not support the web-search tool. See https://github.github.com/gh-aw/guides/web-search/ for alternatives.", engine.GetID()))
 	}
 }
 
@@ -239,9 +238,7 @@ func (c *Compiler) validateWorkflowRunBranches(workflowData *WorkflowData, markd
 	}
 
 	// In normal mode, this is a warning
-	formattedWarning := formatCompilerMessage(markdownPath, "warning", message)
-	fmt.Fprintln(os.Stderr, formattedWarning)
-	c.IncrementWarningCount()
+	c.emitCompilerWarning(markdownPath, message)
 
 	return nil
 }
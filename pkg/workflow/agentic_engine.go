@@ -253,6 +253,11 @@ type BaseEngine struct {
 	supportsWebSearch        bool
 	supportsPlugins          bool
 	llmGatewayPort           int
+	// defaultConcurrency is the agent job concurrency group template used when the
+	// workflow does not configure engine.concurrency. "{engine}" is replaced with the
+	// engine ID; engineDefaultConcurrencyNone opts the engine out of default grouping.
+	// Empty uses the generic gh-aw-{engine}-${{ github.workflow }} group.
+	defaultConcurrency string
 }
 
 func (e *BaseEngine) GetID() string {
@@ -299,6 +304,10 @@ func (e *BaseEngine) getLLMGatewayPort() int {
 	return e.llmGatewayPort
 }
 
+func (e *BaseEngine) getDefaultConcurrency() string {
+	return e.defaultConcurrency
+}
+
 // GetDeclaredOutputFiles returns an empty list by default (engines can override)
 func (e *BaseEngine) GetDeclaredOutputFiles() []string {
 	return []string{}
@@ -81,22 +81,12 @@ func parseCacheMemoryEntry(cacheMap map[string]any, defaultID string) (CacheMemo
 	}
 
 	// Parse retention days
-	if retentionDays, exists := cacheMap["retention-days"]; exists {
-		if retentionDaysInt, ok := retentionDays.(int); ok {
-			entry.RetentionDays = &retentionDaysInt
-		} else if retentionDaysFloat, ok := retentionDays.(float64); ok {
-			retentionDaysIntValue := int(retentionDaysFloat)
-			entry.RetentionDays = &retentionDaysIntValue
-		} else if retentionDaysUint64, ok := retentionDays.(uint64); ok {
-			retentionDaysIntValue := int(retentionDaysUint64)
-			entry.RetentionDays = &retentionDaysIntValue
-		}
-		// Validate retention-days bounds
-		if entry.RetentionDays != nil {
-			if err := validateIntRange(*entry.RetentionDays, 1, 90, "retention-days"); err != nil {
-				return entry, err
-			}
+	if _, exists := cacheMap["retention-days"]; exists {
+		retentionDays, err := getMapFieldAsIntInRange(cacheMap, "retention-days", 0, 1, 90)
+		if err != nil {
+			return entry, err
 		}
+		entry.RetentionDays = &retentionDays
 	}
 
 	// Parse restore-only flag
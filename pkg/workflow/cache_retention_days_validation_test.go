@@ -210,3 +210,29 @@ func TestCacheMemoryRetentionDaysNoValue(t *testing.T) {
 		t.Errorf("Expected RetentionDays to be nil when not specified, got %d", *cache.RetentionDays)
 	}
 }
+
+// TestCacheMemoryRetentionDaysQuotedNumber tests that a quoted retention-days is read as a number
+func TestCacheMemoryRetentionDaysQuotedNumber(t *testing.T) {
+	toolsMap := map[string]any{
+		"cache-memory": map[string]any{
+			"retention-days": "30",
+		},
+	}
+
+	toolsConfig, err := ParseToolsConfig(toolsMap)
+	if err != nil {
+		t.Fatalf("Failed to parse tools config: %v", err)
+	}
+
+	compiler := NewCompiler()
+	config, err := compiler.extractCacheMemoryConfig(toolsConfig)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(config.Caches) != 1 {
+		t.Fatalf("Expected 1 cache, got %d", len(config.Caches))
+	}
+	if config.Caches[0].RetentionDays == nil || *config.Caches[0].RetentionDays != 30 {
+		t.Errorf("Expected retention-days 30, got %v", config.Caches[0].RetentionDays)
+	}
+}
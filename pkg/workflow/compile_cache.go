@@ -0,0 +1,200 @@
+// This file provides the persistent compile cache used across CLI invocations.
+//
+// # Compile Cache
+//
+// Repeated `gh aw compile` runs (e.g. from a pre-commit hook) usually recompile
+// workflows that have not changed. The compile cache stores generated lock file
+// content in a directory keyed by everything that determines it:
+//   - the compiler version and the compile options fingerprint
+//   - the workflow path and the parsed workflow inputs (WorkflowData.Hash)
+//   - the content of every imported file, the repository config file, and the
+//     action pin cache (.github/aw/actions-lock.json)
+//
+// A cache hit lets the caller skip YAML generation and validation and write the
+// cached lock content directly. When the compiler version changes, all entries
+// are discarded the first time the cache directory is opened.
+
+package workflow
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/parser"
+)
+
+var compileCacheLog = logger.New("workflow:compile_cache")
+
+// compileCacheVersionFile records the compiler version that wrote the cache entries
+const compileCacheVersionFile = "VERSION"
+
+// compileCacheEntryExt is the file extension of cached lock file entries
+const compileCacheEntryExt = ".lock.yml"
+
+// CompileCache is a persistent, content-addressed cache of compiled lock files
+type CompileCache struct {
+	dir     string // Cache directory
+	version string // Compiler version; entries from other versions are discarded
+	options string // Fingerprint of compile options that affect the generated lock file
+}
+
+// NewCompileCache opens (creating if necessary) the compile cache in dir. Entries written
+// by a different compiler version are removed. options fingerprints the compile options
+// that affect the generated output (e.g. engine override, action mode, strict mode).
+func NewCompileCache(dir, version, options string) (*CompileCache, error) {
+	compileCacheLog.Printf("Opening compile cache: dir=%s, version=%s", dir, version)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create compile cache directory: %w", err)
+	}
+
+	cache := &CompileCache{dir: dir, version: version, options: options}
+	versionPath := filepath.Join(dir, compileCacheVersionFile)
+	cachedVersion, err := os.ReadFile(versionPath)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("failed to read compile cache version: %w", err)
+	}
+	if string(cachedVersion) != version {
+		compileCacheLog.Printf("Compiler version changed (%q -> %q), invalidating compile cache", string(cachedVersion), version)
+		if err := cache.clear(); err != nil {
+			return nil, err
+		}
+		if err := os.WriteFile(versionPath, []byte(version), 0644); err != nil {
+			return nil, fmt.Errorf("failed to write compile cache version: %w", err)
+		}
+	}
+	return cache, nil
+}
+
+// clear removes all cached lock file entries
+func (c *CompileCache) clear() error {
+	entries, err := filepath.Glob(filepath.Join(c.dir, "*"+compileCacheEntryExt))
+	if err != nil {
+		return fmt.Errorf("failed to list compile cache entries: %w", err)
+	}
+	for _, entry := range entries {
+		if err := os.Remove(entry); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to remove compile cache entry: %w", err)
+		}
+	}
+	compileCacheLog.Printf("Removed %d compile cache entries", len(entries))
+	return nil
+}
+
+// Key returns the cache key for the workflow at markdownPath with the given parsed data
+func (c *CompileCache) Key(markdownPath string, data *WorkflowData) string {
+	h := sha256.New()
+	writeCompileCacheField(h, c.version)
+	writeCompileCacheField(h, c.options)
+	if absPath, err := filepath.Abs(markdownPath); err == nil {
+		markdownPath = absPath
+	}
+	writeCompileCacheField(h, markdownPath)
+	writeCompileCacheField(h, data.Hash())
+	for _, dependency := range compileCacheDependencies(markdownPath, data) {
+		writeCompileCacheField(h, dependency)
+		writeCompileCacheField(h, hashFileContent(dependency))
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Get returns the cached lock file content for key
+func (c *CompileCache) Get(key string) (string, bool) {
+	content, err := os.ReadFile(c.entryPath(key))
+	if err != nil {
+		compileCacheLog.Printf("Compile cache miss: %s", key)
+		return "", false
+	}
+	compileCacheLog.Printf("Compile cache hit: %s", key)
+	return string(content), true
+}
+
+// Put stores lock file content under key
+func (c *CompileCache) Put(key string, lockContent string) error {
+	// Write to a temporary file and rename so concurrent readers never see partial entries
+	tmp, err := os.CreateTemp(c.dir, "entry-*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create compile cache entry: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.WriteString(lockContent); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write compile cache entry: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write compile cache entry: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), c.entryPath(key)); err != nil {
+		return fmt.Errorf("failed to store compile cache entry: %w", err)
+	}
+	compileCacheLog.Printf("Stored compile cache entry: %s", key)
+	return nil
+}
+
+// entryPath returns the path of the cache entry for key
+func (c *CompileCache) entryPath(key string) string {
+	return filepath.Join(c.dir, key+compileCacheEntryExt)
+}
+
+// Hash returns a stable hash of the parsed workflow inputs that determine the compiled
+// output: the frontmatter, the markdown content, and the imports. It does not cover the
+// content of imported files on disk; CompileCache.Key adds those separately.
+func (w *WorkflowData) Hash() string {
+	h := sha256.New()
+	writeCompileCacheField(h, w.FrontmatterYAML)
+	writeCompileCacheField(h, w.MarkdownContent)
+	writeCompileCacheField(h, w.MainWorkflowMarkdown)
+	writeCompileCacheField(h, w.ImportedMarkdown)
+	writeCompileCacheField(h, strings.Join(w.ImportedFiles, "\n"))
+	writeCompileCacheField(h, strings.Join(w.ImportPaths, "\n"))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// writeCompileCacheField writes a length-prefixed field so adjacent fields cannot collide
+func writeCompileCacheField(h hash.Hash, value string) {
+	fmt.Fprintf(h, "%d:%s\n", len(value), value)
+}
+
+// compileCacheDependencies returns the files outside the workflow source whose content
+// affects the compiled output: imported files, the repository config, and the action pin cache
+func compileCacheDependencies(markdownPath string, data *WorkflowData) []string {
+	var dependencies []string
+	markdownDir := filepath.Dir(markdownPath)
+	for _, importedFile := range data.ImportedFiles {
+		// Strip section references (e.g., "shared/foo.md#Section")
+		importPath, _, _ := strings.Cut(importedFile, "#")
+		if strings.HasPrefix(importPath, parser.BuiltinPathPrefix) {
+			// Builtin imports are embedded in the binary and covered by the compiler version
+			continue
+		}
+		fullPath := filepath.Join(markdownDir, importPath)
+		if _, err := os.Stat(fullPath); err != nil {
+			// Remote imports (owner/repo/path@ref) are covered by the import spec in Hash
+			continue
+		}
+		dependencies = append(dependencies, fullPath)
+	}
+	if repoConfigPath := findRepoConfigPath(markdownPath); repoConfigPath != "" {
+		dependencies = append(dependencies, repoConfigPath)
+	}
+	if data.ActionCache != nil {
+		dependencies = append(dependencies, data.ActionCache.GetCachePath())
+	}
+	return dependencies
+}
+
+// hashFileContent returns the SHA-256 of a file's content, or "" when it cannot be read
+func hashFileContent(path string) string {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,91 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompileCacheRoundTrip(t *testing.T) {
+	cache, err := NewCompileCache(testutil.TempDir(t, "compile-cache-*"), "v1.0.0", "")
+	require.NoError(t, err, "cache should open")
+
+	_, ok := cache.Get("missing")
+	assert.False(t, ok, "unknown key should miss")
+
+	require.NoError(t, cache.Put("key", "name: test\n"), "put should succeed")
+	content, ok := cache.Get("key")
+	require.True(t, ok, "stored key should hit")
+	assert.Equal(t, "name: test\n", content, "cached content should round trip")
+}
+
+func TestCompileCacheVersionInvalidation(t *testing.T) {
+	dir := testutil.TempDir(t, "compile-cache-*")
+
+	cache, err := NewCompileCache(dir, "v1.0.0", "")
+	require.NoError(t, err, "cache should open")
+	require.NoError(t, cache.Put("key", "name: test\n"), "put should succeed")
+
+	reopened, err := NewCompileCache(dir, "v1.0.0", "")
+	require.NoError(t, err, "cache should reopen")
+	_, ok := reopened.Get("key")
+	assert.True(t, ok, "entries should survive reopening with the same version")
+
+	upgraded, err := NewCompileCache(dir, "v1.1.0", "")
+	require.NoError(t, err, "cache should open with a new version")
+	_, ok = upgraded.Get("key")
+	assert.False(t, ok, "entries should be discarded when the compiler version changes")
+
+	version, err := os.ReadFile(filepath.Join(dir, compileCacheVersionFile))
+	require.NoError(t, err, "version file should exist")
+	assert.Equal(t, "v1.1.0", string(version), "version file should record the new version")
+}
+
+func TestCompileCacheKey(t *testing.T) {
+	dir := testutil.TempDir(t, "compile-cache-key-*")
+	markdownPath := filepath.Join(dir, "workflow.md")
+	sharedPath := filepath.Join(dir, "shared", "tools.md")
+	require.NoError(t, os.MkdirAll(filepath.Dir(sharedPath), 0755), "should create shared dir")
+	require.NoError(t, os.WriteFile(sharedPath, []byte("# Tools\n"), 0644), "should write import")
+
+	cache, err := NewCompileCache(filepath.Join(dir, "cache"), "v1.0.0", "engine=")
+	require.NoError(t, err, "cache should open")
+
+	newData := func() *WorkflowData {
+		return &WorkflowData{
+			FrontmatterYAML: "on: issues\n",
+			MarkdownContent: "# Workflow\n",
+			ImportedFiles:   []string{"shared/tools.md"},
+		}
+	}
+	baseKey := cache.Key(markdownPath, newData())
+	assert.Equal(t, baseKey, cache.Key(markdownPath, newData()), "key should be stable for identical inputs")
+
+	changedMarkdown := newData()
+	changedMarkdown.MarkdownContent = "# Changed\n"
+	assert.NotEqual(t, baseKey, cache.Key(markdownPath, changedMarkdown), "markdown changes should change the key")
+
+	assert.NotEqual(t, baseKey, cache.Key(filepath.Join(dir, "other.md"), newData()), "workflow path should be part of the key")
+
+	otherOptions, err := NewCompileCache(filepath.Join(dir, "cache"), "v1.0.0", "engine=claude")
+	require.NoError(t, err, "cache should reopen")
+	assert.NotEqual(t, baseKey, otherOptions.Key(markdownPath, newData()), "compile options should be part of the key")
+
+	require.NoError(t, os.WriteFile(sharedPath, []byte("# Tools v2\n"), 0644), "should update import")
+	assert.NotEqual(t, baseKey, cache.Key(markdownPath, newData()), "imported file changes should change the key")
+}
+
+func TestWorkflowDataHash(t *testing.T) {
+	data := &WorkflowData{FrontmatterYAML: "on: issues\n", MarkdownContent: "body"}
+	assert.Equal(t, data.Hash(), (&WorkflowData{FrontmatterYAML: "on: issues\n", MarkdownContent: "body"}).Hash(), "hash should be stable")
+
+	// Length-prefixed fields must not collide when content moves between fields
+	shifted := &WorkflowData{FrontmatterYAML: "on: issues\nbody"}
+	assert.NotEqual(t, data.Hash(), shifted.Hash(), "moving content between fields should change the hash")
+}
@@ -0,0 +1,101 @@
+// This file provides the machine-readable compile manifest for compiled workflows.
+//
+// # Compile Manifest
+//
+// The compile manifest is a JSON document describing each compiled workflow. It is
+// intended as a stable integration point for downstream tooling such as dashboards
+// and policy checks, which should not need to re-parse markdown or lock files.
+//
+// Each entry is derived from the WorkflowData produced during compilation together
+// with the generated lock file content:
+//   - source and lock file paths
+//   - trigger event names from the "on" section
+//   - the resolved workflow-level concurrency group
+//   - the merged feature flag keys
+//   - the secrets referenced by the lock file
+//   - the computed workflow-level permissions
+
+package workflow
+
+import (
+	"sort"
+
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var compileManifestLog = logger.New("workflow:compile_manifest")
+
+// CompileManifest is the top-level document written by `compile --manifest`
+type CompileManifest struct {
+	Workflows []WorkflowManifestEntry `json:"workflows"`
+}
+
+// WorkflowManifestEntry describes a single compiled workflow
+type WorkflowManifestEntry struct {
+	Source           string            `json:"source"`                      // Path to the workflow markdown file
+	LockFile         string            `json:"lock_file"`                   // Path to the generated .lock.yml file
+	Triggers         []string          `json:"triggers"`                    // Sorted trigger event names from the "on" section
+	ConcurrencyGroup string            `json:"concurrency_group,omitempty"` // Resolved workflow-level concurrency group
+	Features         []string          `json:"features"`                    // Sorted feature flag keys after import merging
+	Secrets          []string          `json:"secrets"`                     // Sorted secret names referenced by the lock file
+	Permissions      map[string]string `json:"permissions"`                 // Workflow-level permissions by scope
+}
+
+// BuildWorkflowManifestEntry builds a manifest entry from compiled workflow data.
+// lockContent is the generated lock file YAML and is used to collect secret references;
+// it may be empty when no lock file was emitted.
+func BuildWorkflowManifestEntry(data *WorkflowData, sourcePath, lockPath, lockContent string) WorkflowManifestEntry {
+	compileManifestLog.Printf("Building manifest entry: source=%s", sourcePath)
+
+	entry := WorkflowManifestEntry{
+		Source:      sourcePath,
+		LockFile:    lockPath,
+		Triggers:    []string{},
+		Features:    []string{},
+		Secrets:     []string{},
+		Permissions: map[string]string{},
+	}
+	if data == nil {
+		return entry
+	}
+
+	entry.Triggers = extractTriggerNames(data.On)
+	entry.ConcurrencyGroup = extractConcurrencyGroupFromYAML(data.Concurrency)
+
+	for key := range data.Features {
+		entry.Features = append(entry.Features, key)
+	}
+	sort.Strings(entry.Features)
+
+	if lockContent != "" {
+		entry.Secrets = CollectSecretReferences(lockContent)
+	}
+
+	perms := NewPermissionsParser(data.Permissions).ToPermissions()
+	for _, scope := range GetAllPermissionScopes() {
+		if level, ok := perms.Get(scope); ok {
+			entry.Permissions[string(scope)] = string(level)
+		}
+	}
+
+	compileManifestLog.Printf("Built manifest entry: triggers=%d, features=%d, secrets=%d, permissions=%d",
+		len(entry.Triggers), len(entry.Features), len(entry.Secrets), len(entry.Permissions))
+	return entry
+}
+
+// extractTriggerNames returns the sorted event names declared in a rendered "on" section.
+// Supports the map form (on: {push: ...}), the list form (on: [push, issues]) and the
+// single-event string form (on: push).
+func extractTriggerNames(on string) []string {
+	if on == "" {
+		return []string{}
+	}
+
+	triggers, err := ParseTriggerSet(on)
+	if err != nil {
+		compileManifestLog.Printf("Failed to parse on section for trigger extraction: %v", err)
+		return []string{}
+	}
+
+	return triggers.Names()
+}
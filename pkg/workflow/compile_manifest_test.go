@@ -0,0 +1,94 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtractTriggerNames(t *testing.T) {
+	tests := []struct {
+		name     string
+		on       string
+		expected []string
+	}{
+		{
+			name:     "empty on section",
+			on:       "",
+			expected: []string{},
+		},
+		{
+			name: "map form is sorted",
+			on: `on:
+  workflow_dispatch:
+  issues:
+    types: [opened]
+  push:
+    branches: [main]`,
+			expected: []string{"issues", "push", "workflow_dispatch"},
+		},
+		{
+			name:     "list form",
+			on:       "on: [push, pull_request]",
+			expected: []string{"pull_request", "push"},
+		},
+		{
+			name:     "string form",
+			on:       "on: push",
+			expected: []string{"push"},
+		},
+		{
+			name:     "invalid YAML",
+			on:       "on: [push",
+			expected: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, extractTriggerNames(tt.on), "trigger names should match")
+		})
+	}
+}
+
+func TestBuildWorkflowManifestEntry(t *testing.T) {
+	data := &WorkflowData{
+		On: `on:
+  pull_request:
+    types: [opened]`,
+		Concurrency: `concurrency:
+  group: "gh-aw-${{ github.workflow }}-${{ github.event.pull_request.number || github.ref }}"
+  cancel-in-progress: true`,
+		Features: map[string]any{
+			"mcp-gateway": true,
+			"action-tag":  "v1.0.0",
+		},
+		Permissions: `permissions:
+  contents: read
+  issues: write`,
+	}
+	lockContent := `env:
+  TOKEN: ${{ secrets.GH_AW_GITHUB_TOKEN || secrets.GITHUB_TOKEN }}
+  KEY: ${{ secrets.ANTHROPIC_API_KEY }}`
+
+	entry := BuildWorkflowManifestEntry(data, ".github/workflows/test.md", ".github/workflows/test.lock.yml", lockContent)
+
+	assert.Equal(t, ".github/workflows/test.md", entry.Source, "source path should be recorded")
+	assert.Equal(t, ".github/workflows/test.lock.yml", entry.LockFile, "lock path should be recorded")
+	assert.Equal(t, []string{"pull_request"}, entry.Triggers, "triggers should be extracted from on section")
+	assert.Equal(t, "gh-aw-${{ github.workflow }}-${{ github.event.pull_request.number || github.ref }}", entry.ConcurrencyGroup, "concurrency group should be resolved")
+	assert.Equal(t, []string{"action-tag", "mcp-gateway"}, entry.Features, "feature keys should be sorted")
+	assert.Equal(t, []string{"ANTHROPIC_API_KEY", "GH_AW_GITHUB_TOKEN", "GITHUB_TOKEN"}, entry.Secrets, "secrets should be collected from lock content")
+	assert.Equal(t, map[string]string{"contents": "read", "issues": "write"}, entry.Permissions, "permissions should be computed")
+}
+
+func TestBuildWorkflowManifestEntryNilData(t *testing.T) {
+	entry := BuildWorkflowManifestEntry(nil, "a.md", "a.lock.yml", "")
+
+	assert.Equal(t, "a.md", entry.Source, "source path should be recorded")
+	assert.Empty(t, entry.Triggers, "triggers should be empty")
+	assert.NotNil(t, entry.Secrets, "secrets should be an empty slice, not nil")
+	assert.NotNil(t, entry.Permissions, "permissions should be an empty map, not nil")
+}
@@ -12,7 +12,6 @@ import (
 
 	"github.com/github/gh-aw/pkg/console"
 	"github.com/github/gh-aw/pkg/logger"
-	"github.com/github/gh-aw/pkg/stringutil"
 )
 
 var log = logger.New("workflow:compiler")
@@ -178,6 +177,48 @@ func (c *Compiler) validateWorkflowData(workflowData *WorkflowData, markdownPath
 		return formatCompilerError(markdownPath, "error", err.Error(), err)
 	}
 
+	// Validate that no trigger is declared both inline and as a key
+	log.Printf("Validating trigger declarations")
+	if err := validateTriggerForms(workflowData.FrontmatterYAML); err != nil {
+		return formatCompilerError(markdownPath, "error", err.Error(), err)
+	}
+
+	// Validate that triggers do not reference secrets
+	log.Printf("Validating trigger secret references")
+	if err := validateNoSecretsInTriggers(workflowData.On); err != nil {
+		return formatCompilerError(markdownPath, "error", err.Error(), err)
+	}
+
+	// Validate the activation token; it is used in steps, where the env context is available
+	if workflowData.ActivationGitHubToken != "" {
+		if err := validateSecretsOrEnvExpression(workflowData.ActivationGitHubToken); err != nil {
+			return formatCompilerError(markdownPath, "error", "on.github-token validation failed: "+err.Error(), err)
+		}
+	}
+
+	// Validate the resolved engine ID before it is used in concurrency group keys
+	if workflowData.EngineConfig != nil && workflowData.EngineConfig.ID != "" {
+		log.Printf("Validating engine ID: %s", workflowData.EngineConfig.ID)
+		if err := validateEngineID(workflowData.EngineConfig.ID); err != nil {
+			return formatCompilerError(markdownPath, "error", err.Error(), err)
+		}
+	}
+
+	// Validate concurrency.key expression before the generated group that embeds it
+	if workflowData.ConcurrencyKey != "" {
+		if err := validateConcurrencyGroupExpression(concurrencyKeyExpression(workflowData.ConcurrencyKey)); err != nil {
+			return formatCompilerError(markdownPath, "error", "concurrency.key validation failed: "+err.Error(), err)
+		}
+	}
+
+	// Validate that each trigger resolves to its own identifier in the generated group key
+	if generatedGroup, _ := BuildConcurrencyGroup(workflowData, len(workflowData.Command) > 0); !workflowData.ConcurrencyDisabled &&
+		extractConcurrencyGroupFromYAML(workflowData.Concurrency) == generatedGroup {
+		if err := validateConcurrencyKeyFallbacks(workflowData, len(workflowData.Command) > 0); err != nil {
+			return formatCompilerError(markdownPath, "error", "workflow-level concurrency validation failed: "+err.Error(), err)
+		}
+	}
+
 	// Validate workflow-level concurrency group expression
 	log.Printf("Validating workflow-level concurrency configuration")
 	if workflowData.Concurrency != "" {
@@ -189,6 +230,13 @@ func (c *Compiler) validateWorkflowData(workflowData *WorkflowData, markdownPath
 			if err := validateConcurrencyGroupExpression(groupExpr); err != nil {
 				return formatCompilerError(markdownPath, "error", "workflow-level concurrency validation failed: "+err.Error(), err)
 			}
+			// Groups over GitHub's length limit are only rejected at runtime
+			if err := validateConcurrencyGroupLength(groupExpr, workflowData.Name); err != nil {
+				if c.strictMode {
+					return formatCompilerError(markdownPath, "error", "workflow-level concurrency validation failed: "+err.Error(), err)
+				}
+				c.emitCompilerWarning(markdownPath, err.Error())
+			}
 		}
 	}
 
@@ -199,6 +247,26 @@ func (c *Compiler) validateWorkflowData(workflowData *WorkflowData, markdownPath
 		}
 	}
 
+	// Validate concurrency.group-by against the workflow triggers
+	if err := validateConcurrencyGroupBy(workflowData.ConcurrencyGroupBy, workflowData.On); err != nil {
+		return formatCompilerError(markdownPath, "error", "concurrency.group-by validation failed: "+err.Error(), err)
+	}
+
+	// Explain per-leg cancellation for matrix jobs that cancel in-progress runs
+	for _, warning := range matrixCancelInProgressWarnings(workflowData, len(workflowData.Command) > 0) {
+		c.emitCompilerWarning(markdownPath, warning)
+	}
+
+	// Flag sharded schedules that the default agent job concurrency group would serialize
+	if warning := serializedScheduleWarning(workflowData); warning != "" {
+		c.emitCompilerWarning(markdownPath, warning)
+	}
+
+	// Flag explicit opt-in to cancelling privileged pull_request_target runs
+	if warning := pullRequestTargetCancelInProgressWarning(workflowData); warning != "" {
+		c.emitCompilerWarning(markdownPath, warning)
+	}
+
 	// Validate engine-level concurrency group expression
 	log.Printf("Validating engine-level concurrency configuration")
 	if workflowData.EngineConfig != nil && workflowData.EngineConfig.Concurrency != "" {
@@ -220,8 +288,7 @@ func (c *Compiler) validateWorkflowData(workflowData *WorkflowData, markdownPath
 
 	// Emit warning for sandbox.agent: false (disables agent sandbox firewall)
 	if isAgentSandboxDisabled(workflowData) {
-		fmt.Fprintln(os.Stderr, console.FormatWarningMessage("⚠️  WARNING: Agent sandbox disabled (sandbox.agent: false). This removes firewall protection. The AI agent will have direct network access without firewall filtering. The MCP gateway remains enabled. Only use this for testing or in controlled environments where you trust the AI agent completely."))
-		c.IncrementWarningCount()
+		c.emitWarning("⚠️  WARNING: Agent sandbox disabled (sandbox.agent: false). This removes firewall protection. The AI agent will have direct network access without firewall filtering. The MCP gateway remains enabled. Only use this for testing or in controlled environments where you trust the AI agent completely.")
 	}
 
 	// Validate: threat detection requires sandbox.agent to be enabled (detection runs inside AWF)
@@ -236,44 +303,38 @@ func (c *Compiler) validateWorkflowData(workflowData *WorkflowData, markdownPath
 		workflowData.SafeOutputs.AssignToAgent != nil &&
 		workflowData.SafeOutputs.GitHubApp != nil &&
 		workflowData.SafeOutputs.AssignToAgent.GitHubToken == "" {
-		fmt.Fprintln(os.Stderr, console.FormatWarningMessage(
-			"assign-to-agent does not support GitHub App tokens. "+
-				"The Copilot assignment API requires a fine-grained PAT. "+
-				"The token fallback chain (GH_AW_AGENT_TOKEN || GH_AW_GITHUB_TOKEN || GITHUB_TOKEN) will be used automatically. "+
-				"Add github-token: to your assign-to-agent config to specify a different token."))
-		c.IncrementWarningCount()
+		c.emitWarning(
+			"assign-to-agent does not support GitHub App tokens. " +
+				"The Copilot assignment API requires a fine-grained PAT. " +
+				"The token fallback chain (GH_AW_AGENT_TOKEN || GH_AW_GITHUB_TOKEN || GITHUB_TOKEN) will be used automatically. " +
+				"Add github-token: to your assign-to-agent config to specify a different token.")
 	}
 
 	// Emit experimental warning for mcp-scripts feature
 	if IsMCPScriptsEnabled(workflowData.MCPScripts, workflowData) {
-		fmt.Fprintln(os.Stderr, console.FormatWarningMessage("Using experimental feature: mcp-scripts"))
-		c.IncrementWarningCount()
+		c.emitWarning("Using experimental feature: mcp-scripts")
 	}
 
 	// Emit experimental warning for plugins feature
 	if workflowData.PluginInfo != nil && len(workflowData.PluginInfo.Plugins) > 0 {
-		fmt.Fprintln(os.Stderr, console.FormatWarningMessage("Using experimental feature: plugins"))
-		c.IncrementWarningCount()
+		c.emitWarning("Using experimental feature: plugins")
 	}
 
 	// Emit experimental warning for dependencies (APM) feature
 	if workflowData.APMDependencies != nil && len(workflowData.APMDependencies.Packages) > 0 {
-		fmt.Fprintln(os.Stderr, console.FormatWarningMessage("Using experimental feature: dependencies (APM)"))
-		c.IncrementWarningCount()
+		c.emitWarning("Using experimental feature: dependencies (APM)")
 	}
 
 	// Emit experimental warning for rate-limit feature
 	if workflowData.RateLimit != nil {
-		fmt.Fprintln(os.Stderr, console.FormatWarningMessage("Using experimental feature: rate-limit"))
-		c.IncrementWarningCount()
+		c.emitWarning("Using experimental feature: rate-limit")
 	}
 
 	// Emit experimental warning for tools.github guard policy (repos/min-integrity)
 	if workflowData.ParsedTools != nil && workflowData.ParsedTools.GitHub != nil {
 		github := workflowData.ParsedTools.GitHub
 		if github.Repos != nil || github.MinIntegrity != "" {
-			fmt.Fprintln(os.Stderr, console.FormatWarningMessage("Using experimental feature: tools.github guard policy (repos/min-integrity)"))
-			c.IncrementWarningCount()
+			c.emitWarning("Using experimental feature: tools.github guard policy (repos/min-integrity)")
 		}
 	}
 
@@ -315,8 +376,7 @@ func (c *Compiler) validateWorkflowData(workflowData *WorkflowData, markdownPath
 						return formatCompilerError(markdownPath, "error", message, nil)
 					} else {
 						// In non-strict mode, missing permissions are warnings
-						fmt.Fprintln(os.Stderr, formatCompilerMessage(markdownPath, "warning", message))
-						c.IncrementWarningCount()
+						c.emitCompilerWarning(markdownPath, message)
 					}
 				}
 			}
@@ -333,12 +393,16 @@ func (c *Compiler) validateWorkflowData(workflowData *WorkflowData, markdownPath
 				warningMsg := `This workflow grants id-token: write permission
 OIDC tokens can authenticate to cloud providers (AWS, Azure, GCP).
 Ensure proper audience validation and trust policies are configured.`
-				fmt.Fprintln(os.Stderr, formatCompilerMessage(markdownPath, "warning", warningMsg))
-				c.IncrementWarningCount()
+				c.emitCompilerWarning(markdownPath, warningMsg)
 			}
 		}
 	}
 
+	// Enforce the repository permissions policy from .github/gh-aw.yml
+	if err := validatePermissionsPolicy(workflowData); err != nil {
+		return formatCompilerError(markdownPath, "error", err.Error(), err)
+	}
+
 	// Validate GitHub tools against enabled toolsets
 	log.Printf("Validating GitHub tools against enabled toolsets")
 	if workflowData.ParsedTools != nil && workflowData.ParsedTools.GitHub != nil {
@@ -456,8 +520,7 @@ func (c *Compiler) generateAndValidateYAML(workflowData *WorkflowData, markdownP
 		if err := c.validateContainerImages(workflowData); err != nil {
 			// Treat container image validation failures as warnings, not errors
 			// This is because validation may fail due to auth issues locally (e.g., private registries)
-			fmt.Fprintln(os.Stderr, formatCompilerMessage(markdownPath, "warning", fmt.Sprintf("container image validation failed: %v", err)))
-			c.IncrementWarningCount()
+			c.emitCompilerWarning(markdownPath, fmt.Sprintf("container image validation failed: %v", err))
 		}
 
 		// Validate runtime packages (npx, uv)
@@ -478,10 +541,12 @@ func (c *Compiler) generateAndValidateYAML(workflowData *WorkflowData, markdownP
 			return "", formatCompilerError(markdownPath, "error", fmt.Sprintf("repository feature validation failed: %v", err), err)
 		}
 	} else if c.verbose {
-		fmt.Fprintln(os.Stderr, console.FormatWarningMessage("Schema validation available but skipped (use SetSkipValidation(false) to enable)"))
-		c.IncrementWarningCount()
+		c.emitWarning("Schema validation available but skipped (use SetSkipValidation(false) to enable)")
 	}
 
+	// Check that referenced secrets exist (opt-in, requires GitHub API access)
+	c.validateSecretsExist(yamlContent, markdownPath)
+
 	return yamlContent, nil
 }
 
@@ -578,7 +643,7 @@ func (c *Compiler) CompileWorkflowData(workflowData *WorkflowData, markdownPath
 	}
 
 	// Generate lock file name
-	lockFile := stringutil.MarkdownToLockFile(markdownPath)
+	lockFile := c.LockFilePath(markdownPath)
 
 	// Sanitize the lock file path to prevent path traversal attacks
 	lockFile = filepath.Clean(lockFile)
@@ -602,6 +667,11 @@ func (c *Compiler) CompileWorkflowData(workflowData *WorkflowData, markdownPath
 		return err
 	}
 
+	// Record the source map for tools that report lock file locations
+	if c.buildSourceMaps {
+		c.recordSourceMap(markdownPath, lockFile, yamlContent)
+	}
+
 	// Write output
 	return c.writeWorkflowOutput(lockFile, yamlContent, markdownPath)
 }
@@ -7,8 +7,6 @@ import (
 	"sort"
 	"strings"
 
-	"github.com/github/gh-aw/pkg/stringutil"
-
 	"github.com/github/gh-aw/pkg/constants"
 	"github.com/github/gh-aw/pkg/logger"
 	"github.com/github/gh-aw/pkg/parser"
@@ -196,7 +194,7 @@ func (c *Compiler) buildJobs(data *WorkflowData, markdownPath string) error {
 	}
 
 	// Extract lock filename for timestamp check
-	lockFilename := filepath.Base(stringutil.MarkdownToLockFile(markdownPath))
+	lockFilename := filepath.Base(c.LockFilePath(markdownPath))
 
 	// Build pre-activation and activation jobs
 	_, activationJobCreated, err := c.buildPreActivationAndActivationJobs(data, frontmatter, lockFilename)
@@ -572,6 +570,10 @@ func (c *Compiler) buildCustomJobs(data *WorkflowData, activationJobCreated bool
 					}
 					job.Concurrency = formattedConcurrency.String()
 				}
+			} else if hasGeneratedMatrixConcurrency(data, configMap) {
+				// Give each matrix leg its own group so legs do not cancel or queue behind each other
+				compilerJobsLog.Printf("Generating per-leg concurrency group for matrix job '%s'", jobName)
+				job.Concurrency = c.indentYAMLLines(generateMatrixJobConcurrencyConfig(data, len(data.Command) > 0, jobName), "    ")
 			}
 
 			// Extract env for custom jobs
@@ -667,18 +669,11 @@ func (c *Compiler) buildCustomJobs(data *WorkflowData, activationJobCreated bool
 								job.SecretsInherit = true
 							}
 						case map[string]any:
-							job.Secrets = make(map[string]string)
-							for key, val := range sv {
-								if valStr, ok := val.(string); ok {
-									// Validate that the secret value is a proper GitHub Actions expression
-									// Note: We don't pass the key to validateSecretsExpression to prevent
-									// CodeQL from detecting sensitive data flow to error messages/logs
-									if err := validateSecretsExpression(valStr); err != nil {
-										return err
-									}
-									job.Secrets[key] = valStr
-								}
+							jobSecrets, err := parseJobSecrets(sv)
+							if err != nil {
+								return err
 							}
+							job.Secrets = jobSecrets
 						}
 					}
 				}
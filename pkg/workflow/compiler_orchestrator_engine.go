@@ -6,7 +6,6 @@ import (
 	"os"
 	"strings"
 
-	"github.com/github/gh-aw/pkg/console"
 	"github.com/github/gh-aw/pkg/logger"
 	"github.com/github/gh-aw/pkg/parser"
 )
@@ -86,6 +85,24 @@ func (c *Compiler) setupEngineAndImports(result *parser.FrontmatterResult, clean
 		return nil, err
 	}
 
+	// Validate the names of referenced secrets (duplicates are errors in strict mode, warnings otherwise).
+	// Without an explicit --secret-naming policy, violations only warn so that existing workflows keep compiling.
+	secretWarnings, err := validateFrontmatterSecretReferences(result.Frontmatter, c.GetSecretNamingPolicy(), c.strictMode)
+	if err != nil {
+		if !c.HasSecretNamingPolicy() {
+			orchestratorEngineLog.Print("Secret reference validation failed without an explicit naming policy, reporting as warning")
+			c.emitCompilerWarning(cleanPath, err.Error()+"\n\nPass --secret-naming strict to make this an error, or --secret-naming permissive to accept any name GitHub accepts.")
+		} else {
+			orchestratorEngineLog.Printf("Secret reference validation failed: %v", err)
+			// Restore strict mode before returning error
+			c.strictMode = initialStrictMode
+			return nil, err
+		}
+	}
+	for _, warning := range secretWarnings {
+		c.emitCompilerWarning(cleanPath, warning)
+	}
+
 	// Restore the initial strict mode state after validation
 	// This ensures strict mode doesn't leak to other workflows being compiled
 	c.strictMode = initialStrictMode
@@ -94,8 +111,7 @@ func (c *Compiler) setupEngineAndImports(result *parser.FrontmatterResult, clean
 	if c.engineOverride != "" {
 		originalEngineSetting := engineSetting
 		if originalEngineSetting != "" && originalEngineSetting != c.engineOverride {
-			fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Command line --engine %s overrides markdown file engine: %s", c.engineOverride, originalEngineSetting)))
-			c.IncrementWarningCount()
+			c.emitWarning(fmt.Sprintf("Command line --engine %s overrides markdown file engine: %s", c.engineOverride, originalEngineSetting))
 		}
 		engineSetting = c.engineOverride
 		// Update engineConfig.ID so that downstream code (e.g. generateCreateAwInfo) uses
@@ -121,6 +137,12 @@ func (c *Compiler) setupEngineAndImports(result *parser.FrontmatterResult, clean
 		}
 	}
 
+	// Reject direct self-imports early with a targeted message; transitive cycles are
+	// reported by the import cycle detector below
+	if err := validateNoSelfImport(result.Frontmatter, markdownDir, cleanPath); err != nil {
+		return nil, err
+	}
+
 	// Process imports from frontmatter first (before @include directives)
 	orchestratorEngineLog.Printf("Processing imports from frontmatter")
 	importCache := c.getSharedImportCache()
@@ -136,7 +158,12 @@ func (c *Compiler) setupEngineAndImports(result *parser.FrontmatterResult, clean
 		return nil, err // Error is already formatted with source location
 	}
 
-	// Security scan imported markdown files' content (skip non-markdown imports like .yml)
+	// Secrets declared by the top-level 'secrets' field; imported fragments may only
+	// reference these when the field is present
+	declaredSecrets := collectDeclaredSecrets(result.Frontmatter)
+
+	// Security scan imported markdown files' content and check their secret references
+	// (skip non-markdown imports like .yml)
 	for _, importedFile := range importsResult.ImportedFiles {
 		// Strip section references (e.g., "shared/foo.md#Section")
 		importFilePath := importedFile
@@ -164,6 +191,15 @@ func (c *Compiler) setupEngineAndImports(result *parser.FrontmatterResult, clean
 			orchestratorEngineLog.Printf("Security scan failed for imported file: %s (%d findings)", importedFile, len(findings))
 			return nil, fmt.Errorf("imported workflow '%s' failed security scan: %s", importedFile, FormatSecurityFindings(findings, importedFile))
 		}
+		// Secrets blocks in imported fragments are ignored, so point users at the top-level workflow
+		if err := validateNoImportedSecretsBlock(importedFile, string(importContent)); err != nil {
+			c.emitCompilerWarning(fullPath, err.Error())
+		}
+		if declaredSecrets != nil {
+			if err := validateImportedSecretsDeclared(importedFile, string(importContent), declaredSecrets); err != nil {
+				return nil, err
+			}
+		}
 	}
 
 	// Merge network permissions from imports with top-level network permissions
@@ -270,8 +306,7 @@ func (c *Compiler) setupEngineAndImports(result *parser.FrontmatterResult, clean
 
 	log.Printf("AI engine: %s (%s)", agenticEngine.GetDisplayName(), engineSetting)
 	if agenticEngine.IsExperimental() && c.verbose {
-		fmt.Fprintln(os.Stderr, console.FormatWarningMessage("Using experimental engine: "+agenticEngine.GetDisplayName()))
-		c.IncrementWarningCount()
+		c.emitWarning("Using experimental engine: " + agenticEngine.GetDisplayName())
 	}
 
 	// Enable firewall by default for copilot engine when network restrictions are present
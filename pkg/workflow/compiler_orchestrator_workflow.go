@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"maps"
+	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/github/gh-aw/pkg/logger"
@@ -18,6 +20,9 @@ var orchestratorWorkflowLog = logger.New("workflow:compiler_orchestrator_workflo
 func (c *Compiler) ParseWorkflowFile(markdownPath string) (*WorkflowData, error) {
 	orchestratorWorkflowLog.Printf("Starting workflow file parsing: %s", markdownPath)
 
+	// Attribute warnings emitted while parsing (e.g. schedule warnings) to this file
+	c.markdownPath = markdownPath
+
 	// Parse frontmatter section
 	parseResult, err := c.parseFrontmatterSection(markdownPath)
 	if err != nil {
@@ -92,15 +97,18 @@ func (c *Compiler) ParseWorkflowFile(markdownPath string) (*WorkflowData, error)
 	// Extract YAML configuration sections from frontmatter
 	c.extractYAMLSections(result.Frontmatter, workflowData)
 
-	// Merge features from imports
-	if len(engineSetup.importsResult.MergedFeatures) > 0 {
-		mergedFeatures, err := c.MergeFeatures(workflowData.Features, engineSetup.importsResult.MergedFeatures)
-		if err != nil {
-			return nil, fmt.Errorf("failed to merge features from imports: %w", err)
-		}
-		workflowData.Features = mergedFeatures
+	// Merge features from imports and repository config defaults
+	if err := c.mergeWorkflowFeatures(workflowData, engineSetup.importsResult.MergedFeatures, cleanPath); err != nil {
+		return nil, err
 	}
 
+	// Attach the repository permissions policy enforced during validation
+	permissionsPolicy, err := c.repoPermissionsPolicyFor(cleanPath)
+	if err != nil {
+		return nil, err
+	}
+	workflowData.PermissionsPolicy = permissionsPolicy
+
 	// Process and merge custom steps with imported steps
 	c.processAndMergeSteps(result.Frontmatter, workflowData, engineSetup.importsResult)
 
@@ -226,10 +234,21 @@ func (c *Compiler) extractYAMLSections(frontmatter map[string]any, workflowData
 	workflowData.Permissions = c.extractPermissions(frontmatter)
 	workflowData.Network = c.extractTopLevelYAMLSection(frontmatter, "network")
 	workflowData.ConcurrencyJobDiscriminator = extractConcurrencyJobDiscriminator(frontmatter)
+	workflowData.ConcurrencyGroupBy = extractConcurrencyGroupBy(frontmatter)
+	workflowData.ConcurrencyKey = extractConcurrencyKey(frontmatter)
+	workflowData.ConcurrencyCancelInProgress = extractConcurrencyCancelInProgress(frontmatter)
+	workflowData.ConcurrencyDisabled = extractConcurrencyDisabled(frontmatter)
+	workflowData.ConcurrencyHashLongGroup = extractConcurrencyHashLongGroup(frontmatter)
+	workflowData.ConcurrencyHashPrefix = extractConcurrencyHashPrefix(frontmatter)
+	workflowData.ConcurrencyMatrix = extractConcurrencyMatrix(frontmatter)
+	workflowData.ConcurrencyForkIsolation = extractConcurrencyForkIsolation(frontmatter)
+	workflowData.ConcurrencyCancelPolicy = extractConcurrencyCancelPolicy(frontmatter)
 	workflowData.Concurrency = c.extractConcurrencySection(frontmatter)
 	workflowData.RunName = c.extractTopLevelYAMLSection(frontmatter, "run-name")
 	workflowData.Env = c.extractTopLevelYAMLSection(frontmatter, "env")
 	workflowData.Features = c.extractFeatures(frontmatter)
+	workflowData.ActionlintIgnore = c.extractActionlintIgnore(frontmatter)
+	workflowData.SelfHostedRunnerLabels = extractSelfHostedRunnerLabels(frontmatter)
 	workflowData.If = c.extractIfCondition(frontmatter)
 
 	// Extract timeout-minutes (canonical form)
@@ -241,10 +260,15 @@ func (c *Compiler) extractYAMLSections(frontmatter map[string]any, workflowData
 	workflowData.Cache = c.extractTopLevelYAMLSection(frontmatter, "cache")
 }
 
-// extractConcurrencyJobDiscriminator reads the job-discriminator value from the
-// frontmatter concurrency block without modifying the original map.
-// Returns the discriminator expression string or empty string if not present.
-func extractConcurrencyJobDiscriminator(frontmatter map[string]any) string {
+// concurrencyExtensionFields lists the gh-aw-specific fields accepted in the frontmatter
+// concurrency block. They configure how the compiler generates concurrency groups and are
+// stripped from the compiled lock file, which must be valid GitHub Actions YAML.
+var concurrencyExtensionFields = []string{"job-discriminator", "group-by", "key", "hash-long-group", "hash-prefix", "matrix", "cancel-policy", "fork-isolation"}
+
+// extractConcurrencyStringField reads a string field from the frontmatter concurrency
+// block without modifying the original map.
+// Returns the field value or empty string if not present.
+func extractConcurrencyStringField(frontmatter map[string]any, field string) string {
 	concurrencyRaw, ok := frontmatter["concurrency"]
 	if !ok {
 		return ""
@@ -253,49 +277,182 @@ func extractConcurrencyJobDiscriminator(frontmatter map[string]any) string {
 	if !ok {
 		return ""
 	}
-	discriminator, ok := concurrencyMap["job-discriminator"]
+	value, ok := concurrencyMap[field]
 	if !ok {
 		return ""
 	}
-	discriminatorStr, ok := discriminator.(string)
+	valueStr, ok := value.(string)
 	if !ok {
 		return ""
 	}
-	return discriminatorStr
+	return valueStr
+}
+
+// extractConcurrencyJobDiscriminator reads the job-discriminator value from the
+// frontmatter concurrency block without modifying the original map.
+// Returns the discriminator expression string or empty string if not present.
+func extractConcurrencyJobDiscriminator(frontmatter map[string]any) string {
+	return extractConcurrencyStringField(frontmatter, "job-discriminator")
+}
+
+// extractConcurrencyGroupBy reads the group-by value from the frontmatter concurrency
+// block without modifying the original map.
+// Returns the group-by mode (e.g. "label") or empty string if not present.
+func extractConcurrencyGroupBy(frontmatter map[string]any) string {
+	return extractConcurrencyStringField(frontmatter, "group-by")
+}
+
+// extractConcurrencyKey reads the custom key expression from the frontmatter concurrency
+// block without modifying the original map.
+// Returns the key expression (e.g. "github.event.client_payload.id") or empty string if not present.
+func extractConcurrencyKey(frontmatter map[string]any) string {
+	return extractConcurrencyStringField(frontmatter, "key")
+}
+
+// extractConcurrencyCancelInProgress reads the cancel-in-progress override for the
+// generated concurrency group from a frontmatter concurrency block that has no group.
+// Returns "true", "false", an expression string, or empty string if not present.
+func extractConcurrencyCancelInProgress(frontmatter map[string]any) string {
+	concurrencyMap, ok := frontmatter["concurrency"].(map[string]any)
+	if !ok {
+		return ""
+	}
+	if _, hasGroup := concurrencyMap["group"]; hasGroup {
+		// A custom group is emitted verbatim along with its own cancel-in-progress
+		return ""
+	}
+	switch v := concurrencyMap["cancel-in-progress"].(type) {
+	case bool:
+		return strconv.FormatBool(v)
+	case string:
+		return v
+	default:
+		return ""
+	}
+}
+
+// extractConcurrencyHashLongGroup reads the hash-long-group flag from the frontmatter
+// concurrency block without modifying the original map.
+func extractConcurrencyHashLongGroup(frontmatter map[string]any) bool {
+	concurrencyMap, ok := frontmatter["concurrency"].(map[string]any)
+	if !ok {
+		return false
+	}
+	hashLongGroup, _ := concurrencyMap["hash-long-group"].(bool)
+	return hashLongGroup
+}
+
+// extractConcurrencyHashPrefix reads the hash-prefix flag from the frontmatter
+// concurrency block without modifying the original map.
+func extractConcurrencyHashPrefix(frontmatter map[string]any) bool {
+	concurrencyMap, ok := frontmatter["concurrency"].(map[string]any)
+	if !ok {
+		return false
+	}
+	hashPrefix, _ := concurrencyMap["hash-prefix"].(bool)
+	return hashPrefix
+}
+
+// extractConcurrencyMatrix reads the matrix flag from the frontmatter concurrency block
+// without modifying the original map.
+func extractConcurrencyMatrix(frontmatter map[string]any) bool {
+	concurrencyMap, ok := frontmatter["concurrency"].(map[string]any)
+	if !ok {
+		return false
+	}
+	matrix, _ := concurrencyMap["matrix"].(bool)
+	return matrix
+}
+
+// extractConcurrencyForkIsolation reads the fork-isolation flag from the frontmatter
+// concurrency block without modifying the original map.
+func extractConcurrencyForkIsolation(frontmatter map[string]any) bool {
+	concurrencyMap, ok := frontmatter["concurrency"].(map[string]any)
+	if !ok {
+		return false
+	}
+	forkIsolation, _ := concurrencyMap["fork-isolation"].(bool)
+	return forkIsolation
+}
+
+// extractConcurrencyCancelPolicy reads the per-trigger cancel-policy map from the frontmatter
+// concurrency block without modifying the original map. Entries without a boolean value are
+// ignored. Returns nil if not present.
+func extractConcurrencyCancelPolicy(frontmatter map[string]any) map[string]bool {
+	concurrencyMap, ok := frontmatter["concurrency"].(map[string]any)
+	if !ok {
+		return nil
+	}
+	policyMap, ok := concurrencyMap["cancel-policy"].(map[string]any)
+	if !ok {
+		return nil
+	}
+	policy := make(map[string]bool, len(policyMap))
+	for trigger, value := range policyMap {
+		if cancel, ok := value.(bool); ok {
+			policy[trigger] = cancel
+		}
+	}
+	return policy
+}
+
+// concurrencyDisabledValue is the frontmatter concurrency value that disables
+// compiler-generated concurrency groups (concurrency: false is equivalent)
+const concurrencyDisabledValue = "none"
+
+// extractConcurrencyDisabled reports whether the frontmatter disables concurrency
+// generation with concurrency: none or concurrency: false.
+func extractConcurrencyDisabled(frontmatter map[string]any) bool {
+	switch v := frontmatter["concurrency"].(type) {
+	case string:
+		return v == concurrencyDisabledValue
+	case bool:
+		return !v
+	default:
+		return false
+	}
 }
 
 // extractConcurrencySection extracts the workflow-level concurrency YAML section,
-// stripping the gh-aw-specific job-discriminator field so it does not appear in
-// the compiled lock file (which must be valid GitHub Actions YAML).
+// stripping the gh-aw-specific extension fields (see concurrencyExtensionFields) so
+// they do not appear in the compiled lock file (which must be valid GitHub Actions YAML).
 func (c *Compiler) extractConcurrencySection(frontmatter map[string]any) string {
 	concurrencyRaw, ok := frontmatter["concurrency"]
-	if !ok {
+	if !ok || extractConcurrencyDisabled(frontmatter) {
 		return ""
 	}
 	concurrencyMap, ok := concurrencyRaw.(map[string]any)
 	if !ok || len(concurrencyMap) == 0 {
-		// String or empty format: serialize as-is (no job-discriminator possible)
+		// String or empty format: serialize as-is (no extension fields possible)
 		return c.extractTopLevelYAMLSection(frontmatter, "concurrency")
 	}
 
-	_, hasDiscriminator := concurrencyMap["job-discriminator"]
-	if !hasDiscriminator {
+	hasExtensionField := false
+	for _, field := range concurrencyExtensionFields {
+		if _, exists := concurrencyMap[field]; exists {
+			hasExtensionField = true
+			break
+		}
+	}
+	// Without a group, cancel-in-progress overrides the generated group's setting
+	// (see extractConcurrencyCancelInProgress) and the block is not emitted as-is
+	_, hasGroup := concurrencyMap["group"]
+	if !hasExtensionField && hasGroup {
 		return c.extractTopLevelYAMLSection(frontmatter, "concurrency")
 	}
 
-	// Build a copy of the concurrency map without job-discriminator for serialization.
-	// Use len(concurrencyMap) for capacity: at most one entry (job-discriminator) will be
-	// omitted, so this is a slight over-allocation that avoids a subtle negative-capacity
-	// edge case if job-discriminator were the only key.
+	// Build a copy of the concurrency map without extension fields for serialization.
+	// Use len(concurrencyMap) for capacity: this is a slight over-allocation that avoids
+	// a subtle negative-capacity edge case if extension fields were the only keys.
 	cleanMap := make(map[string]any, len(concurrencyMap))
 	for k, v := range concurrencyMap {
-		if k != "job-discriminator" {
+		if !slices.Contains(concurrencyExtensionFields, k) {
 			cleanMap[k] = v
 		}
 	}
-	// When job-discriminator is the only field, there is no user-specified workflow-level
-	// group to emit; return empty so the compiler can generate the default concurrency.
-	if len(cleanMap) == 0 {
+	// When no group is present, there is no user-specified workflow-level group to
+	// emit; return empty so the compiler can generate the default concurrency.
+	if !hasGroup {
 		return ""
 	}
 	// Use a minimal temporary frontmatter containing only the concurrency key to avoid
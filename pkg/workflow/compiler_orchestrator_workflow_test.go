@@ -1668,6 +1668,28 @@ func TestExtractConcurrencySection(t *testing.T) {
 		assert.NotContains(t, result, "job-discriminator", "no job-discriminator should appear")
 	})
 
+	t.Run("group-by is stripped from serialized YAML", func(t *testing.T) {
+		frontmatter := map[string]any{
+			"concurrency": map[string]any{
+				"group":    "gh-aw-${{ github.workflow }}",
+				"group-by": "label",
+			},
+		}
+		result := compiler.extractConcurrencySection(frontmatter)
+		assert.NotContains(t, result, "group-by", "group-by should be stripped from serialized concurrency YAML")
+		assert.Contains(t, result, "gh-aw-${{ github.workflow }}", "group value should be preserved")
+	})
+
+	t.Run("group-by only (no group) returns empty string", func(t *testing.T) {
+		frontmatter := map[string]any{
+			"concurrency": map[string]any{
+				"group-by": "label",
+			},
+		}
+		result := compiler.extractConcurrencySection(frontmatter)
+		assert.Empty(t, result, "when only group-by is present the compiler should generate the default concurrency")
+	})
+
 	t.Run("job-discriminator only (no group) returns empty string", func(t *testing.T) {
 		frontmatter := map[string]any{
 			"concurrency": map[string]any{
@@ -1677,6 +1699,64 @@ func TestExtractConcurrencySection(t *testing.T) {
 		result := compiler.extractConcurrencySection(frontmatter)
 		assert.Empty(t, result, "when only job-discriminator is present the workflow-level concurrency should be empty (compiler generates defaults)")
 	})
+
+	t.Run("cancel-in-progress only (no group) returns empty string", func(t *testing.T) {
+		frontmatter := map[string]any{
+			"concurrency": map[string]any{
+				"cancel-in-progress": true,
+			},
+		}
+		result := compiler.extractConcurrencySection(frontmatter)
+		assert.Empty(t, result, "when no group is present the compiler should generate the default concurrency")
+	})
+}
+
+func TestExtractConcurrencyCancelInProgress(t *testing.T) {
+	tests := []struct {
+		name        string
+		frontmatter map[string]any
+		want        string
+	}{
+		{
+			name:        "true without group",
+			frontmatter: map[string]any{"concurrency": map[string]any{"cancel-in-progress": true}},
+			want:        "true",
+		},
+		{
+			name:        "false without group",
+			frontmatter: map[string]any{"concurrency": map[string]any{"cancel-in-progress": false}},
+			want:        "false",
+		},
+		{
+			name:        "expression without group",
+			frontmatter: map[string]any{"concurrency": map[string]any{"cancel-in-progress": "${{ github.event_name == 'schedule' }}"}},
+			want:        "${{ github.event_name == 'schedule' }}",
+		},
+		{
+			name: "custom group keeps its own setting",
+			frontmatter: map[string]any{"concurrency": map[string]any{
+				"group":              "custom",
+				"cancel-in-progress": true,
+			}},
+			want: "",
+		},
+		{
+			name:        "not configured",
+			frontmatter: map[string]any{"concurrency": map[string]any{"group-by": "label"}},
+			want:        "",
+		},
+		{
+			name:        "concurrency as string",
+			frontmatter: map[string]any{"concurrency": "custom"},
+			want:        "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, extractConcurrencyCancelInProgress(tt.frontmatter), "extractConcurrencyCancelInProgress() mismatch")
+		})
+	}
 }
 
 // TestExtractYAMLSections_ConcurrencyJobDiscriminator verifies that extractYAMLSections
@@ -1713,3 +1793,67 @@ func TestExtractYAMLSections_ConcurrencyJobDiscriminator(t *testing.T) {
 			"ConcurrencyJobDiscriminator should be empty when not in frontmatter")
 	})
 }
+
+func TestExtractConcurrencyCancelPolicy(t *testing.T) {
+	tests := []struct {
+		name        string
+		frontmatter map[string]any
+		want        map[string]bool
+	}{
+		{
+			name: "boolean entries",
+			frontmatter: map[string]any{"concurrency": map[string]any{
+				"cancel-policy": map[string]any{"schedule": true, "pull_request": false},
+			}},
+			want: map[string]bool{"schedule": true, "pull_request": false},
+		},
+		{
+			name: "non-boolean entries are ignored",
+			frontmatter: map[string]any{"concurrency": map[string]any{
+				"cancel-policy": map[string]any{"schedule": true, "issues": "yes"},
+			}},
+			want: map[string]bool{"schedule": true},
+		},
+		{
+			name:        "no cancel-policy",
+			frontmatter: map[string]any{"concurrency": map[string]any{"cancel-in-progress": true}},
+		},
+		{
+			name:        "string concurrency",
+			frontmatter: map[string]any{"concurrency": "none"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, extractConcurrencyCancelPolicy(tt.frontmatter), "cancel-policy should be extracted")
+		})
+	}
+}
+
+func TestExtractConcurrencyDisabled(t *testing.T) {
+	tests := []struct {
+		name        string
+		concurrency any
+		want        bool
+	}{
+		{name: "none", concurrency: "none", want: true},
+		{name: "false", concurrency: false, want: true},
+		{name: "custom string group", concurrency: "my-group", want: false},
+		{name: "object", concurrency: map[string]any{"group": "none"}, want: false},
+		{name: "true", concurrency: true, want: false},
+	}
+
+	compiler := NewCompiler()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			frontmatter := map[string]any{"concurrency": tt.concurrency}
+			assert.Equal(t, tt.want, extractConcurrencyDisabled(frontmatter), "extractConcurrencyDisabled() mismatch")
+			if tt.want {
+				assert.Empty(t, compiler.extractConcurrencySection(frontmatter), "disabled concurrency should not be serialized")
+			}
+		})
+	}
+
+	assert.False(t, extractConcurrencyDisabled(map[string]any{}), "missing concurrency should not disable generation")
+}
@@ -7,7 +7,6 @@ import (
 	"time"
 
 	"github.com/github/gh-aw/pkg/parser"
-	"github.com/github/gh-aw/pkg/stringutil"
 )
 
 // CompileToYAML compiles workflow data and returns the YAML as a string
@@ -32,7 +31,7 @@ func (c *Compiler) CompileToYAML(workflowData *WorkflowData, markdownPath string
 		c.artifactManager.Reset()
 	}
 
-	lockFile := stringutil.MarkdownToLockFile(markdownPath)
+	lockFile := c.LockFilePath(markdownPath)
 
 	if err := c.validateWorkflowData(workflowData, markdownPath); err != nil {
 		return "", err
@@ -149,13 +148,10 @@ func (c *Compiler) ParseWorkflowString(content string, virtualPath string) (*Wor
 	// Extract YAML configuration sections
 	c.extractYAMLSections(parseResult.frontmatterResult.Frontmatter, workflowData)
 
-	// Merge features from imports
-	if len(engineSetup.importsResult.MergedFeatures) > 0 {
-		mergedFeatures, err := c.MergeFeatures(workflowData.Features, engineSetup.importsResult.MergedFeatures)
-		if err != nil {
-			return nil, fmt.Errorf("failed to merge features from imports: %w", err)
-		}
-		workflowData.Features = mergedFeatures
+	// Merge features from imports. The repository config file is not consulted
+	// because string-based compilation has no filesystem access.
+	if err := c.mergeWorkflowFeatures(workflowData, engineSetup.importsResult.MergedFeatures, ""); err != nil {
+		return nil, err
 	}
 
 	// Process and merge custom steps
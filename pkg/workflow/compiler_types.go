@@ -3,8 +3,10 @@ package workflow
 import (
 	"os"
 
+	"github.com/github/gh-aw/pkg/console"
 	"github.com/github/gh-aw/pkg/logger"
 	"github.com/github/gh-aw/pkg/parser"
+	"github.com/github/gh-aw/pkg/stringutil"
 )
 
 var logTypes = logger.New("workflow:compiler_types")
@@ -52,6 +54,11 @@ func WithWorkflowIdentifier(identifier string) CompilerOption {
 	return func(c *Compiler) { c.workflowIdentifier = identifier }
 }
 
+// WithLockFileSuffix sets the file suffix of generated lock files (default .lock.yml)
+func WithLockFileSuffix(suffix string) CompilerOption {
+	return func(c *Compiler) { c.lockFileSuffix = suffix }
+}
+
 // FileTracker interface for tracking files created during compilation
 type FileTracker interface {
 	TrackCreated(filePath string)
@@ -73,10 +80,13 @@ type Compiler struct {
 	quiet                   bool // If true, suppress success messages (for interactive mode)
 	engineOverride          string
 	customOutput            string              // If set, output will be written to this path instead of default location
+	lockFileSuffix          string              // File suffix of generated lock files (empty means stringutil.DefaultLockFileSuffix)
 	version                 string              // Version of the extension
 	skipValidation          bool                // If true, skip schema validation
 	noEmit                  bool                // If true, validate without generating lock files
 	strictMode              bool                // If true, enforce strict validation requirements
+	secretNamingPolicy      SecretNamingPolicy  // Naming policy for secret references (empty means SecretNamingStrict)
+	checkSecretsExist       bool                // If true, warn about referenced secrets missing from the repository (requires GitHub API access)
 	trialMode               bool                // If true, suppress safe outputs for trial mode execution
 	trialLogicalRepoSlug    string              // If set in trial mode, the logical repository to checkout
 	refreshStopTime         bool                // If true, regenerate stop-after times instead of preserving existing ones
@@ -105,6 +115,16 @@ type Compiler struct {
 	contentOverride         string              // If set, use this content instead of reading from disk (for Wasm/in-memory compilation)
 	skipHeader              bool                // If true, skip ASCII art header in generated YAML (for Wasm/editor mode)
 	inlinePrompt            bool                // If true, inline markdown content in YAML instead of using runtime-import macros (for Wasm builds)
+	buildSourceMaps         bool                // If true, record a source map for each compiled lock file
+
+	// warnings accumulates warning diagnostics for this compiler instance (see compiler_warnings.go)
+	warnings []console.CompilerError
+
+	// repoConfigs caches loaded repository config files by path (see repo_config.go)
+	repoConfigs map[string]*RepoConfig
+
+	// sourceMaps holds the source map of each compiled lock file by path (see source_map.go)
+	sourceMaps map[string]*SourceMap
 }
 
 // NewCompiler creates a new workflow compiler with functional options.
@@ -155,6 +175,20 @@ func NewCompilerWithVersion(version string) *Compiler {
 	)
 }
 
+// LockFileSuffix returns the file suffix of generated lock files (default .lock.yml)
+func (c *Compiler) LockFileSuffix() string {
+	if c.lockFileSuffix == "" {
+		return stringutil.DefaultLockFileSuffix
+	}
+	return c.lockFileSuffix
+}
+
+// LockFilePath returns the path of the lock file generated for the markdown workflow at
+// markdownPath, using the configured lock file suffix
+func (c *Compiler) LockFilePath(markdownPath string) string {
+	return stringutil.MarkdownToLockFileWithSuffix(markdownPath, c.LockFileSuffix())
+}
+
 // SetSkipValidation configures whether to skip schema validation
 func (c *Compiler) SetSkipValidation(skip bool) {
 	c.skipValidation = skip
@@ -170,6 +204,11 @@ func (c *Compiler) SetNoEmit(noEmit bool) {
 	c.noEmit = noEmit
 }
 
+// SetBuildSourceMaps configures whether to record lock file source maps during compilation
+func (c *Compiler) SetBuildSourceMaps(build bool) {
+	c.buildSourceMaps = build
+}
+
 // SetFileTracker sets the file tracker for tracking created files
 func (c *Compiler) SetFileTracker(tracker FileTracker) {
 	c.fileTracker = tracker
@@ -190,6 +229,31 @@ func (c *Compiler) SetStrictMode(strict bool) {
 	c.strictMode = strict
 }
 
+// SetSecretNamingPolicy configures which secret names are accepted in secret references
+func (c *Compiler) SetSecretNamingPolicy(policy SecretNamingPolicy) {
+	c.secretNamingPolicy = policy
+}
+
+// HasSecretNamingPolicy reports whether a secret naming policy was set explicitly. Without one,
+// secret name violations are reported as warnings instead of failing compilation.
+func (c *Compiler) HasSecretNamingPolicy() bool {
+	return c.secretNamingPolicy != ""
+}
+
+// GetSecretNamingPolicy returns the secret naming policy, defaulting to SecretNamingStrict
+func (c *Compiler) GetSecretNamingPolicy() SecretNamingPolicy {
+	if c.secretNamingPolicy == "" {
+		return SecretNamingStrict
+	}
+	return c.secretNamingPolicy
+}
+
+// SetCheckSecretsExist configures whether to check referenced secrets against the secret names
+// of the repository and its organization
+func (c *Compiler) SetCheckSecretsExist(check bool) {
+	c.checkSecretsExist = check
+}
+
 // SetRefreshStopTime configures whether to force regeneration of stop-after times
 func (c *Compiler) SetRefreshStopTime(refresh bool) {
 	c.refreshStopTime = refresh
@@ -412,6 +476,9 @@ type WorkflowData struct {
 	ToolsTimeout                int                  // timeout in seconds for tool/MCP operations (0 = use engine default)
 	ToolsStartupTimeout         int                  // timeout in seconds for MCP server startup (0 = use engine default)
 	Features                    map[string]any       // feature flags and configuration options from frontmatter (supports bool and string values)
+	ActionlintIgnore            []string             // actionlint rule kinds suppressed for this workflow (from actionlint.ignore)
+	SelfHostedRunnerLabels      []string             // custom runner labels declared in runs-on fields, for actionlint
+	PermissionsPolicy           *PermissionsPolicy   // repository permissions policy enforced at compile time (from .github/gh-aw.yml)
 	ActionCache                 *ActionCache         // cache for action pin resolutions
 	ActionResolver              *ActionResolver      // resolver for action pins
 	StrictMode                  bool                 // strict mode for action pinning
@@ -425,6 +492,15 @@ type WorkflowData struct {
 	CheckoutConfigs             []*CheckoutConfig    // user-configured checkout settings from frontmatter
 	HasDispatchItemNumber       bool                 // true when workflow_dispatch has item_number input (generated by label trigger shorthand)
 	ConcurrencyJobDiscriminator string               // optional discriminator expression appended to job-level concurrency groups (from concurrency.job-discriminator)
+	ConcurrencyGroupBy          string               // optional key used for the generated workflow-level concurrency group instead of the entity number (from concurrency.group-by, e.g. "label")
+	ConcurrencyKey              string               // optional event expression that keys the generated workflow-level concurrency group instead of the trigger-based key (from concurrency.key, e.g. "github.event.client_payload.id")
+	ConcurrencyCancelInProgress string               // optional cancel-in-progress override for the generated workflow-level concurrency group ("true", "false", or an expression)
+	ConcurrencyCancelPolicy     map[string]bool      // optional per-trigger cancel-in-progress policy for the generated workflow-level concurrency group (from concurrency.cancel-policy, e.g. {"schedule": true})
+	ConcurrencyDisabled         bool                 // true when concurrency generation is disabled (from concurrency: none or concurrency: false)
+	ConcurrencyHashLongGroup    bool                 // true when generated workflow-level groups that would exceed GitHub's length limit are shortened with a hash (from concurrency.hash-long-group)
+	ConcurrencyHashPrefix       bool                 // true when the static prefix of generated workflow-level groups is replaced with gh-aw-<short-sha> (from concurrency.hash-prefix)
+	ConcurrencyForkIsolation    bool                 // true when pull request groups also key on the PR head repository so fork and internal runs never share a group (from concurrency.fork-isolation)
+	ConcurrencyMatrix           bool                 // true when custom matrix jobs without their own concurrency get a per-leg group keyed on strategy.job-index (from concurrency.matrix)
 	IsDetectionRun              bool                 // true when this WorkflowData is used for inline threat detection (not the main agent run)
 	EngineConfigSteps           []map[string]any     // steps returned by engine.RenderConfig — prepended before execution steps
 }
@@ -0,0 +1,49 @@
+// This file provides warning diagnostics collection for the compiler.
+//
+// Warnings are printed to stderr as they are emitted and also recorded as
+// structured diagnostics so callers (e.g. the CLI compile command) can render
+// them in other formats, such as GitHub Actions workflow annotations.
+
+package workflow
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/github/gh-aw/pkg/console"
+)
+
+// emitWarning prints a warning message to stderr and records it as a warning
+// diagnostic for the workflow currently being compiled.
+func (c *Compiler) emitWarning(message string) {
+	fmt.Fprintln(os.Stderr, console.FormatWarningMessage(message))
+	c.recordWarning(c.markdownPath, message)
+	c.IncrementWarningCount()
+}
+
+// emitCompilerWarning prints a file-scoped compiler warning to stderr and records
+// it as a warning diagnostic for filePath.
+func (c *Compiler) emitCompilerWarning(filePath string, message string) {
+	fmt.Fprintln(os.Stderr, formatCompilerMessage(filePath, "warning", message))
+	c.recordWarning(filePath, message)
+	c.IncrementWarningCount()
+}
+
+// recordWarning records a warning diagnostic without printing it or changing the warning count.
+// Compile-time warnings have no precise source position, so they are attributed to line 1.
+func (c *Compiler) recordWarning(filePath string, message string) {
+	c.warnings = append(c.warnings, console.CompilerError{
+		Position: console.ErrorPosition{
+			File:   filePath,
+			Line:   1,
+			Column: 1,
+		},
+		Type:    "warning",
+		Message: message,
+	})
+}
+
+// Warnings returns all warning diagnostics accumulated by this compiler instance
+func (c *Compiler) Warnings() []console.CompilerError {
+	return c.warnings
+}
@@ -0,0 +1,41 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompilerWarningsRecordsDiagnostics(t *testing.T) {
+	compiler := NewCompiler()
+	compiler.markdownPath = "workflows/test.md"
+
+	compiler.emitWarning("Using experimental feature: plugins")
+	compiler.emitCompilerWarning("workflows/other.md", "missing permission")
+
+	warnings := compiler.Warnings()
+	require.Len(t, warnings, 2, "both warnings should be recorded")
+	assert.Equal(t, 2, compiler.GetWarningCount(), "warning count should be incremented for each warning")
+
+	assert.Equal(t, "warning", warnings[0].Type, "diagnostic should be a warning")
+	assert.Equal(t, "workflows/test.md", warnings[0].Position.File, "warning should be attributed to the workflow being compiled")
+	assert.Equal(t, 1, warnings[0].Position.Line, "warning should be attributed to line 1")
+	assert.Equal(t, "Using experimental feature: plugins", warnings[0].Message, "message should be recorded")
+
+	assert.Equal(t, "workflows/other.md", warnings[1].Position.File, "file-scoped warning should keep its file")
+}
+
+func TestCompilerWarningsIncludeScheduleWarnings(t *testing.T) {
+	compiler := NewCompiler()
+	compiler.markdownPath = "workflows/schedule.md"
+
+	compiler.addScheduleWarning("schedule warning")
+
+	warnings := compiler.Warnings()
+	require.Len(t, warnings, 1, "schedule warning should be recorded as a diagnostic")
+	assert.Equal(t, "schedule warning", warnings[0].Message, "message should be recorded")
+	assert.Equal(t, 0, compiler.GetWarningCount(), "recording a schedule warning should not change the count")
+}
@@ -192,7 +192,9 @@ func (c *Compiler) generateWorkflowBody(yaml *strings.Builder, data *WorkflowDat
 	// Agent permissions are applied only to the agent job
 	yaml.WriteString("permissions: {}\n\n")
 
-	yaml.WriteString(data.Concurrency + "\n\n")
+	if data.Concurrency != "" {
+		yaml.WriteString(data.Concurrency + "\n\n")
+	}
 	yaml.WriteString(data.RunName + "\n\n")
 
 	// Add env section if present
@@ -1,7 +1,12 @@
 package workflow
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/github/gh-aw/pkg/logger"
@@ -9,37 +14,153 @@ import (
 
 var concurrencyLog = logger.New("workflow:concurrency")
 
+// concurrencyGroupByLabel is the concurrency.group-by value that keys issue and pull request
+// workflows on the triggering label name instead of the issue or pull request number.
+const concurrencyGroupByLabel = "label"
+
+// concurrencyGroupBySchedule is the concurrency.group-by value that keys schedule workflows
+// on the cron expression that triggered the run, so each schedule gets its own group
+const concurrencyGroupBySchedule = "schedule"
+
+// scheduleConcurrencyKey is the group key used with concurrency.group-by: schedule.
+// Runs not started by a schedule (e.g. workflow_dispatch) fall back to the event name.
+const scheduleConcurrencyKey = "${{ github.event.schedule || github.event_name }}"
+
+// forkIsolationConcurrencyKey is appended to pull request concurrency groups with
+// concurrency.fork-isolation so that runs from a fork never share a group with internal runs.
+// Events without a pull request payload fall back to the base repository.
+const forkIsolationConcurrencyKey = "${{ github.event.pull_request.head.repo.full_name || github.repository }}"
+
+// matrixConcurrencyKey is appended to the concurrency group of matrix jobs with
+// concurrency.matrix so that each matrix leg gets its own group
+const matrixConcurrencyKey = "${{ strategy.job-index }}"
+
+// scheduleConcurrencyComment is emitted above groups keyed per cron schedule
+const scheduleConcurrencyComment = "# Grouped per cron schedule (concurrency.group-by: schedule): runs from different schedules do not wait for each other"
+
+// plainConcurrencyGroupPattern matches group values that can be emitted as plain YAML
+// scalars: an identifier-like start followed by letters, digits, '_', '-', '.', or '/'.
+// Values with ':', '#', spaces, or ${{ }} expressions must stay quoted.
+var plainConcurrencyGroupPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_./-]*$`)
+
+// yamlReservedPlainScalars are plain scalars that YAML parsers may resolve to
+// booleans or null instead of strings, so they must stay quoted
+var yamlReservedPlainScalars = []string{"true", "false", "yes", "no", "on", "off", "y", "n", "null"}
+
+// ConcurrencyConfig is a compiler-generated workflow-level concurrency block
+type ConcurrencyConfig struct {
+	// Group is the concurrency group value, unquoted
+	Group string
+	// CancelInProgress is the cancel-in-progress value, or nil when it is omitted
+	// (GitHub Actions then defaults to false) or set by CancelInProgressExpression
+	CancelInProgress *bool
+	// CancelInProgressExpression is a ${{ }} expression from concurrency.cancel-in-progress,
+	// or the event-conditional default for mixed pull_request_target workflows, which is
+	// only known at runtime
+	CancelInProgressExpression string
+}
+
+// YAML renders the config as a "concurrency:" block
+func (c ConcurrencyConfig) YAML() string {
+	concurrencyConfig := "concurrency:\n"
+	if strings.Contains(c.Group, scheduleConcurrencyKey) {
+		concurrencyConfig += "  " + scheduleConcurrencyComment + "\n"
+	}
+	concurrencyConfig += "  group: " + formatConcurrencyGroupValue(c.Group)
+
+	if c.CancelInProgressExpression != "" {
+		concurrencyConfig += "\n  cancel-in-progress: " + c.CancelInProgressExpression
+	} else if c.CancelInProgress != nil {
+		concurrencyConfig += "\n  cancel-in-progress: " + strconv.FormatBool(*c.CancelInProgress)
+	}
+	return concurrencyConfig
+}
+
 // GenerateConcurrencyConfig generates the concurrency configuration for a workflow
 // based on its trigger types and characteristics.
 func GenerateConcurrencyConfig(workflowData *WorkflowData, isCommandTrigger bool) string {
 	concurrencyLog.Printf("Generating concurrency config: isCommandTrigger=%v", isCommandTrigger)
 
+	// Emit no concurrency block when disabled with concurrency: none or false
+	if workflowData.ConcurrencyDisabled {
+		concurrencyLog.Print("Concurrency generation disabled by frontmatter")
+		return ""
+	}
+
 	// Don't override if already set
 	if workflowData.Concurrency != "" {
 		concurrencyLog.Print("Using existing concurrency configuration from workflow data")
 		return workflowData.Concurrency
 	}
 
-	// Build concurrency group keys using the original workflow-specific logic
-	keys := buildConcurrencyGroupKeys(workflowData, isCommandTrigger)
-	groupValue := strings.Join(keys, "-")
-	concurrencyLog.Printf("Built concurrency group: %s", groupValue)
+	return BuildConcurrencyConfig(workflowData, isCommandTrigger).YAML()
+}
 
-	// Build the concurrency configuration
-	concurrencyConfig := fmt.Sprintf("concurrency:\n  group: \"%s\"", groupValue)
+// BuildConcurrencyConfig returns the compiler-generated workflow-level concurrency config
+// for workflowData: the group from BuildConcurrencyGroup and cancel-in-progress from the
+// concurrency.cancel-in-progress override, or the trigger heuristic when it is not set.
+//
+// Like BuildConcurrencyGroup, explicit concurrency blocks and concurrency: none are not
+// applied; GenerateConcurrencyConfig handles those before delegating here.
+func BuildConcurrencyConfig(workflowData *WorkflowData, isCommandTrigger bool) ConcurrencyConfig {
+	groupValue, cancelInProgress := BuildConcurrencyGroup(workflowData, isCommandTrigger)
+	config := ConcurrencyConfig{Group: groupValue}
+
+	// Prefer the frontmatter override to the trigger heuristic
+	switch override := workflowData.ConcurrencyCancelInProgress; override {
+	case "":
+		if expression := eventConditionalCancelInProgress(workflowData, isCommandTrigger); expression != "" {
+			concurrencyLog.Print("Enabling cancel-in-progress for all events except pull_request_target")
+			config.CancelInProgressExpression = expression
+		} else if cancelInProgress {
+			concurrencyLog.Print("Enabling cancel-in-progress for concurrency group")
+			config.CancelInProgress = &cancelInProgress
+		}
+	case "true", "false":
+		concurrencyLog.Printf("Using configured cancel-in-progress: %s", override)
+		enabled := override == "true"
+		config.CancelInProgress = &enabled
+	default:
+		concurrencyLog.Printf("Using configured cancel-in-progress expression: %s", override)
+		config.CancelInProgressExpression = override
+	}
 
-	// Add cancel-in-progress if appropriate
-	if shouldEnableCancelInProgress(workflowData, isCommandTrigger) {
-		concurrencyLog.Print("Enabling cancel-in-progress for concurrency group")
-		concurrencyConfig += "\n  cancel-in-progress: true"
+	return config
+}
+
+// BuildConcurrencyGroup returns the compiler-generated workflow-level concurrency group for
+// workflowData and whether its trigger types call for cancel-in-progress. The group is the
+// same value GenerateConcurrencyConfig emits: trigger-based keys joined with '-', with the
+// static prefix hashed when concurrency.hash-prefix is set, shortened when
+// concurrency.hash-long-group is set, and sanitized.
+//
+// Explicit concurrency blocks, concurrency: none, and the concurrency.cancel-in-progress
+// override are not applied; callers that honor those read them from workflowData.
+func BuildConcurrencyGroup(workflowData *WorkflowData, isCommandTrigger bool) (group string, cancelInProgress bool) {
+	// Build concurrency group keys using the original workflow-specific logic
+	keys := buildConcurrencyGroupKeys(workflowData, isCommandTrigger)
+	if workflowData.ConcurrencyHashPrefix {
+		keys = hashConcurrencyGroupPrefix(keys, workflowData.Name)
 	}
+	group = strings.Join(keys, "-")
+	if workflowData.ConcurrencyHashLongGroup {
+		group = shortenConcurrencyGroup(group, workflowData.Name)
+	}
+	group = sanitizeConcurrencyGroup(group)
+	concurrencyLog.Printf("Built concurrency group: %s", group)
 
-	return concurrencyConfig
+	return group, shouldEnableCancelInProgress(workflowData, isCommandTrigger)
 }
 
 // GenerateJobConcurrencyConfig generates the agent concurrency configuration
 // for the agent job based on engine.concurrency field
 func GenerateJobConcurrencyConfig(workflowData *WorkflowData) string {
+	return generateJobConcurrencyConfig(workflowData, GetGlobalEngineRegistry())
+}
+
+// generateJobConcurrencyConfig generates the agent job concurrency configuration,
+// consulting registry for engine-specific default concurrency templates
+func generateJobConcurrencyConfig(workflowData *WorkflowData, registry *EngineRegistry) string {
 	concurrencyLog.Print("Generating job-level concurrency config")
 
 	// If concurrency is explicitly configured in engine, use it
@@ -48,6 +169,12 @@ func GenerateJobConcurrencyConfig(workflowData *WorkflowData) string {
 		return workflowData.EngineConfig.Concurrency
 	}
 
+	// Skip the default job concurrency when disabled with concurrency: none or false
+	if workflowData.ConcurrencyDisabled {
+		concurrencyLog.Print("Concurrency generation disabled by frontmatter, skipping default job concurrency")
+		return ""
+	}
+
 	// Check if this workflow has special trigger handling (issues, PRs, discussions, push, command,
 	// or workflow_dispatch-only). For these cases, no default concurrency should be applied at agent level
 	if hasSpecialTriggers(workflowData) {
@@ -67,54 +194,256 @@ func GenerateJobConcurrencyConfig(workflowData *WorkflowData) string {
 		return ""
 	}
 
-	// Build the default concurrency configuration
+	// Build the default concurrency configuration, preferring the engine's own template
 	groupValue := fmt.Sprintf("gh-aw-%s-${{ github.workflow }}", engineID)
+	if template := engineDefaultConcurrency(registry, engineID); template != "" {
+		if template == engineDefaultConcurrencyNone {
+			concurrencyLog.Printf("Engine %s opts out of default job concurrency", engineID)
+			return ""
+		}
+		concurrencyLog.Printf("Using engine default concurrency template for %s: %s", engineID, template)
+		groupValue = strings.ReplaceAll(template, "{engine}", engineID)
+	}
+	// With group-by: schedule, key on the triggering cron expression so that different
+	// schedules do not serialize each other
+	groupedBySchedule := workflowData.ConcurrencyGroupBy == concurrencyGroupBySchedule
+	if groupedBySchedule {
+		concurrencyLog.Print("Appending schedule key to job-level concurrency group")
+		groupValue = fmt.Sprintf("%s-%s", groupValue, scheduleConcurrencyKey)
+	}
 	// If the user specified a job-discriminator, append it so that concurrent
 	// runs with different inputs (fan-out pattern) do not share the same group.
 	if workflowData.ConcurrencyJobDiscriminator != "" {
 		concurrencyLog.Printf("Appending job discriminator to job-level concurrency group: %s", workflowData.ConcurrencyJobDiscriminator)
 		groupValue = fmt.Sprintf("%s-%s", groupValue, workflowData.ConcurrencyJobDiscriminator)
 	}
-	concurrencyConfig := fmt.Sprintf("concurrency:\n  group: \"%s\"", groupValue)
+	concurrencyConfig := "concurrency:\n"
+	if groupedBySchedule {
+		concurrencyConfig += "  " + scheduleConcurrencyComment + "\n"
+	}
+	concurrencyConfig += "  group: " + formatConcurrencyGroupValue(groupValue)
 
 	return concurrencyConfig
 }
 
+// generateMatrixJobConcurrencyConfig generates the job-level concurrency configuration for a
+// custom matrix job when concurrency.matrix is set. The group extends the generated
+// workflow-level group with the job name and the matrix leg index, so a new run only cancels
+// (or queues behind) the in-progress leg with the same index. cancel-in-progress follows the
+// workflow-level setting and is always emitted, since custom jobs default to not cancelling.
+func generateMatrixJobConcurrencyConfig(workflowData *WorkflowData, isCommandTrigger bool, jobName string) string {
+	group, _ := BuildConcurrencyGroup(workflowData, isCommandTrigger)
+	group = fmt.Sprintf("%s-%s-%s", group, sanitizeConcurrencyGroupSegment(jobName), matrixConcurrencyKey)
+	concurrencyLog.Printf("Built matrix job concurrency group for %s: %s", jobName, group)
+
+	cancelValue := matrixJobCancelInProgress(workflowData, isCommandTrigger)
+	return "concurrency:\n  group: " + formatConcurrencyGroupValue(group) + "\n  cancel-in-progress: " + cancelValue
+}
+
+// matrixJobCancelInProgress returns the cancel-in-progress value of the per-leg groups
+// generated with concurrency.matrix: the concurrency.cancel-in-progress override, or the
+// workflow-level default for the workflow triggers
+func matrixJobCancelInProgress(workflowData *WorkflowData, isCommandTrigger bool) string {
+	if workflowData.ConcurrencyCancelInProgress != "" {
+		return workflowData.ConcurrencyCancelInProgress
+	}
+	if expression := eventConditionalCancelInProgress(workflowData, isCommandTrigger); expression != "" {
+		return expression
+	}
+	return strconv.FormatBool(shouldEnableCancelInProgress(workflowData, isCommandTrigger))
+}
+
+// hasGeneratedMatrixConcurrency reports whether concurrency.matrix generates a per-leg
+// concurrency group for a custom job, i.e. the job has a matrix and no concurrency of its own
+func hasGeneratedMatrixConcurrency(workflowData *WorkflowData, jobConfig map[string]any) bool {
+	if !workflowData.ConcurrencyMatrix || workflowData.ConcurrencyDisabled || !hasMatrixStrategy(jobConfig) {
+		return false
+	}
+	_, hasConcurrency := jobConfig["concurrency"]
+	return !hasConcurrency
+}
+
+// hasMatrixStrategy reports whether a custom job configuration declares a strategy matrix
+func hasMatrixStrategy(jobConfig map[string]any) bool {
+	strategy, ok := jobConfig["strategy"].(map[string]any)
+	return ok && strategy["matrix"] != nil
+}
+
+// formatConcurrencyGroupValue renders a concurrency group value for YAML output.
+// Simple values that YAML reads back as the same string are emitted without quotes;
+// everything else (e.g. values containing ':' or ${{ }} expressions) is double-quoted.
+func formatConcurrencyGroupValue(group string) string {
+	if plainConcurrencyGroupPattern.MatchString(group) && !slices.Contains(yamlReservedPlainScalars, strings.ToLower(group)) {
+		return group
+	}
+	return fmt.Sprintf("\"%s\"", group)
+}
+
+// unsafeConcurrencyGroupCharPattern matches characters replaced in the static segments of
+// generated concurrency groups (see sanitizeConcurrencyGroup)
+var unsafeConcurrencyGroupCharPattern = regexp.MustCompile(`[^A-Za-z0-9_.-]+`)
+
+// sanitizeConcurrencyGroup replaces runs of characters outside [A-Za-z0-9_.-] in the
+// static segments of a generated concurrency group with a single '-', so literal text
+// (e.g. from concurrency.key or a shortened workflow name) always yields a valid group.
+// ${{ }} expression segments are left unchanged.
+func sanitizeConcurrencyGroup(group string) string {
+	var sb strings.Builder
+	last := 0
+	for _, loc := range concurrencyExpressionPattern.FindAllStringIndex(group, -1) {
+		sb.WriteString(sanitizeConcurrencyGroupSegment(group[last:loc[0]]))
+		sb.WriteString(group[loc[0]:loc[1]])
+		last = loc[1]
+	}
+	sb.WriteString(sanitizeConcurrencyGroupSegment(group[last:]))
+	return sb.String()
+}
+
+// sanitizeConcurrencyGroupSegment replaces runs of disallowed characters in a static
+// concurrency group segment with a single '-'
+func sanitizeConcurrencyGroupSegment(segment string) string {
+	return unsafeConcurrencyGroupCharPattern.ReplaceAllString(segment, "-")
+}
+
+// concurrencyGroupHashLength is the number of hex characters of the SHA-256 suffix used
+// by shortenConcurrencyGroup
+const concurrencyGroupHashLength = 8
+
+// shortenConcurrencyGroup replaces ${{ github.workflow }} in a generated group with a
+// truncated workflow name plus a short SHA-256 suffix of the full name when the group
+// risks exceeding GitHub's length limit. The suffix keeps groups of different workflows
+// distinct. Groups that fit within the limit are returned unchanged.
+func shortenConcurrencyGroup(group string, workflowName string) string {
+	_, estimated := estimateConcurrencyGroupLength(group, workflowName)
+	if estimated <= maxConcurrencyGroupLength || !githubWorkflowExpressionPattern.MatchString(group) {
+		return group
+	}
+
+	sum := sha256.Sum256([]byte(workflowName))
+	suffix := hex.EncodeToString(sum[:])[:concurrencyGroupHashLength]
+	prefix := []rune(strings.Trim(sanitizeConcurrencyGroupSegment(workflowName), "-"))
+	keep := max(len(prefix)-(estimated-maxConcurrencyGroupLength)-len(suffix)-1, 0)
+	shortName := suffix
+	if keep > 0 {
+		shortName = strings.TrimRight(string(prefix[:keep]), "-") + "-" + suffix
+	}
+
+	concurrencyLog.Printf("Shortened workflow name in concurrency group to %s", shortName)
+	return githubWorkflowExpressionPattern.ReplaceAllLiteralString(group, shortName)
+}
+
+// hashConcurrencyGroupPrefix replaces the static prefix of generated concurrency group keys
+// (the leading keys before the first per-run expression, including ${{ github.workflow }},
+// which is fixed for a workflow) with a single gh-aw-<short-sha> key. The hash covers the
+// workflow name and all original keys, so the result is deterministic and distinct per
+// workflow. The per-run expression keys are preserved unchanged.
+func hashConcurrencyGroupPrefix(keys []string, workflowName string) []string {
+	prefixLen := 0
+	for prefixLen < len(keys) && isStaticConcurrencyKey(keys[prefixLen]) {
+		prefixLen++
+	}
+
+	sum := sha256.Sum256([]byte(workflowName + "\n" + strings.Join(keys, "-")))
+	hashed := "gh-aw-" + hex.EncodeToString(sum[:])[:concurrencyGroupHashLength]
+	concurrencyLog.Printf("Replaced %d static concurrency keys with %s", prefixLen, hashed)
+
+	return append([]string{hashed}, keys[prefixLen:]...)
+}
+
+// isStaticConcurrencyKey reports whether a concurrency group key has the same value for
+// every run of a workflow: literal text or the ${{ github.workflow }} expression
+func isStaticConcurrencyKey(key string) bool {
+	return !strings.Contains(key, "${{") || githubWorkflowExpressionPattern.ReplaceAllString(key, "") == ""
+}
+
+// engineDefaultConcurrencyNone is the engine default concurrency template that opts
+// an engine out of default agent job concurrency grouping
+const engineDefaultConcurrencyNone = "none"
+
+// engineDefaultConcurrency returns the default agent job concurrency template declared
+// by the registered engine with the given ID, or "" when the engine declares none.
+// Prefix matches (e.g. "codex-experimental") resolve to the matching engine.
+func engineDefaultConcurrency(registry *EngineRegistry, engineID string) string {
+	engine, err := registry.GetEngine(engineID)
+	if err != nil {
+		if engine, err = registry.GetEngineByPrefix(engineID); err != nil {
+			return ""
+		}
+	}
+	type defaultConcurrencyProvider interface{ getDefaultConcurrency() string }
+	if p, ok := engine.(defaultConcurrencyProvider); ok {
+		return p.getDefaultConcurrency()
+	}
+	return ""
+}
+
 // hasSpecialTriggers checks if the workflow has special trigger types that require
-// workflow-level concurrency handling (issues, PRs, discussions, push, command,
-// slash_command, or workflow_dispatch-only)
+// workflow-level concurrency handling (issues, PRs, discussions, push, workflow_run,
+// merge_group, release, repository_dispatch, command, slash_command, or
+// workflow_dispatch-only), or a custom
+// concurrency key that takes their place
 func hasSpecialTriggers(workflowData *WorkflowData) bool {
+	// A custom concurrency key groups runs per event like the built-in trigger keys
+	if workflowData.ConcurrencyKey != "" {
+		return true
+	}
+
 	// Check for specific trigger types that have special concurrency handling
-	on := workflowData.On
+	triggers := workflowTriggers(workflowData.On)
 
 	// Check for issue-related triggers
-	if isIssueWorkflow(on) {
+	if isIssueWorkflow(triggers) {
 		return true
 	}
 
 	// Check for pull request triggers
-	if isPullRequestWorkflow(on) {
+	if isPullRequestWorkflow(triggers) {
 		return true
 	}
 
 	// Check for discussion triggers
-	if isDiscussionWorkflow(on) {
+	if isDiscussionWorkflow(triggers) {
 		return true
 	}
 
 	// Check for push triggers
-	if isPushWorkflow(on) {
+	if isPushWorkflow(triggers) {
+		return true
+	}
+
+	// Check for workflow_run triggers (chained workflows keyed on the triggering run)
+	if isWorkflowRunWorkflow(triggers) {
+		return true
+	}
+
+	// Check for merge_group triggers (merge queue checks keyed on the merge group commit)
+	if isMergeGroupWorkflow(triggers) {
+		return true
+	}
+
+	// Check for release triggers (keyed on the release tag)
+	if isReleaseWorkflow(triggers) {
+		return true
+	}
+
+	// Check for repository_dispatch triggers (keyed on the dispatched event type)
+	if isRepositoryDispatchWorkflow(triggers) {
+		return true
+	}
+
+	// Check for deployment triggers (keyed on the deployment environment)
+	if isDeploymentWorkflow(triggers) {
 		return true
 	}
 
 	// Check for slash_command triggers (synthetic event that expands to issue_comment + workflow_dispatch)
-	if isSlashCommandWorkflow(on) {
+	if isSlashCommandWorkflow(workflowData.On) {
 		return true
 	}
 
 	// workflow_dispatch-only workflows represent explicit user intent, so the
 	// top-level workflow concurrency group is sufficient – no engine-level group needed
-	if isWorkflowDispatchOnly(on) {
+	if isWorkflowDispatchOnly(triggers) {
 		return true
 	}
 
@@ -123,33 +452,64 @@ func hasSpecialTriggers(workflowData *WorkflowData) bool {
 	return false
 }
 
+// workflowTriggers parses a workflow's rendered "on" section with ParseTriggerSet so that the
+// trigger helpers below can query it without parsing it again. Only top-level events are
+// included, so nested keys such as workflow_dispatch input names ("force_push", "issues"),
+// choice options, or branch filters are never mistaken for triggers. An "on" section that
+// cannot be parsed yields an empty set.
+func workflowTriggers(on string) TriggerSet {
+	triggers, err := ParseTriggerSet(on)
+	if err != nil {
+		concurrencyLog.Printf("Failed to parse on section for trigger detection: %v", err)
+		return TriggerSet{}
+	}
+	return triggers
+}
+
 // isPullRequestWorkflow checks if a workflow's "on" section contains pull_request triggers
-func isPullRequestWorkflow(on string) bool {
-	return strings.Contains(on, "pull_request")
+func isPullRequestWorkflow(triggers TriggerSet) bool {
+	return triggers.Has("pull_request", "pull_request_target", "pull_request_review", "pull_request_review_comment")
+}
+
+// isPullRequestTargetWorkflow checks if a workflow's "on" section contains pull_request_target triggers
+func isPullRequestTargetWorkflow(triggers TriggerSet) bool {
+	return triggers.Has("pull_request_target")
+}
+
+// isRegularPullRequestWorkflow checks if a workflow's "on" section contains pull request
+// triggers that run in the context of the PR merge commit, i.e. any PR trigger other than
+// pull_request_target
+func isRegularPullRequestWorkflow(triggers TriggerSet) bool {
+	return triggers.Has("pull_request", "pull_request_review", "pull_request_review_comment")
 }
 
 // isIssueWorkflow checks if a workflow's "on" section contains issue-related triggers
-func isIssueWorkflow(on string) bool {
-	return strings.Contains(on, "issues") || strings.Contains(on, "issue_comment")
+func isIssueWorkflow(triggers TriggerSet) bool {
+	return triggers.Has("issues", "issue_comment")
 }
 
 // isDiscussionWorkflow checks if a workflow's "on" section contains discussion-related triggers
-func isDiscussionWorkflow(on string) bool {
-	return strings.Contains(on, "discussion")
+func isDiscussionWorkflow(triggers TriggerSet) bool {
+	return triggers.Has("discussion", "discussion_comment")
+}
+
+// isDiscussionCommentWorkflow checks if a workflow's "on" section contains discussion_comment triggers
+func isDiscussionCommentWorkflow(triggers TriggerSet) bool {
+	return triggers.Has("discussion_comment")
 }
 
 // isWorkflowDispatchOnly returns true when workflow_dispatch is the only trigger in the
 // "on" section, indicating the workflow is always started by explicit user intent.
 // It handles both rendered YAML (standard GitHub Actions events) and input YAML
 // (which may contain synthetic events like slash_command before they are expanded).
-func isWorkflowDispatchOnly(on string) bool {
-	if !strings.Contains(on, "workflow_dispatch") {
+func isWorkflowDispatchOnly(triggers TriggerSet) bool {
+	if !triggers.Has("workflow_dispatch") {
 		return false
 	}
-	// If any other common trigger is present as a YAML key, this is not a
-	// workflow_dispatch-only workflow. We check for the trigger name followed by
-	// ':' (YAML key in object form) or as the sole inline value to avoid false
-	// matches from input parameter names (e.g., "push_branch" ≠ "push" trigger).
+	// If any other common trigger is an event of the on section, this is not a
+	// workflow_dispatch-only workflow. Only top-level events are considered, so input
+	// parameter names (e.g., "push_branch", "force_push", or an input named "issues")
+	// are not mistaken for triggers.
 	// slash_command is included here because it is a synthetic event that expands
 	// to issue_comment + workflow_dispatch at compile time; its presence means the
 	// workflow is not triggered solely by explicit user dispatch.
@@ -162,22 +522,38 @@ func isWorkflowDispatchOnly(on string) bool {
 		"status", "watch", "merge_group", "check_run", "check_suite",
 		"slash_command",
 	}
-	for _, trigger := range otherTriggers {
-		// Trigger in object format: "push:" / "  push:"
-		if strings.Contains(on, trigger+":") {
-			return false
-		}
-		// Trigger in inline format: "on: push" (no colon, trigger is the last token)
-		if strings.HasSuffix(strings.TrimSpace(on), " "+trigger) {
-			return false
-		}
-	}
-	return true
+	return !triggers.Has(otherTriggers...)
 }
 
 // isPushWorkflow checks if a workflow's "on" section contains push triggers
-func isPushWorkflow(on string) bool {
-	return strings.Contains(on, "push")
+func isPushWorkflow(triggers TriggerSet) bool {
+	return triggers.Has("push")
+}
+
+// isWorkflowRunWorkflow checks if a workflow's "on" section contains workflow_run triggers
+func isWorkflowRunWorkflow(triggers TriggerSet) bool {
+	return triggers.Has("workflow_run")
+}
+
+// isMergeGroupWorkflow checks if a workflow's "on" section contains merge_group triggers
+func isMergeGroupWorkflow(triggers TriggerSet) bool {
+	return triggers.Has("merge_group")
+}
+
+// isReleaseWorkflow checks if a workflow's "on" section contains release triggers
+func isReleaseWorkflow(triggers TriggerSet) bool {
+	return triggers.Has("release")
+}
+
+// isRepositoryDispatchWorkflow checks if a workflow's "on" section contains repository_dispatch triggers
+func isRepositoryDispatchWorkflow(triggers TriggerSet) bool {
+	return triggers.Has("repository_dispatch")
+}
+
+// isDeploymentWorkflow checks if a workflow's "on" section contains deployment or
+// deployment_status triggers
+func isDeploymentWorkflow(triggers TriggerSet) bool {
+	return triggers.Has("deployment", "deployment_status")
 }
 
 // isSlashCommandWorkflow checks if a workflow's "on" section contains the slash_command
@@ -189,6 +565,53 @@ func isSlashCommandWorkflow(on string) bool {
 	return strings.Contains(on, "slash_command")
 }
 
+// pushEventRefCondition is the concurrency key part that resolves to the ref for push
+// events and to false otherwise, so mixed push and PR workflows key push runs on the
+// branch and PR runs on the PR number
+const pushEventRefCondition = "github.event_name == 'push' && github.ref"
+
+// pullRequestTargetNodeIDCondition is the concurrency key part that resolves to the PR node
+// ID for pull_request_target events and to false otherwise. The node ID identifies the same
+// PR as its number but never equals a number, so workflows with both pull_request_target and
+// regular PR triggers key target runs apart from regular runs for the same PR.
+const pullRequestTargetNodeIDCondition = "github.event_name == 'pull_request_target' && github.event.pull_request.node_id"
+
+// mergeGroupHeadSHA is the concurrency key part that identifies a merge queue entry
+const mergeGroupHeadSHA = "github.event.merge_group.head_sha"
+
+// pullRequestPrimaryParts returns the primary identifiers for pull request workflows.
+// Merge queue checks have no PR number, so workflows that also run on merge_group key
+// those runs on the merge group commit before falling back to the PR number.
+func pullRequestPrimaryParts(workflowData *WorkflowData, triggers TriggerSet) []string {
+	parts := entityPrimaryParts(workflowData, pullRequestNumberParts(triggers)...)
+	if isMergeGroupWorkflow(triggers) {
+		return append([]string{mergeGroupHeadSHA}, parts...)
+	}
+	return parts
+}
+
+// pullRequestNumberParts returns the PR number identifiers for a workflow's concurrency key.
+// pull_request_target runs execute with a privileged token against the base branch, unlike
+// regular PR runs, so when a workflow has both, target runs are keyed on
+// pullRequestTargetNodeIDCondition first and never share a group with regular runs.
+func pullRequestNumberParts(triggers TriggerSet) []string {
+	if isPullRequestTargetWorkflow(triggers) && isRegularPullRequestWorkflow(triggers) {
+		return []string{pullRequestTargetNodeIDCondition, "github.event.pull_request.number"}
+	}
+	return []string{"github.event.pull_request.number"}
+}
+
+// concurrencyKeyExpression returns the concurrency group key for a concurrency.key value.
+// A context path (e.g. "github.event.client_payload.id") is wrapped in ${{ }}; a value that
+// already contains an expression is used verbatim.
+func concurrencyKeyExpression(key string) string {
+	key = strings.TrimSpace(key)
+	if strings.Contains(key, "${{") {
+		return key
+	}
+	return "${{ " + key + " }}"
+}
+
 // entityConcurrencyKey builds a ${{ ... }} concurrency-group expression for entity-number
 // based workflows. primaryParts are the event-number identifiers (e.g.,
 // "github.event.pull_request.number"), tailParts are the trailing fallbacks (e.g.,
@@ -205,65 +628,122 @@ func entityConcurrencyKey(primaryParts []string, tailParts []string, hasItemNumb
 	return "${{ " + strings.Join(parts, " || ") + " }}"
 }
 
+// entityPrimaryParts returns the event-number identifiers for an entity-number based
+// concurrency key. When the workflow sets concurrency.group-by: label, the label name is
+// placed first so that all events for the same label share a group; events without a
+// label (e.g. a manual dispatch) fall back to the entity number.
+func entityPrimaryParts(workflowData *WorkflowData, parts ...string) []string {
+	if workflowData.ConcurrencyGroupBy == concurrencyGroupByLabel {
+		return append([]string{"github.event.label.name"}, parts...)
+	}
+	return parts
+}
+
 // buildConcurrencyGroupKeys builds an array of keys for the concurrency group
 func buildConcurrencyGroupKeys(workflowData *WorkflowData, isCommandTrigger bool) []string {
 	keys := []string{"gh-aw", "${{ github.workflow }}"}
+	triggers := workflowTriggers(workflowData.On)
 
 	// Whether this workflow exposes inputs.item_number via workflow_dispatch (label trigger shorthand).
 	// When true, include it in the concurrency key so that manual dispatches for different items
 	// use distinct groups and don't cancel each other.
 	hasItemNumber := workflowData.HasDispatchItemNumber
 
-	if isCommandTrigger || isSlashCommandWorkflow(workflowData.On) {
+	if workflowData.ConcurrencyKey != "" {
+		// Custom key from concurrency.key replaces the trigger-based key
+		keys = append(keys, concurrencyKeyExpression(workflowData.ConcurrencyKey))
+	} else if isCommandTrigger || isSlashCommandWorkflow(workflowData.On) {
 		// For command/slash_command workflows: use issue/PR number; fall back to run_id when
 		// neither is available (e.g. manual workflow_dispatch of the outer workflow).
 		keys = append(keys, "${{ github.event.issue.number || github.event.pull_request.number || github.run_id }}")
-	} else if isPullRequestWorkflow(workflowData.On) && isIssueWorkflow(workflowData.On) {
+	} else if isPullRequestWorkflow(triggers) && isIssueWorkflow(triggers) {
 		// Mixed workflows with both issue and PR triggers
 		keys = append(keys, entityConcurrencyKey(
-			[]string{"github.event.issue.number", "github.event.pull_request.number"},
+			entityPrimaryParts(workflowData, append([]string{"github.event.issue.number"}, pullRequestNumberParts(triggers)...)...),
 			[]string{"github.run_id"},
 			hasItemNumber,
 		))
-	} else if isPullRequestWorkflow(workflowData.On) && isDiscussionWorkflow(workflowData.On) {
+	} else if isPullRequestWorkflow(triggers) && isDiscussionWorkflow(triggers) {
 		// Mixed workflows with PR and discussion triggers
 		keys = append(keys, entityConcurrencyKey(
-			[]string{"github.event.pull_request.number", "github.event.discussion.number"},
+			entityPrimaryParts(workflowData, append(pullRequestNumberParts(triggers), "github.event.discussion.number")...),
 			[]string{"github.run_id"},
 			hasItemNumber,
 		))
-	} else if isIssueWorkflow(workflowData.On) && isDiscussionWorkflow(workflowData.On) {
+	} else if isIssueWorkflow(triggers) && isDiscussionWorkflow(triggers) {
 		// Mixed workflows with issue and discussion triggers
 		keys = append(keys, entityConcurrencyKey(
-			[]string{"github.event.issue.number", "github.event.discussion.number"},
+			entityPrimaryParts(workflowData, "github.event.issue.number", "github.event.discussion.number"),
 			[]string{"github.run_id"},
 			hasItemNumber,
 		))
-	} else if isPullRequestWorkflow(workflowData.On) {
+	} else if isPullRequestWorkflow(triggers) && isPushWorkflow(triggers) {
+		// Mixed push and PR workflows: push events have no PR number, so key them on the
+		// ref explicitly and PR events on the PR number
+		keys = append(keys, entityConcurrencyKey(
+			append([]string{pushEventRefCondition}, pullRequestPrimaryParts(workflowData, triggers)...),
+			[]string{"github.ref", "github.run_id"},
+			hasItemNumber,
+		))
+	} else if isPullRequestWorkflow(triggers) {
 		// PR workflows: use PR number, fall back to ref then run_id
 		keys = append(keys, entityConcurrencyKey(
-			[]string{"github.event.pull_request.number"},
+			pullRequestPrimaryParts(workflowData, triggers),
 			[]string{"github.ref", "github.run_id"},
 			hasItemNumber,
 		))
-	} else if isIssueWorkflow(workflowData.On) {
+		if workflowData.ConcurrencyForkIsolation {
+			// Key on the PR head repository as well, so a fork PR never shares a group
+			// with an internal PR (or branch) that resolves to the same identifier
+			keys = append(keys, forkIsolationConcurrencyKey)
+		}
+	} else if isIssueWorkflow(triggers) {
 		// Issue workflows: run_id is the fallback when no issue context is available
 		// (e.g. when a mixed-trigger workflow is started via workflow_dispatch).
 		keys = append(keys, entityConcurrencyKey(
-			[]string{"github.event.issue.number"},
+			entityPrimaryParts(workflowData, "github.event.issue.number"),
+			[]string{"github.run_id"},
+			hasItemNumber,
+		))
+	} else if isDiscussionCommentWorkflow(triggers) {
+		// Discussion comment workflows: the comment payload carries its parent discussion,
+		// so comments are grouped with the discussion they belong to
+		keys = append(keys, entityConcurrencyKey(
+			[]string{"github.event.discussion.number"},
 			[]string{"github.run_id"},
 			hasItemNumber,
 		))
-	} else if isDiscussionWorkflow(workflowData.On) {
+	} else if isDiscussionWorkflow(triggers) {
 		// Discussion workflows: run_id is the fallback when no discussion context is available.
 		keys = append(keys, entityConcurrencyKey(
 			[]string{"github.event.discussion.number"},
 			[]string{"github.run_id"},
 			hasItemNumber,
 		))
-	} else if isPushWorkflow(workflowData.On) {
+	} else if isPushWorkflow(triggers) {
 		// Push workflows: use ref to differentiate between branches
 		keys = append(keys, "${{ github.ref || github.run_id }}")
+	} else if isWorkflowRunWorkflow(triggers) {
+		// Chained workflows: key on the triggering workflow run so downstream runs for
+		// different source runs do not collide
+		keys = append(keys, "${{ github.event.workflow_run.id || github.run_id }}")
+	} else if isMergeGroupWorkflow(triggers) {
+		// Merge queue workflows: key on the merge group commit so each queue entry has its own group
+		keys = append(keys, "${{ "+mergeGroupHeadSHA+" || github.run_id }}")
+	} else if isReleaseWorkflow(triggers) {
+		// Release workflows: key on the release tag so different releases do not share a group
+		keys = append(keys, "${{ github.event.release.tag_name || github.ref }}")
+	} else if isRepositoryDispatchWorkflow(triggers) {
+		// Repository dispatch workflows: key on the event type so different pipelines
+		// dispatched to the same workflow do not share a group
+		keys = append(keys, "${{ github.event.action || github.run_id }}")
+	} else if isDeploymentWorkflow(triggers) {
+		// Deployment workflows: key on the target environment so deployments to production
+		// and staging serialize independently instead of waiting for each other
+		keys = append(keys, "${{ github.event.deployment.environment || github.run_id }}")
+	} else if workflowData.ConcurrencyGroupBy == concurrencyGroupBySchedule {
+		// Schedule workflows grouped per cron expression (concurrency.group-by: schedule)
+		keys = append(keys, scheduleConcurrencyKey)
 	}
 
 	return keys
@@ -276,6 +756,104 @@ func shouldEnableCancelInProgress(workflowData *WorkflowData, isCommandTrigger b
 		return false
 	}
 
+	triggers := workflowTriggers(workflowData.On)
+
+	// Never enable cancellation for merge queue workflows; cancelling queued checks
+	// removes the pull request from the merge queue
+	if isMergeGroupWorkflow(triggers) {
+		return false
+	}
+
+	// Consult the per-trigger policy from concurrency.cancel-policy when one is configured
+	if len(workflowData.ConcurrencyCancelPolicy) > 0 {
+		return shouldCancelByPolicy(workflowData)
+	}
+
+	// Never enable cancellation by default for pull_request_target workflows; they run with a
+	// privileged token and secrets, so interrupting a run requires explicit opt-in through
+	// concurrency.cancel-in-progress
+	if isPullRequestTargetWorkflow(triggers) {
+		return false
+	}
+
+	// Never enable cancellation by default for deployment workflows; cancelling an in-flight
+	// deployment can leave an environment partially deployed
+	if isDeploymentWorkflow(triggers) {
+		return false
+	}
+
 	// Enable cancellation for pull request workflows (including mixed workflows)
-	return isPullRequestWorkflow(workflowData.On)
+	return isPullRequestWorkflow(triggers)
+}
+
+// excludePullRequestTargetCancelExpression enables cancel-in-progress for every event except
+// pull_request_target
+const excludePullRequestTargetCancelExpression = "${{ github.event_name != 'pull_request_target' }}"
+
+// eventConditionalCancelInProgress returns the cancel-in-progress expression for workflows
+// with both pull_request_target and regular pull request triggers, or "" for other workflows.
+// shouldEnableCancelInProgress keeps such workflows from cancelling unconditionally, since
+// pull_request_target runs need an explicit opt-in; the expression still lets regular pull
+// request runs cancel outdated ones. This is only safe because pullRequestNumberParts keys
+// target runs apart from regular runs for the same PR, so the two never share a group. A
+// concurrency.key or group-by: label key does not separate them, and command, merge queue,
+// deployment, and cancel-policy workflows follow their own rules, so those get no expression.
+func eventConditionalCancelInProgress(workflowData *WorkflowData, isCommandTrigger bool) string {
+	triggers := workflowTriggers(workflowData.On)
+	if isCommandTrigger || isMergeGroupWorkflow(triggers) || len(workflowData.ConcurrencyCancelPolicy) > 0 {
+		return ""
+	}
+	if workflowData.ConcurrencyKey != "" || workflowData.ConcurrencyGroupBy == concurrencyGroupByLabel {
+		return ""
+	}
+	if isDeploymentWorkflow(triggers) || isSlashCommandWorkflow(workflowData.On) {
+		return ""
+	}
+	if !isPullRequestTargetWorkflow(triggers) || !isRegularPullRequestWorkflow(triggers) {
+		return ""
+	}
+	return excludePullRequestTargetCancelExpression
+}
+
+// defaultCancelInProgressTriggers lists the triggers whose runs are cancelled by newer runs
+// in the same group when concurrency.cancel-policy does not mention them
+var defaultCancelInProgressTriggers = []string{"pull_request", "pull_request_review", "pull_request_review_comment"}
+
+// optInCancelInProgressTriggers lists the triggers whose workflows are never cancelled unless
+// concurrency.cancel-policy lists them explicitly, even when another trigger would cancel
+var optInCancelInProgressTriggers = []string{"pull_request_target", "deployment", "deployment_status"}
+
+// shouldCancelByPolicy applies concurrency.cancel-policy to the workflow triggers. Each
+// trigger uses its policy entry, or the default (pull request triggers cancel) when it has
+// none, and cancellation is enabled when any trigger cancels. A trigger from
+// optInCancelInProgressTriggers without an explicit entry keeps the default of never
+// cancelling the workflow.
+func shouldCancelByPolicy(workflowData *WorkflowData) bool {
+	triggers, err := ParseTriggerSet(workflowData.On)
+	if err != nil {
+		concurrencyLog.Printf("Cannot apply cancel-policy: %v", err)
+		return false
+	}
+
+	policy := workflowData.ConcurrencyCancelPolicy
+	for _, event := range optInCancelInProgressTriggers {
+		if _, hasTrigger := triggers[event]; !hasTrigger {
+			continue
+		}
+		if _, listed := policy[event]; !listed {
+			return false
+		}
+	}
+
+	for event := range triggers {
+		cancel, listed := policy[event]
+		if !listed {
+			cancel = slices.Contains(defaultCancelInProgressTriggers, event)
+		}
+		if cancel {
+			concurrencyLog.Printf("cancel-policy enables cancel-in-progress for %s", event)
+			return true
+		}
+	}
+	return false
 }
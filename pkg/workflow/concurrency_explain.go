@@ -0,0 +1,239 @@
+// This file provides human-readable explanations of compiler-generated concurrency groups.
+//
+// # Concurrency Group Explanation
+//
+// ExplainConcurrencyGroup breaks the workflow-level concurrency group produced by
+// buildConcurrencyGroupKeys into its key fragments and describes each one in plain
+// English (e.g. "workflow identity", "PR number, falling back to branch ref"), so
+// reports can show users what each part of the group does.
+//
+// ExplainConcurrencyKeyResolution shows, for each trigger event of the workflow, which
+// identifier of the trigger-based key the event resolves to, making the fallback ordering
+// of mixed-trigger keys (e.g. issue number before discussion number) explicit.
+//
+// Only the compiler-generated group is explained; a custom concurrency block from the
+// frontmatter is emitted verbatim and is not broken down.
+
+package workflow
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// concurrencyIdentifierDescriptions maps the identifiers used in generated concurrency
+// keys to plain-English descriptions
+var concurrencyIdentifierDescriptions = map[string]string{
+	"github.workflow":                               "workflow identity",
+	"github.event.pull_request.number":              "PR number",
+	"github.event.issue.number":                     "issue number",
+	"github.event.discussion.number":                "discussion number",
+	"github.event.label.name":                       "label name",
+	"inputs.item_number":                            "dispatched item number",
+	"github.event.workflow_run.id":                  "triggering workflow run ID",
+	mergeGroupHeadSHA:                               "merge group commit SHA",
+	"github.event.release.tag_name":                 "release tag",
+	"github.event.action":                           "repository dispatch event type",
+	"github.event.deployment.environment":           "deployment environment",
+	"github.event.pull_request.head.repo.full_name": "PR head repository",
+	"github.repository":                             "repository",
+	"github.event.schedule":                         "cron schedule",
+	"github.event_name":                             "event name",
+	"github.ref":                                    "branch ref",
+	pushEventRefCondition:                           "branch ref for push events",
+	pullRequestTargetNodeIDCondition:                "PR node ID for pull_request_target events",
+	"github.run_id":                                 "run ID (unique per run)",
+}
+
+// concurrencyIdentifierEvents maps the event-specific identifiers used in generated
+// concurrency keys to the trigger events whose payload populates them. Identifiers not
+// listed here (github.ref, github.run_id, github.event_name, custom expressions) are treated
+// as available for every event.
+var concurrencyIdentifierEvents = map[string][]string{
+	"github.event.issue.number":           {"issues", "issue_comment"},
+	"github.event.pull_request.number":    {"pull_request", "pull_request_target", "pull_request_review", "pull_request_review_comment"},
+	"github.event.discussion.number":      {"discussion", "discussion_comment"},
+	"github.event.label.name":             labelTriggerEvents,
+	"inputs.item_number":                  {"workflow_dispatch"},
+	"github.event.workflow_run.id":        {"workflow_run"},
+	mergeGroupHeadSHA:                     {"merge_group"},
+	"github.event.release.tag_name":       {"release"},
+	"github.event.schedule":               {"schedule"},
+	"github.event.action":                 {"repository_dispatch"},
+	"github.event.deployment.environment": {"deployment", "deployment_status"},
+	pushEventRefCondition:                 {"push"},
+	pullRequestTargetNodeIDCondition:      {"pull_request_target"},
+}
+
+// conditionalConcurrencyIdentifiers lists identifiers that are only populated for some
+// activity of the events that carry them; an event falls through to the next identifier
+// when they are empty
+var conditionalConcurrencyIdentifiers = map[string]string{
+	"github.event.label.name": "labeled or unlabeled activity",
+	"inputs.item_number":      "dispatches that provide it",
+}
+
+// ConcurrencyEventResolution describes which identifier of a trigger-based concurrency key
+// a trigger event resolves to
+type ConcurrencyEventResolution struct {
+	Event       string `json:"event"`       // Trigger event (e.g., "discussion")
+	Identifier  string `json:"identifier"`  // First identifier the event always populates (e.g., "github.event.discussion.number")
+	Description string `json:"description"` // Plain-English description (e.g., "discussion number")
+}
+
+// ConcurrencyGroupFragment is a single key fragment of a concurrency group
+type ConcurrencyGroupFragment struct {
+	Key         string `json:"key"`         // Fragment as it appears in the group (e.g., "${{ github.ref || github.run_id }}")
+	Description string `json:"description"` // Plain-English description (e.g., "branch ref, falling back to run ID (unique per run)")
+}
+
+// ConcurrencyGroupExplanation describes a compiler-generated concurrency group
+type ConcurrencyGroupExplanation struct {
+	Group     string                     `json:"group"`     // Full group value (fragments joined with '-')
+	Fragments []ConcurrencyGroupFragment `json:"fragments"` // Fragments in group order
+}
+
+// ExplainConcurrencyGroup returns the compiler-generated workflow-level concurrency group
+// for workflowData broken down into described key fragments
+func ExplainConcurrencyGroup(workflowData *WorkflowData, isCommandTrigger bool) ConcurrencyGroupExplanation {
+	keys := buildConcurrencyGroupKeys(workflowData, isCommandTrigger)
+	concurrencyLog.Printf("Explaining concurrency group with %d fragments", len(keys))
+
+	explanation := ConcurrencyGroupExplanation{
+		Group:     strings.Join(keys, "-"),
+		Fragments: make([]ConcurrencyGroupFragment, 0, len(keys)),
+	}
+	for _, key := range keys {
+		explanation.Fragments = append(explanation.Fragments, ConcurrencyGroupFragment{
+			Key:         key,
+			Description: describeConcurrencyKey(key),
+		})
+	}
+	return explanation
+}
+
+// ExplainConcurrencyKeyResolution returns, for each trigger event of the workflow in sorted
+// order, the identifier of the trigger-based concurrency key that the event resolves to:
+// the first identifier in the "||" chain that the event populates. Identifiers populated only
+// for some activity (e.g. the label name) are mentioned in the description. Returns nil when
+// the group has no trigger-based key or the "on" section cannot be parsed.
+func ExplainConcurrencyKeyResolution(workflowData *WorkflowData, isCommandTrigger bool) []ConcurrencyEventResolution {
+	keys := buildConcurrencyGroupKeys(workflowData, isCommandTrigger)
+	if len(keys) < 3 {
+		return nil
+	}
+	triggers, err := ParseTriggerSet(workflowData.On)
+	if err != nil {
+		concurrencyLog.Printf("Cannot resolve concurrency key per event: %v", err)
+		return nil
+	}
+
+	// The trigger-based key follows the gh-aw prefix and the workflow identity
+	identifiers := concurrencyKeyIdentifiers(keys[2])
+	events := make([]string, 0, len(triggers))
+	for event := range triggers {
+		events = append(events, event)
+	}
+	slices.Sort(events)
+
+	resolutions := make([]ConcurrencyEventResolution, 0, len(events))
+	for _, event := range events {
+		identifier, conditional := resolveConcurrencyIdentifier(identifiers, event)
+		if identifier == "" {
+			continue
+		}
+		description := describeConcurrencyIdentifier(identifier)
+		for i := len(conditional) - 1; i >= 0; i-- {
+			description = fmt.Sprintf("%s for %s, otherwise %s",
+				describeConcurrencyIdentifier(conditional[i]), conditionalConcurrencyIdentifiers[conditional[i]], description)
+		}
+		resolutions = append(resolutions, ConcurrencyEventResolution{Event: event, Identifier: identifier, Description: description})
+	}
+	return resolutions
+}
+
+// resolveConcurrencyIdentifier returns the first identifier in the chain that event always
+// populates, along with the conditionally populated identifiers that precede it
+func resolveConcurrencyIdentifier(identifiers []string, event string) (string, []string) {
+	var conditional []string
+	for _, identifier := range identifiers {
+		if !isConcurrencyIdentifierPopulated(identifier, event) {
+			continue
+		}
+		if _, ok := conditionalConcurrencyIdentifiers[identifier]; ok {
+			conditional = append(conditional, identifier)
+			continue
+		}
+		return identifier, conditional
+	}
+	return "", conditional
+}
+
+// isConcurrencyIdentifierPopulated reports whether event may populate identifier
+func isConcurrencyIdentifierPopulated(identifier string, event string) bool {
+	events, eventSpecific := concurrencyIdentifierEvents[identifier]
+	return !eventSpecific || slices.Contains(events, event)
+}
+
+// concurrencyKeyIdentifiers splits a "${{ a || b }}" concurrency key into its identifiers.
+// Literal keys yield no identifiers.
+func concurrencyKeyIdentifiers(key string) []string {
+	inner, isExpression := strings.CutPrefix(key, "${{")
+	if !isExpression {
+		return nil
+	}
+	inner = strings.TrimSpace(strings.TrimSuffix(inner, "}}"))
+
+	var identifiers []string
+	for alternative := range strings.SplitSeq(inner, "||") {
+		identifiers = append(identifiers, strings.TrimSpace(alternative))
+	}
+	return identifiers
+}
+
+// describeConcurrencyIdentifier describes a single identifier, falling back to the
+// identifier itself when it has no description
+func describeConcurrencyIdentifier(identifier string) string {
+	if description, ok := concurrencyIdentifierDescriptions[identifier]; ok {
+		return description
+	}
+	return identifier
+}
+
+// String renders the explanation as the group followed by one line per fragment
+func (e ConcurrencyGroupExplanation) String() string {
+	var sb strings.Builder
+	sb.WriteString(e.Group)
+	for _, fragment := range e.Fragments {
+		fmt.Fprintf(&sb, "\n  %s: %s", fragment.Key, fragment.Description)
+	}
+	return sb.String()
+}
+
+// describeConcurrencyKey describes a single concurrency group key. Expressions with
+// "||" fallbacks are described as the first identifier followed by its fallbacks.
+func describeConcurrencyKey(key string) string {
+	if key == "gh-aw" {
+		return "gh-aw prefix shared by all agentic workflows"
+	}
+
+	identifiers := concurrencyKeyIdentifiers(key)
+	if identifiers == nil {
+		return "literal value"
+	}
+
+	descriptions := make([]string, 0, len(identifiers))
+	for _, identifier := range identifiers {
+		descriptions = append(descriptions, describeConcurrencyIdentifier(identifier))
+	}
+
+	if len(descriptions) == 1 {
+		return descriptions[0]
+	}
+	description := descriptions[0] + ", falling back to " + descriptions[1]
+	for _, fallback := range descriptions[2:] {
+		description += ", then " + fallback
+	}
+	return description
+}
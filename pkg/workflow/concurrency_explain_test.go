@@ -0,0 +1,171 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExplainConcurrencyGroup(t *testing.T) {
+	tests := []struct {
+		name             string
+		workflowData     *WorkflowData
+		isCommandTrigger bool
+		want             []ConcurrencyGroupFragment
+	}{
+		{
+			name:         "schedule workflow",
+			workflowData: &WorkflowData{On: "on:\n  schedule:\n    - cron: \"0 * * * *\""},
+			want: []ConcurrencyGroupFragment{
+				{Key: "gh-aw", Description: "gh-aw prefix shared by all agentic workflows"},
+				{Key: "${{ github.workflow }}", Description: "workflow identity"},
+			},
+		},
+		{
+			name:         "pull request workflow",
+			workflowData: &WorkflowData{On: "on:\n  pull_request:\n    types: [opened]"},
+			want: []ConcurrencyGroupFragment{
+				{Key: "gh-aw", Description: "gh-aw prefix shared by all agentic workflows"},
+				{Key: "${{ github.workflow }}", Description: "workflow identity"},
+				{
+					Key:         "${{ github.event.pull_request.number || github.ref || github.run_id }}",
+					Description: "PR number, falling back to branch ref, then run ID (unique per run)",
+				},
+			},
+		},
+		{
+			name:         "push workflow",
+			workflowData: &WorkflowData{On: "on:\n  push:\n    branches: [main]"},
+			want: []ConcurrencyGroupFragment{
+				{Key: "gh-aw", Description: "gh-aw prefix shared by all agentic workflows"},
+				{Key: "${{ github.workflow }}", Description: "workflow identity"},
+				{Key: "${{ github.ref || github.run_id }}", Description: "branch ref, falling back to run ID (unique per run)"},
+			},
+		},
+		{
+			name:             "command workflow",
+			workflowData:     &WorkflowData{On: "on:\n  issue_comment:\n    types: [created]"},
+			isCommandTrigger: true,
+			want: []ConcurrencyGroupFragment{
+				{Key: "gh-aw", Description: "gh-aw prefix shared by all agentic workflows"},
+				{Key: "${{ github.workflow }}", Description: "workflow identity"},
+				{
+					Key:         "${{ github.event.issue.number || github.event.pull_request.number || github.run_id }}",
+					Description: "issue number, falling back to PR number, then run ID (unique per run)",
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			explanation := ExplainConcurrencyGroup(tt.workflowData, tt.isCommandTrigger)
+			assert.Equal(t, tt.want, explanation.Fragments, "fragments should be described")
+
+			keys := buildConcurrencyGroupKeys(tt.workflowData, tt.isCommandTrigger)
+			assert.Len(t, explanation.Fragments, len(keys), "every key should be explained")
+		})
+	}
+}
+
+func TestConcurrencyGroupExplanationString(t *testing.T) {
+	explanation := ExplainConcurrencyGroup(&WorkflowData{On: "on:\n  push:\n    branches: [main]"}, false)
+	assert.Equal(t, `gh-aw-${{ github.workflow }}-${{ github.ref || github.run_id }}
+  gh-aw: gh-aw prefix shared by all agentic workflows
+  ${{ github.workflow }}: workflow identity
+  ${{ github.ref || github.run_id }}: branch ref, falling back to run ID (unique per run)`, explanation.String(), "explanation should render one line per fragment")
+}
+
+func TestDescribeConcurrencyKeyUnknownIdentifier(t *testing.T) {
+	assert.Equal(t, "inputs.finding_id, falling back to run ID (unique per run)", describeConcurrencyKey("${{ inputs.finding_id || github.run_id }}"), "unknown identifiers should be shown as-is")
+	assert.Equal(t, "literal value", describeConcurrencyKey("custom"), "non-expression keys should be described as literals")
+}
+
+func TestExplainConcurrencyGroupPushAndPullRequest(t *testing.T) {
+	explanation := ExplainConcurrencyGroup(&WorkflowData{On: "on:\n  push:\n    branches: [main]\n  pull_request:\n    types: [opened]"}, false)
+	last := explanation.Fragments[len(explanation.Fragments)-1]
+	assert.Equal(t, "branch ref for push events, falling back to PR number, then branch ref, then run ID (unique per run)", last.Description, "event-type-aware key should be described")
+}
+
+func TestExplainConcurrencyKeyResolution(t *testing.T) {
+	tests := []struct {
+		name     string
+		on       string
+		expected []ConcurrencyEventResolution
+	}{
+		{
+			name: "issues and discussions resolve to their own numbers",
+			on:   "on:\n  issues:\n    types: [opened]\n  discussion:\n    types: [created]",
+			expected: []ConcurrencyEventResolution{
+				{Event: "discussion", Identifier: "github.event.discussion.number", Description: "discussion number"},
+				{Event: "issues", Identifier: "github.event.issue.number", Description: "issue number"},
+			},
+		},
+		{
+			name: "push and pull request",
+			on:   "on:\n  push:\n    branches: [main]\n  pull_request:\n    types: [opened]",
+			expected: []ConcurrencyEventResolution{
+				{Event: "pull_request", Identifier: "github.event.pull_request.number", Description: "PR number"},
+				{Event: "push", Identifier: pushEventRefCondition, Description: "branch ref for push events"},
+			},
+		},
+		{
+			name: "deployment status resolves to the environment",
+			on:   "on:\n  deployment_status:",
+			expected: []ConcurrencyEventResolution{
+				{Event: "deployment_status", Identifier: "github.event.deployment.environment", Description: "deployment environment"},
+			},
+		},
+		{
+			name:     "no trigger-based key",
+			on:       "on:\n  schedule:\n    - cron: '0 9 * * 1'",
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolutions := ExplainConcurrencyKeyResolution(&WorkflowData{On: tt.on}, false)
+			assert.Equal(t, tt.expected, resolutions, "each event should resolve to its own identifier")
+		})
+	}
+}
+
+func TestExplainConcurrencyKeyResolutionConditionalIdentifier(t *testing.T) {
+	workflowData := &WorkflowData{
+		On:             "on:\n  pull_request:\n    types: [labeled]",
+		ConcurrencyKey: "${{ github.event.label.name || github.event.pull_request.number || github.run_id }}",
+	}
+	resolutions := ExplainConcurrencyKeyResolution(workflowData, false)
+	require.Len(t, resolutions, 1, "single trigger should yield one resolution")
+	assert.Equal(t, "github.event.pull_request.number", resolutions[0].Identifier, "label name is only conditionally populated")
+	assert.Equal(t, "label name for labeled or unlabeled activity, otherwise PR number", resolutions[0].Description, "conditional identifier should be described")
+}
+
+func TestExplainConcurrencyKeyResolutionForkIsolation(t *testing.T) {
+	workflowData := &WorkflowData{
+		On:                       "on:\n  pull_request:\n    types: [opened]",
+		ConcurrencyForkIsolation: true,
+	}
+	resolutions := ExplainConcurrencyKeyResolution(workflowData, false)
+	require.Len(t, resolutions, 1, "single trigger should yield one resolution")
+	assert.Equal(t, "github.event.pull_request.number", resolutions[0].Identifier, "resolution should use the trigger-based key, not the fork isolation key")
+
+	explanation := ExplainConcurrencyGroup(workflowData, false)
+	last := explanation.Fragments[len(explanation.Fragments)-1]
+	assert.Equal(t, "PR head repository, falling back to repository", last.Description, "fork isolation key should be described")
+}
+
+func TestExplainConcurrencyKeyResolutionPullRequestTarget(t *testing.T) {
+	workflowData := &WorkflowData{
+		On: "on:\n  pull_request:\n    types: [opened]\n  pull_request_target:\n    types: [opened]",
+	}
+	resolutions := ExplainConcurrencyKeyResolution(workflowData, false)
+	require.Len(t, resolutions, 2, "each trigger should yield a resolution")
+	assert.Equal(t, ConcurrencyEventResolution{Event: "pull_request", Identifier: "github.event.pull_request.number", Description: "PR number"}, resolutions[0], "regular PR events should use the PR number")
+	assert.Equal(t, ConcurrencyEventResolution{Event: "pull_request_target", Identifier: pullRequestTargetNodeIDCondition, Description: "PR node ID for pull_request_target events"}, resolutions[1], "target events should use the PR node ID")
+	require.NoError(t, validateConcurrencyKeyFallbacks(workflowData, false), "each event should resolve to its own identifier")
+}
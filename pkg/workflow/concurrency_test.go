@@ -5,10 +5,13 @@ package workflow
 import (
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
 
 	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestConcurrencyRules(t *testing.T) {
@@ -179,6 +182,337 @@ This is a test workflow for concurrency behavior.
 	}
 }
 
+func TestConcurrencyGroupByLabelCompile(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "concurrency-group-by-test")
+	compiler := NewCompiler()
+
+	t.Run("label-triggered issue workflow is grouped by label name", func(t *testing.T) {
+		testContent := `---
+on:
+  issues:
+    types: [labeled]
+concurrency:
+  group-by: label
+tools:
+  github:
+    allowed: [list_issues]
+---
+
+# Label Grouped Workflow
+`
+		testFile := filepath.Join(tmpDir, "label-grouped.md")
+		if err := os.WriteFile(testFile, []byte(testContent), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := compiler.CompileWorkflow(testFile); err != nil {
+			t.Fatalf("Expected compilation to succeed, got: %v", err)
+		}
+
+		lockContent, err := os.ReadFile(filepath.Join(tmpDir, "label-grouped.lock.yml"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		lock := string(lockContent)
+		expectedGroup := `group: "gh-aw-${{ github.workflow }}-${{ github.event.label.name || github.event.issue.number || github.run_id }}"`
+		if !strings.Contains(lock, expectedGroup) {
+			t.Errorf("Expected lock file to contain %s", expectedGroup)
+		}
+		if strings.Contains(lock, "group-by") {
+			t.Error("Expected group-by to be stripped from the lock file")
+		}
+	})
+
+	t.Run("group-by label without label trigger fails", func(t *testing.T) {
+		testContent := `---
+on:
+  issues:
+    types: [opened]
+concurrency:
+  group-by: label
+tools:
+  github:
+    allowed: [list_issues]
+---
+
+# Invalid Label Grouped Workflow
+`
+		testFile := filepath.Join(tmpDir, "invalid-label-grouped.md")
+		if err := os.WriteFile(testFile, []byte(testContent), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		err := compiler.CompileWorkflow(testFile)
+		if err == nil {
+			t.Fatal("Expected compilation to fail for group-by label without a label trigger")
+		}
+		if !strings.Contains(err.Error(), "concurrency.group-by") {
+			t.Errorf("Expected error to mention concurrency.group-by, got: %v", err)
+		}
+	})
+}
+
+func TestConcurrencyGroupByScheduleCompile(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "concurrency-group-by-schedule-test")
+	compiler := NewCompiler()
+
+	testContent := `---
+on:
+  schedule:
+    - cron: "0 9 * * *"
+    - cron: "0 21 * * *"
+concurrency:
+  group-by: schedule
+permissions:
+  contents: read
+engine: copilot
+---
+
+# Schedule Grouped Workflow
+`
+	testFile := filepath.Join(tmpDir, "schedule-grouped.md")
+	require.NoError(t, os.WriteFile(testFile, []byte(testContent), 0644), "should write test workflow")
+	require.NoError(t, compiler.CompileWorkflow(testFile), "schedule-grouped workflow should compile")
+
+	lockContent, err := os.ReadFile(filepath.Join(tmpDir, "schedule-grouped.lock.yml"))
+	require.NoError(t, err, "should read lock file")
+	lock := string(lockContent)
+
+	assert.Contains(t, lock, `group: "gh-aw-${{ github.workflow }}-${{ github.event.schedule || github.event_name }}"`,
+		"workflow-level group should be keyed per cron schedule")
+	assert.Contains(t, lock, `group: "gh-aw-copilot-${{ github.workflow }}-${{ github.event.schedule || github.event_name }}"`,
+		"agent job group should be keyed per cron schedule")
+	assert.Contains(t, lock, scheduleConcurrencyComment, "lock file should explain the per-schedule grouping")
+	assert.NotContains(t, lock, "\n  group-by:", "group-by should be stripped from the lock file")
+}
+
+func TestConcurrencyMatrixCompile(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "concurrency-matrix-test")
+	compiler := NewCompiler()
+
+	testContent := `---
+on:
+  pull_request:
+    types: [opened, synchronize]
+concurrency:
+  matrix: true
+permissions:
+  contents: read
+  pull-requests: read
+engine: copilot
+jobs:
+  test:
+    runs-on: ubuntu-latest
+    strategy:
+      matrix:
+        os: [ubuntu-latest, windows-latest]
+    steps:
+      - run: echo test
+  lint:
+    runs-on: ubuntu-latest
+    strategy:
+      matrix:
+        go: ["1.24", "1.25"]
+    concurrency:
+      group: lint-${{ matrix.go }}
+    steps:
+      - run: echo lint
+---
+
+# Matrix Workflow
+`
+	testFile := filepath.Join(tmpDir, "matrix.md")
+	require.NoError(t, os.WriteFile(testFile, []byte(testContent), 0644), "should write test workflow")
+	require.NoError(t, compiler.CompileWorkflow(testFile), "matrix workflow should compile")
+
+	lockContent, err := os.ReadFile(filepath.Join(tmpDir, "matrix.lock.yml"))
+	require.NoError(t, err, "should read lock file")
+	lock := string(lockContent)
+
+	assert.Contains(t, lock, `group: "gh-aw-${{ github.workflow }}-${{ github.event.pull_request.number || github.ref || github.run_id }}-test-${{ strategy.job-index }}"
+      cancel-in-progress: true`, "matrix job should get a per-leg group that follows the workflow cancellation")
+	assert.Contains(t, lock, "group: lint-${{ matrix.go }}", "job-level concurrency should be kept")
+	assert.NotContains(t, lock, "-lint-${{ strategy.job-index }}", "jobs with their own concurrency should not get a generated group")
+	assert.NotContains(t, lock, "\n  matrix: true", "matrix should be stripped from the lock file")
+}
+
+func TestGenerateMatrixJobConcurrencyConfig(t *testing.T) {
+	tests := []struct {
+		name         string
+		workflowData *WorkflowData
+		expected     string
+	}{
+		{
+			name:         "schedule workflow does not cancel",
+			workflowData: &WorkflowData{On: "on:\n  schedule:\n    - cron: '0 9 * * 1'"},
+			expected:     "concurrency:\n  group: \"gh-aw-${{ github.workflow }}-build-${{ strategy.job-index }}\"\n  cancel-in-progress: false",
+		},
+		{
+			name:         "cancel-in-progress override is used",
+			workflowData: &WorkflowData{On: "on:\n  push:\n    branches: [main]", ConcurrencyCancelInProgress: "true"},
+			expected:     "concurrency:\n  group: \"gh-aw-${{ github.workflow }}-${{ github.ref || github.run_id }}-build-${{ strategy.job-index }}\"\n  cancel-in-progress: true",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, generateMatrixJobConcurrencyConfig(tt.workflowData, false, "build"), "matrix job concurrency should extend the workflow group")
+		})
+	}
+}
+
+func TestConcurrencyGroupLengthCompile(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "concurrency-group-length-test")
+	workflowContent := func(concurrency string) string {
+		return `---
+name: ` + strings.Repeat("Long workflow name ", 12) + `
+on:
+  issues:
+    types: [opened]
+permissions:
+  contents: read
+  issues: read
+engine: copilot
+` + concurrency + `---
+
+# Long Named Workflow
+`
+	}
+
+	t.Run("long generated group warns", func(t *testing.T) {
+		testFile := filepath.Join(tmpDir, "long-warn.md")
+		require.NoError(t, os.WriteFile(testFile, []byte(workflowContent("")), 0644), "should write workflow")
+
+		compiler := NewCompiler()
+		require.NoError(t, compiler.CompileWorkflow(testFile), "long groups should only warn outside strict mode")
+		var messages []string
+		for _, warning := range compiler.Warnings() {
+			messages = append(messages, warning.Message)
+		}
+		assert.Contains(t, strings.Join(messages, "\n"), "255 character limit", "compiler should warn about the group length")
+	})
+
+	t.Run("long generated group fails in strict mode", func(t *testing.T) {
+		testFile := filepath.Join(tmpDir, "long-strict.md")
+		require.NoError(t, os.WriteFile(testFile, []byte(workflowContent("")), 0644), "should write workflow")
+
+		compiler := NewCompiler()
+		compiler.SetStrictMode(true)
+		err := compiler.CompileWorkflow(testFile)
+		require.Error(t, err, "long groups should fail in strict mode")
+		assert.Contains(t, err.Error(), "255 character limit", "error should mention the limit")
+	})
+
+	t.Run("hash-long-group shortens the generated group", func(t *testing.T) {
+		testFile := filepath.Join(tmpDir, "long-hashed.md")
+		require.NoError(t, os.WriteFile(testFile, []byte(workflowContent("concurrency:\n  hash-long-group: true\n")), 0644), "should write workflow")
+
+		compiler := NewCompiler()
+		compiler.SetStrictMode(true)
+		require.NoError(t, compiler.CompileWorkflow(testFile), "shortened group should compile in strict mode")
+
+		lockContent, err := os.ReadFile(filepath.Join(tmpDir, "long-hashed.lock.yml"))
+		require.NoError(t, err, "lock file should be written")
+		lock := string(lockContent)
+		assert.Contains(t, lock, `group: "gh-aw-Long-workflow-name-`, "group should start with the truncated workflow name")
+		assert.NotContains(t, lock, "hash-long-group", "hash-long-group should be stripped from the lock file")
+	})
+
+	t.Run("hash-prefix replaces the static prefix", func(t *testing.T) {
+		testFile := filepath.Join(tmpDir, "long-prefix.md")
+		require.NoError(t, os.WriteFile(testFile, []byte(workflowContent("concurrency:\n  hash-prefix: true\n")), 0644), "should write workflow")
+
+		compiler := NewCompiler()
+		compiler.SetStrictMode(true)
+		require.NoError(t, compiler.CompileWorkflow(testFile), "hashed prefix should compile in strict mode")
+
+		lockContent, err := os.ReadFile(filepath.Join(tmpDir, "long-prefix.lock.yml"))
+		require.NoError(t, err, "lock file should be written")
+		lock := string(lockContent)
+		assert.Regexp(t, `group: "gh-aw-[0-9a-f]{8}-\$\{\{ github\.event\.issue\.number \|\| github\.run_id \}\}"`, lock, "group should be the hashed prefix followed by the issue key")
+		assert.NotContains(t, lock, "hash-prefix", "hash-prefix should be stripped from the lock file")
+	})
+}
+
+func TestConcurrencyKeyCompile(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "concurrency-key-test")
+	workflowContent := func(key string) string {
+		return `---
+on:
+  repository_dispatch:
+    types: [deploy]
+permissions:
+  contents: read
+engine: copilot
+concurrency:
+  key: "` + key + `"
+---
+
+# Custom Payload Workflow
+`
+	}
+
+	t.Run("custom key groups by the event payload", func(t *testing.T) {
+		testFile := filepath.Join(tmpDir, "custom-key.md")
+		require.NoError(t, os.WriteFile(testFile, []byte(workflowContent("github.event.client_payload.id")), 0644), "should write workflow")
+
+		compiler := NewCompiler()
+		require.NoError(t, compiler.CompileWorkflow(testFile), "workflow with a custom key should compile")
+
+		lockContent, err := os.ReadFile(filepath.Join(tmpDir, "custom-key.lock.yml"))
+		require.NoError(t, err, "lock file should be written")
+		lock := string(lockContent)
+		assert.Contains(t, lock, `group: "gh-aw-${{ github.workflow }}-${{ github.event.client_payload.id }}"`, "group should be keyed on the payload")
+		assert.NotContains(t, lock, "key: github.event.client_payload.id", "concurrency.key should be stripped from the lock file")
+		assert.NotContains(t, lock, "gh-aw-copilot-${{ github.workflow }}", "default agent job concurrency should not be applied")
+	})
+
+	t.Run("unbalanced key expression fails", func(t *testing.T) {
+		testFile := filepath.Join(tmpDir, "invalid-key.md")
+		require.NoError(t, os.WriteFile(testFile, []byte(workflowContent("${{ github.event.client_payload.id")), 0644), "should write workflow")
+
+		err := NewCompiler().CompileWorkflow(testFile)
+		require.Error(t, err, "unbalanced key expression should fail")
+		assert.Contains(t, err.Error(), "concurrency.key", "error should reference concurrency.key")
+	})
+}
+
+func TestConcurrencyCancelInProgressCompile(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "concurrency-cancel-test")
+	compiler := NewCompiler()
+
+	testContent := `---
+on:
+  schedule:
+    - cron: "0 * * * *"
+concurrency:
+  cancel-in-progress: ${{ github.event_name == 'schedule' }}
+tools:
+  github:
+    allowed: [list_issues]
+---
+
+# Cancelling Scheduled Workflow
+`
+	testFile := filepath.Join(tmpDir, "cancel-scheduled.md")
+	if err := os.WriteFile(testFile, []byte(testContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := compiler.CompileWorkflow(testFile); err != nil {
+		t.Fatalf("Expected compilation to succeed, got: %v", err)
+	}
+
+	lockContent, err := os.ReadFile(filepath.Join(tmpDir, "cancel-scheduled.lock.yml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "concurrency:\n  group: \"gh-aw-${{ github.workflow }}\"\n  cancel-in-progress: ${{ github.event_name == 'schedule' }}"
+	if !strings.Contains(string(lockContent), expected) {
+		t.Errorf("Expected lock file to contain generated group with cancel-in-progress override:\n%s", expected)
+	}
+}
+
 func TestGenerateConcurrencyConfig(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -351,6 +685,48 @@ func TestGenerateConcurrencyConfig(t *testing.T) {
   group: "gh-aw-${{ github.workflow }}-${{ github.event.issue.number || github.run_id }}"`,
 			description: "Rendered slash_command YAML (issue_comment + workflow_dispatch) uses issue number via isIssueWorkflow",
 		},
+		{
+			name: "Schedule workflow with cancel-in-progress override should cancel",
+			workflowData: &WorkflowData{
+				On: `on:
+  schedule:
+    - cron: "0 * * * *"`,
+				ConcurrencyCancelInProgress: "true",
+			},
+			isAliasTrigger: false,
+			expected: `concurrency:
+  group: "gh-aw-${{ github.workflow }}"
+  cancel-in-progress: true`,
+			description: "cancel-in-progress override should enable cancellation for non-PR workflows",
+		},
+		{
+			name: "PR workflow with cancel-in-progress false override should not cancel",
+			workflowData: &WorkflowData{
+				On: `on:
+  pull_request:
+    types: [opened, synchronize]`,
+				ConcurrencyCancelInProgress: "false",
+			},
+			isAliasTrigger: false,
+			expected: `concurrency:
+  group: "gh-aw-${{ github.workflow }}-${{ github.event.pull_request.number || github.ref || github.run_id }}"
+  cancel-in-progress: false`,
+			description: "cancel-in-progress override should disable the pull request default",
+		},
+		{
+			name: "Command workflow with cancel-in-progress expression override",
+			workflowData: &WorkflowData{
+				On: `on:
+  issues:
+    types: [opened, edited, reopened]`,
+				ConcurrencyCancelInProgress: "${{ github.event_name == 'issues' }}",
+			},
+			isAliasTrigger: true,
+			expected: `concurrency:
+  group: "gh-aw-${{ github.workflow }}-${{ github.event.issue.number || github.event.pull_request.number || github.run_id }}"
+  cancel-in-progress: ${{ github.event_name == 'issues' }}`,
+			description: "cancel-in-progress expression should be emitted verbatim",
+		},
 	}
 
 	for _, tt := range tests {
@@ -446,6 +822,18 @@ func TestGenerateJobConcurrencyConfig(t *testing.T) {
   group: "gh-aw-codex-${{ github.workflow }}"`,
 			description: "Codex with schedule should get default concurrency",
 		},
+		{
+			name: "Schedule workflow grouped by schedule keys on the cron expression",
+			workflowData: &WorkflowData{
+				On:                 "on:\n  schedule:\n    - cron: '0 0 * * *'\n    - cron: '0 12 * * *'",
+				EngineConfig:       &EngineConfig{ID: "codex"},
+				ConcurrencyGroupBy: "schedule",
+			},
+			expected: `concurrency:
+  # Grouped per cron schedule (concurrency.group-by: schedule): runs from different schedules do not wait for each other
+  group: "gh-aw-codex-${{ github.workflow }}-${{ github.event.schedule || github.event_name }}"`,
+			description: "group-by schedule should give each cron expression its own job-level group",
+		},
 		{
 			name: "Default concurrency for workflow_dispatch combined with schedule",
 			workflowData: &WorkflowData{
@@ -575,6 +963,189 @@ func TestGenerateJobConcurrencyConfig(t *testing.T) {
 	}
 }
 
+func TestGenerateJobConcurrencyConfigEngineDefault(t *testing.T) {
+	templated := NewClaudeEngine()
+	templated.defaultConcurrency = "gh-aw-{engine}-pool-${{ github.workflow }}"
+	optedOut := NewCodexEngine()
+	optedOut.defaultConcurrency = engineDefaultConcurrencyNone
+
+	registry := NewEngineRegistry()
+	registry.Register(templated)
+	registry.Register(optedOut)
+
+	tests := []struct {
+		name         string
+		workflowData *WorkflowData
+		expected     string
+	}{
+		{
+			name: "engine template replaces generic group",
+			workflowData: &WorkflowData{
+				On:           "on:\n  schedule:\n    - cron: '0 9 * * 1'",
+				EngineConfig: &EngineConfig{ID: "claude"},
+			},
+			expected: "concurrency:\n  group: \"gh-aw-claude-pool-${{ github.workflow }}\"",
+		},
+		{
+			name: "engine template keeps job discriminator",
+			workflowData: &WorkflowData{
+				On:                          "on:\n  schedule:\n    - cron: '0 9 * * 1'",
+				EngineConfig:                &EngineConfig{ID: "claude"},
+				ConcurrencyJobDiscriminator: "${{ inputs.id }}",
+			},
+			expected: "concurrency:\n  group: \"gh-aw-claude-pool-${{ github.workflow }}-${{ inputs.id }}\"",
+		},
+		{
+			name: "engine opts out of default grouping",
+			workflowData: &WorkflowData{
+				On:           "on:\n  schedule:\n    - cron: '0 9 * * 1'",
+				EngineConfig: &EngineConfig{ID: "codex"},
+			},
+			expected: "",
+		},
+		{
+			name: "engine without template uses generic group",
+			workflowData: &WorkflowData{
+				On:           "on:\n  schedule:\n    - cron: '0 9 * * 1'",
+				EngineConfig: &EngineConfig{ID: "copilot"},
+			},
+			expected: "concurrency:\n  group: \"gh-aw-copilot-${{ github.workflow }}\"",
+		},
+		{
+			name: "explicit engine concurrency takes precedence over template",
+			workflowData: &WorkflowData{
+				On:           "on:\n  schedule:\n    - cron: '0 9 * * 1'",
+				EngineConfig: &EngineConfig{ID: "codex", Concurrency: "concurrency:\n  group: \"custom\""},
+			},
+			expected: "concurrency:\n  group: \"custom\"",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := generateJobConcurrencyConfig(tt.workflowData, registry)
+			if result != tt.expected {
+				t.Errorf("generateJobConcurrencyConfig() mismatch\nExpected:\n%s\nGot:\n%s", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestSanitizeConcurrencyGroup(t *testing.T) {
+	tests := []struct {
+		name     string
+		group    string
+		expected string
+	}{
+		{
+			name:     "valid group is unchanged",
+			group:    "gh-aw-${{ github.workflow }}-${{ github.event.issue.number || github.run_id }}",
+			expected: "gh-aw-${{ github.workflow }}-${{ github.event.issue.number || github.run_id }}",
+		},
+		{
+			name:     "slashes are replaced",
+			group:    "gh-aw-deploy/prod/eu",
+			expected: "gh-aw-deploy-prod-eu",
+		},
+		{
+			name:     "colons and spaces collapse to one dash",
+			group:    "gh-aw-Release: Nightly",
+			expected: "gh-aw-Release-Nightly",
+		},
+		{
+			name:     "unicode is replaced",
+			group:    "gh-aw-Überprüfung ✨ täglich",
+			expected: "gh-aw--berpr-fung-t-glich",
+		},
+		{
+			name:     "expression segments are left alone",
+			group:    "deploy: ${{ github.event.client_payload['env/name'] }}/${{ github.run_id }}",
+			expected: "deploy-${{ github.event.client_payload['env/name'] }}-${{ github.run_id }}",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sanitized := sanitizeConcurrencyGroup(tt.group)
+			assert.Equal(t, tt.expected, sanitized, "static segments should be sanitized")
+			assert.Equal(t, sanitized, sanitizeConcurrencyGroup(sanitized), "sanitizing should be idempotent")
+		})
+	}
+}
+
+func TestGenerateConcurrencyConfigSanitizesWorkflowNames(t *testing.T) {
+	tests := []struct {
+		name         string
+		workflowName string
+	}{
+		{name: "slashes", workflowName: "Deploy/Prod " + strings.Repeat("pipeline/stage ", 16)},
+		{name: "colons", workflowName: "Release: " + strings.Repeat("nightly:build ", 20)},
+		{name: "unicode", workflowName: "Überprüfung ✨ " + strings.Repeat("täglicher Bericht ", 16)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			workflowData := &WorkflowData{
+				Name:                     tt.workflowName,
+				On:                       "on:\n  issues:\n    types: [opened]",
+				ConcurrencyHashLongGroup: true,
+			}
+			config := GenerateConcurrencyConfig(workflowData, false)
+			group := extractConcurrencyGroupFromYAML(config)
+
+			assert.NotContains(t, group, "github.workflow", "long workflow name should be embedded as a shortened literal")
+			static := concurrencyExpressionPattern.ReplaceAllString(group, "")
+			assert.Regexp(t, `^[A-Za-z0-9_.-]+$`, static, "static segments should only contain allowed characters")
+			assert.Equal(t, config, GenerateConcurrencyConfig(workflowData, false), "generated group should be stable")
+			assert.NoError(t, validateConcurrencyGroupExpression(group), "generated group should be a valid expression")
+		})
+	}
+}
+
+func TestFormatConcurrencyGroupValue(t *testing.T) {
+	tests := []struct {
+		name     string
+		group    string
+		expected string
+	}{
+		{name: "simple identifier is unquoted", group: "gh-aw-copilot", expected: "gh-aw-copilot"},
+		{name: "dots slashes and underscores are unquoted", group: "deploy/prod_v1.2", expected: "deploy/prod_v1.2"},
+		{name: "expression stays quoted", group: "gh-aw-${{ github.workflow }}", expected: "\"gh-aw-${{ github.workflow }}\""},
+		{name: "colon stays quoted", group: "group:a", expected: "\"group:a\""},
+		{name: "space stays quoted", group: "my group", expected: "\"my group\""},
+		{name: "hash stays quoted", group: "group#1", expected: "\"group#1\""},
+		{name: "leading digit stays quoted", group: "123", expected: "\"123\""},
+		{name: "leading dash stays quoted", group: "-group", expected: "\"-group\""},
+		{name: "boolean-like word stays quoted", group: "Yes", expected: "\"Yes\""},
+		{name: "null stays quoted", group: "null", expected: "\"null\""},
+		{name: "empty stays quoted", group: "", expected: "\"\""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatConcurrencyGroupValue(tt.group); got != tt.expected {
+				t.Errorf("formatConcurrencyGroupValue(%q) = %s, want %s", tt.group, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGenerateJobConcurrencyConfigUnquotedGroup(t *testing.T) {
+	pooled := NewClaudeEngine()
+	pooled.defaultConcurrency = "gh-aw-{engine}"
+	registry := NewEngineRegistry()
+	registry.Register(pooled)
+
+	workflowData := &WorkflowData{
+		On:           "on:\n  schedule:\n    - cron: '0 9 * * 1'",
+		EngineConfig: &EngineConfig{ID: "claude"},
+	}
+	expected := "concurrency:\n  group: gh-aw-claude"
+	if got := generateJobConcurrencyConfig(workflowData, registry); got != expected {
+		t.Errorf("generateJobConcurrencyConfig() = %q, want %q", got, expected)
+	}
+}
+
 func TestIsPullRequestWorkflow(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -622,7 +1193,7 @@ func TestIsPullRequestWorkflow(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := isPullRequestWorkflow(tt.on)
+			result := isPullRequestWorkflow(workflowTriggers(tt.on))
 			if result != tt.expected {
 				t.Errorf("isPullRequestWorkflow() for %s = %v, expected %v", tt.name, result, tt.expected)
 			}
@@ -686,7 +1257,7 @@ func TestIsIssueWorkflow(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := isIssueWorkflow(tt.on)
+			result := isIssueWorkflow(workflowTriggers(tt.on))
 			if result != tt.expected {
 				t.Errorf("isIssueWorkflow() for %s = %v, expected %v", tt.name, result, tt.expected)
 			}
@@ -734,7 +1305,7 @@ func TestIsPushWorkflow(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := isPushWorkflow(tt.on)
+			result := isPushWorkflow(workflowTriggers(tt.on))
 			if result != tt.expected {
 				t.Errorf("isPushWorkflow() for %s = %v, expected %v", tt.name, result, tt.expected)
 			}
@@ -782,7 +1353,7 @@ func TestIsDiscussionWorkflow(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := isDiscussionWorkflow(tt.on)
+			result := isDiscussionWorkflow(workflowTriggers(tt.on))
 			if result != tt.expected {
 				t.Errorf("isDiscussionWorkflow() for %s = %v, expected %v", tt.name, result, tt.expected)
 			}
@@ -790,6 +1361,13 @@ func TestIsDiscussionWorkflow(t *testing.T) {
 	}
 }
 
+func TestIsDiscussionCommentWorkflow(t *testing.T) {
+	assert.True(t, isDiscussionCommentWorkflow(workflowTriggers("on:\n  discussion_comment:\n    types: [created]")), "discussion_comment trigger should be detected")
+	assert.True(t, isDiscussionCommentWorkflow(workflowTriggers("on: [discussion, discussion_comment]")), "inline discussion_comment trigger should be detected")
+	assert.False(t, isDiscussionCommentWorkflow(workflowTriggers("on:\n  discussion:\n    types: [created]")), "discussion trigger should not be detected as discussion_comment")
+	assert.False(t, isDiscussionCommentWorkflow(workflowTriggers("on:\n  issue_comment:\n    types: [created]")), "issue_comment trigger should not be detected as discussion_comment")
+}
+
 func TestBuildConcurrencyGroupKeys(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -868,6 +1446,30 @@ func TestBuildConcurrencyGroupKeys(t *testing.T) {
 			expected:       []string{"gh-aw", "${{ github.workflow }}", "${{ github.event.issue.number || github.event.discussion.number || github.run_id }}"},
 			description:    "Mixed issue and discussion workflows should use issue/discussion number",
 		},
+		{
+			name: "Discussion comment workflow should include discussion number",
+			workflowData: &WorkflowData{
+				On: `on:
+  discussion_comment:
+    types: [created]`,
+			},
+			isAliasTrigger: false,
+			expected:       []string{"gh-aw", "${{ github.workflow }}", "${{ github.event.discussion.number || github.run_id }}"},
+			description:    "Discussion comment workflows should group comments by their parent discussion number",
+		},
+		{
+			name: "Mixed issue comment and discussion comment workflow should include issue/discussion number",
+			workflowData: &WorkflowData{
+				On: `on:
+  issue_comment:
+    types: [created]
+  discussion_comment:
+    types: [created]`,
+			},
+			isAliasTrigger: false,
+			expected:       []string{"gh-aw", "${{ github.workflow }}", "${{ github.event.issue.number || github.event.discussion.number || github.run_id }}"},
+			description:    "Mixed issue and discussion comment workflows should use the mixed issue/discussion key",
+		},
 		{
 			name: "Push workflow should include github.ref",
 			workflowData: &WorkflowData{
@@ -880,7 +1482,7 @@ func TestBuildConcurrencyGroupKeys(t *testing.T) {
 			description:    "Push workflows should use github.ref",
 		},
 		{
-			name: "Mixed push and PR workflow should use PR logic (PR takes priority)",
+			name: "Mixed push and PR workflow should key push events on ref and PR events on PR number",
 			workflowData: &WorkflowData{
 				On: `on:
   push:
@@ -889,14 +1491,188 @@ func TestBuildConcurrencyGroupKeys(t *testing.T) {
     types: [opened, synchronize]`,
 			},
 			isAliasTrigger: false,
-			expected:       []string{"gh-aw", "${{ github.workflow }}", "${{ github.event.pull_request.number || github.ref || github.run_id }}"},
-			description:    "Mixed push+PR workflows should use PR logic since PR is checked first",
+			expected:       []string{"gh-aw", "${{ github.workflow }}", "${{ github.event_name == 'push' && github.ref || github.event.pull_request.number || github.ref || github.run_id }}"},
+			description:    "Mixed push+PR workflows should use an event-type-aware key",
 		},
 		{
-			name: "Other workflow should not include additional keys",
+			name: "workflow_run workflow should key on the triggering run",
 			workflowData: &WorkflowData{
 				On: `on:
-  schedule:
+  workflow_run:
+    workflows: ["CI"]
+    types: [completed]`,
+			},
+			isAliasTrigger: false,
+			expected:       []string{"gh-aw", "${{ github.workflow }}", "${{ github.event.workflow_run.id || github.run_id }}"},
+			description:    "workflow_run workflows should use the triggering run ID",
+		},
+		{
+			name: "merge_group workflow should key on the merge group commit",
+			workflowData: &WorkflowData{
+				On: `on:
+  merge_group:
+    types: [checks_requested]`,
+			},
+			isAliasTrigger: false,
+			expected:       []string{"gh-aw", "${{ github.workflow }}", "${{ github.event.merge_group.head_sha || github.run_id }}"},
+			description:    "merge_group workflows should use the merge group head SHA",
+		},
+		{
+			name: "Mixed PR and merge_group workflow should key queue runs on the merge group commit",
+			workflowData: &WorkflowData{
+				On: `on:
+  pull_request:
+    types: [opened, synchronize]
+  merge_group:`,
+			},
+			isAliasTrigger: false,
+			expected:       []string{"gh-aw", "${{ github.workflow }}", "${{ github.event.merge_group.head_sha || github.event.pull_request.number || github.ref || github.run_id }}"},
+			description:    "Mixed PR+merge_group workflows should key merge queue runs on the head SHA",
+		},
+		{
+			name: "Release workflow should key on the release tag",
+			workflowData: &WorkflowData{
+				On: `on:
+  release:
+    types: [published]`,
+			},
+			isAliasTrigger: false,
+			expected:       []string{"gh-aw", "${{ github.workflow }}", "${{ github.event.release.tag_name || github.ref }}"},
+			description:    "Release workflows should use a tag-scoped group",
+		},
+		{
+			name: "Repository dispatch workflow should key on the event type",
+			workflowData: &WorkflowData{
+				On: `on:
+  repository_dispatch:
+    types: [deploy, rollback]`,
+			},
+			isAliasTrigger: false,
+			expected:       []string{"gh-aw", "${{ github.workflow }}", "${{ github.event.action || github.run_id }}"},
+			description:    "Repository dispatch workflows should use an event-type-scoped group",
+		},
+		{
+			name: "Fork isolation adds the PR head repository",
+			workflowData: &WorkflowData{
+				On: `on:
+  pull_request:
+    types: [opened, synchronize]`,
+				ConcurrencyForkIsolation: true,
+			},
+			isAliasTrigger: false,
+			expected: []string{
+				"gh-aw", "${{ github.workflow }}",
+				"${{ github.event.pull_request.number || github.ref || github.run_id }}",
+				"${{ github.event.pull_request.head.repo.full_name || github.repository }}",
+			},
+			description: "Fork and internal PR runs should never share a group",
+		},
+		{
+			name: "pull_request_target-only workflow should key on the PR number",
+			workflowData: &WorkflowData{
+				On: `on:
+  pull_request_target:
+    types: [opened, synchronize]`,
+			},
+			isAliasTrigger: false,
+			expected:       []string{"gh-aw", "${{ github.workflow }}", "${{ github.event.pull_request.number || github.ref || github.run_id }}"},
+			description:    "pull_request_target payloads carry the PR, so no event condition is needed",
+		},
+		{
+			name: "Mixed pull_request and pull_request_target workflow should key target runs apart",
+			workflowData: &WorkflowData{
+				On: `on:
+  pull_request:
+    types: [opened, synchronize]
+  pull_request_target:
+    types: [labeled]`,
+			},
+			isAliasTrigger: false,
+			expected: []string{
+				"gh-aw", "${{ github.workflow }}",
+				"${{ github.event_name == 'pull_request_target' && github.event.pull_request.node_id || github.event.pull_request.number || github.ref || github.run_id }}",
+			},
+			description: "Target and regular runs for the same PR should not share a group",
+		},
+		{
+			name: "Mixed issue, pull_request and pull_request_target workflow should key target runs apart",
+			workflowData: &WorkflowData{
+				On: `on:
+  issues:
+    types: [opened]
+  pull_request_review:
+  pull_request_target:
+    types: [opened]`,
+			},
+			isAliasTrigger: false,
+			expected: []string{
+				"gh-aw", "${{ github.workflow }}",
+				"${{ github.event.issue.number || github.event_name == 'pull_request_target' && github.event.pull_request.node_id || github.event.pull_request.number || github.run_id }}",
+			},
+			description: "Mixed-entity keys should split target runs the same way",
+		},
+		{
+			name: "Fork isolation has no effect on non-PR workflows",
+			workflowData: &WorkflowData{
+				On: `on:
+  issues:
+    types: [opened]`,
+				ConcurrencyForkIsolation: true,
+			},
+			isAliasTrigger: false,
+			expected:       []string{"gh-aw", "${{ github.workflow }}", "${{ github.event.issue.number || github.run_id }}"},
+			description:    "Only the pull request branch honors fork isolation",
+		},
+		{
+			name: "Deployment workflow should key on the environment",
+			workflowData: &WorkflowData{
+				On: `on:
+  deployment:`,
+			},
+			isAliasTrigger: false,
+			expected:       []string{"gh-aw", "${{ github.workflow }}", "${{ github.event.deployment.environment || github.run_id }}"},
+			description:    "Deployments to different environments should not share a group",
+		},
+		{
+			name: "Deployment status workflow should key on the environment",
+			workflowData: &WorkflowData{
+				On: `on:
+  deployment_status:
+  workflow_dispatch:`,
+			},
+			isAliasTrigger: false,
+			expected:       []string{"gh-aw", "${{ github.workflow }}", "${{ github.event.deployment.environment || github.run_id }}"},
+			description:    "deployment_status payloads carry the deployment and its environment",
+		},
+		{
+			name: "Custom concurrency key replaces the trigger-based key",
+			workflowData: &WorkflowData{
+				On: `on:
+  repository_dispatch:
+    types: [deploy]`,
+				ConcurrencyKey: "github.event.client_payload.id",
+			},
+			isAliasTrigger: false,
+			expected:       []string{"gh-aw", "${{ github.workflow }}", "${{ github.event.client_payload.id }}"},
+			description:    "concurrency.key should be appended as an expression",
+		},
+		{
+			name: "Custom concurrency key expression is used verbatim",
+			workflowData: &WorkflowData{
+				On: `on:
+  issues:
+    types: [opened]`,
+				ConcurrencyKey: "${{ github.event.client_payload.id || github.event.issue.number }}",
+			},
+			isAliasTrigger: false,
+			expected:       []string{"gh-aw", "${{ github.workflow }}", "${{ github.event.client_payload.id || github.event.issue.number }}"},
+			description:    "concurrency.key expressions should not be wrapped again",
+		},
+		{
+			name: "Other workflow should not include additional keys",
+			workflowData: &WorkflowData{
+				On: `on:
+  schedule:
     - cron: "0 9 * * 1"`,
 			},
 			isAliasTrigger: false,
@@ -992,6 +1768,74 @@ func TestBuildConcurrencyGroupKeys(t *testing.T) {
 			expected:       []string{"gh-aw", "${{ github.workflow }}", "${{ github.event.discussion.number || inputs.item_number || github.run_id }}"},
 			description:    "Label trigger shorthand discussion workflows should include inputs.item_number fallback",
 		},
+		{
+			name: "Issue workflow grouped by label should key on label name",
+			workflowData: &WorkflowData{
+				On: `on:
+  issues:
+    types: [labeled]`,
+				ConcurrencyGroupBy: "label",
+			},
+			isAliasTrigger: false,
+			expected:       []string{"gh-aw", "${{ github.workflow }}", "${{ github.event.label.name || github.event.issue.number || github.run_id }}"},
+			description:    "Issue workflows with group-by label should key on the label name before the issue number",
+		},
+		{
+			name: "PR workflow grouped by label should key on label name",
+			workflowData: &WorkflowData{
+				On: `on:
+  pull_request:
+    types: [labeled, unlabeled]`,
+				ConcurrencyGroupBy: "label",
+			},
+			isAliasTrigger: false,
+			expected:       []string{"gh-aw", "${{ github.workflow }}", "${{ github.event.label.name || github.event.pull_request.number || github.ref || github.run_id }}"},
+			description:    "PR workflows with group-by label should key on the label name before the PR number",
+		},
+		{
+			name: "Label trigger shorthand grouped by label keeps item_number fallback",
+			workflowData: &WorkflowData{
+				On: `on:
+  issues:
+    types: [labeled]
+  workflow_dispatch:
+    inputs:
+      item_number:
+        description: The number of the issue
+        required: true
+        type: string`,
+				HasDispatchItemNumber: true,
+				ConcurrencyGroupBy:    "label",
+			},
+			isAliasTrigger: false,
+			expected:       []string{"gh-aw", "${{ github.workflow }}", "${{ github.event.label.name || github.event.issue.number || inputs.item_number || github.run_id }}"},
+			description:    "Label grouping should preserve the inputs.item_number fallback for manual dispatches",
+		},
+		{
+			name: "Schedule workflow grouped by schedule should key on cron expression",
+			workflowData: &WorkflowData{
+				On: `on:
+  schedule:
+    - cron: "0 9 * * *"
+    - cron: "0 21 * * *"`,
+				ConcurrencyGroupBy: "schedule",
+			},
+			isAliasTrigger: false,
+			expected:       []string{"gh-aw", "${{ github.workflow }}", "${{ github.event.schedule || github.event_name }}"},
+			description:    "Schedule workflows with group-by schedule should key on the triggering cron expression",
+		},
+		{
+			name: "Command workflow ignores group-by label",
+			workflowData: &WorkflowData{
+				On: `on:
+  issues:
+    types: [labeled]`,
+				ConcurrencyGroupBy: "label",
+			},
+			isAliasTrigger: true,
+			expected:       []string{"gh-aw", "${{ github.workflow }}", "${{ github.event.issue.number || github.event.pull_request.number || github.run_id }}"},
+			description:    "Command workflows should keep grouping by issue/PR number",
+		},
 	}
 
 	for _, tt := range tests {
@@ -1042,6 +1886,53 @@ func TestShouldEnableCancelInProgress(t *testing.T) {
 			expected:       true,
 			description:    "PR workflows should enable cancellation",
 		},
+		{
+			name: "pull_request_target workflow should not enable cancellation",
+			workflowData: &WorkflowData{
+				On: `on:
+  pull_request_target:
+    types: [opened, synchronize]`,
+			},
+			isAliasTrigger: false,
+			expected:       false,
+			description:    "pull_request_target workflows require explicit opt-in, unlike plain pull_request workflows",
+		},
+		{
+			name: "Mixed pull_request and pull_request_target workflow should not enable cancellation",
+			workflowData: &WorkflowData{
+				On: `on:
+  pull_request:
+    types: [opened]
+  pull_request_target:
+    types: [labeled]`,
+			},
+			isAliasTrigger: false,
+			expected:       false,
+			description:    "pull_request_target runs are not cancelled unconditionally; BuildConcurrencyConfig cancels the other events with an event-conditional expression",
+		},
+		{
+			name: "merge_group workflow should not enable cancellation",
+			workflowData: &WorkflowData{
+				On: `on:
+  merge_group:
+    types: [checks_requested]`,
+			},
+			isAliasTrigger: false,
+			expected:       false,
+			description:    "Cancelling merge queue checks would drop PRs from the queue",
+		},
+		{
+			name: "Mixed PR and merge_group workflow should not enable cancellation",
+			workflowData: &WorkflowData{
+				On: `on:
+  pull_request:
+    types: [opened, synchronize]
+  merge_group:`,
+			},
+			isAliasTrigger: false,
+			expected:       false,
+			description:    "Workflows that run in the merge queue should never enable cancellation",
+		},
 		{
 			name: "Issue workflow should not enable cancellation",
 			workflowData: &WorkflowData{
@@ -1077,6 +1968,140 @@ func TestShouldEnableCancelInProgress(t *testing.T) {
 			expected:       false,
 			description:    "Other workflows should not enable cancellation",
 		},
+		{
+			name: "cancel-policy enables cancellation for schedule",
+			workflowData: &WorkflowData{
+				On: `on:
+  schedule:
+    - cron: "0 */2 * * *"`,
+				ConcurrencyCancelPolicy: map[string]bool{"schedule": true},
+			},
+			isAliasTrigger: false,
+			expected:       true,
+			description:    "Newer scheduled runs should cancel older ones when the policy opts schedule in",
+		},
+		{
+			name: "cancel-policy enables cancellation for issue_comment",
+			workflowData: &WorkflowData{
+				On: `on:
+  issue_comment:
+    types: [created]`,
+				ConcurrencyCancelPolicy: map[string]bool{"issue_comment": true},
+			},
+			isAliasTrigger: false,
+			expected:       true,
+			description:    "Issue comment workflows should cancel when the policy opts them in",
+		},
+		{
+			name: "cancel-policy keeps pull_request default for unlisted triggers",
+			workflowData: &WorkflowData{
+				On: `on:
+  schedule:
+    - cron: "0 9 * * 1"
+  pull_request:
+    types: [opened]`,
+				ConcurrencyCancelPolicy: map[string]bool{"schedule": false},
+			},
+			isAliasTrigger: false,
+			expected:       true,
+			description:    "Triggers not listed in the policy should keep their default",
+		},
+		{
+			name: "cancel-policy disables pull_request cancellation",
+			workflowData: &WorkflowData{
+				On: `on:
+  pull_request:
+    types: [opened, synchronize]`,
+				ConcurrencyCancelPolicy: map[string]bool{"pull_request": false},
+			},
+			isAliasTrigger: false,
+			expected:       false,
+			description:    "The policy should override the pull_request default",
+		},
+		{
+			name: "cancel-policy does not enable cancellation for unlisted pull_request_target",
+			workflowData: &WorkflowData{
+				On: `on:
+  schedule:
+    - cron: "0 9 * * 1"
+  pull_request_target:
+    types: [opened]`,
+				ConcurrencyCancelPolicy: map[string]bool{"schedule": true},
+			},
+			isAliasTrigger: false,
+			expected:       false,
+			description:    "pull_request_target workflows require an explicit policy entry",
+		},
+		{
+			name: "cancel-policy does not override merge_group",
+			workflowData: &WorkflowData{
+				On: `on:
+  merge_group:
+    types: [checks_requested]`,
+				ConcurrencyCancelPolicy: map[string]bool{"merge_group": true},
+			},
+			isAliasTrigger: false,
+			expected:       false,
+			description:    "Merge queue checks are never cancelled",
+		},
+		{
+			name: "cancel-policy does not override command workflows",
+			workflowData: &WorkflowData{
+				On: `on:
+  issue_comment:
+    types: [created]`,
+				ConcurrencyCancelPolicy: map[string]bool{"issue_comment": true},
+			},
+			isAliasTrigger: true,
+			expected:       false,
+			description:    "Command workflows are never cancelled",
+		},
+		{
+			name: "Deployment workflow should not enable cancellation",
+			workflowData: &WorkflowData{
+				On: `on:
+  deployment:`,
+			},
+			isAliasTrigger: false,
+			expected:       false,
+			description:    "Cancelling an in-flight deployment is dangerous",
+		},
+		{
+			name: "Mixed pull_request and deployment_status workflow should not enable cancellation",
+			workflowData: &WorkflowData{
+				On: `on:
+  pull_request:
+    types: [opened]
+  deployment_status:`,
+			},
+			isAliasTrigger: false,
+			expected:       false,
+			description:    "Any deployment trigger should disable default cancellation",
+		},
+		{
+			name: "cancel-policy requires an explicit entry for deployment",
+			workflowData: &WorkflowData{
+				On: `on:
+  pull_request:
+    types: [opened]
+  deployment:`,
+				ConcurrencyCancelPolicy: map[string]bool{"pull_request": true},
+			},
+			isAliasTrigger: false,
+			expected:       false,
+			description:    "Deployment workflows are only cancelled when the policy lists the trigger",
+		},
+		{
+			name: "cancel-policy opts deployment in",
+			workflowData: &WorkflowData{
+				On: `on:
+  deployment:`,
+				ConcurrencyCancelPolicy: map[string]bool{"deployment": true},
+			},
+			isAliasTrigger: false,
+			expected:       true,
+			description:    "An explicit deployment entry enables cancellation",
+		},
 	}
 
 	for _, tt := range tests {
@@ -1177,7 +2202,7 @@ func TestIsWorkflowDispatchOnly(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := isWorkflowDispatchOnly(tt.on)
+			result := isWorkflowDispatchOnly(workflowTriggers(tt.on))
 			if result != tt.expected {
 				t.Errorf("isWorkflowDispatchOnly() for %q = %v, want %v: %s", tt.name, result, tt.expected, tt.desc)
 			}
@@ -1216,6 +2241,46 @@ func TestHasSpecialTriggers(t *testing.T) {
 			expected: true,
 			desc:     "push trigger should be detected as special",
 		},
+		{
+			name: "workflow_run workflow is a special trigger",
+			on: `on:
+  workflow_run:
+    workflows: ["CI"]
+    types: [completed]`,
+			expected: true,
+			desc:     "workflow_run trigger should be detected as special",
+		},
+		{
+			name: "merge_group workflow is a special trigger",
+			on: `on:
+  merge_group:
+    types: [checks_requested]`,
+			expected: true,
+			desc:     "merge_group trigger should be detected as special",
+		},
+		{
+			name: "release workflow is a special trigger",
+			on: `on:
+  release:
+    types: [published]`,
+			expected: true,
+			desc:     "release trigger should be detected as special",
+		},
+		{
+			name: "repository_dispatch workflow is a special trigger",
+			on: `on:
+  repository_dispatch:
+    types: [deploy]`,
+			expected: true,
+			desc:     "repository_dispatch trigger should be detected as special",
+		},
+		{
+			name: "deployment_status workflow is a special trigger",
+			on: `on:
+  deployment_status:`,
+			expected: true,
+			desc:     "deployment_status trigger should be detected as special",
+		},
 		{
 			name: "Discussion workflow is a special trigger",
 			on: `on:
@@ -1276,3 +2341,311 @@ func TestHasSpecialTriggers(t *testing.T) {
 		})
 	}
 }
+
+func TestConcurrencyDisabled(t *testing.T) {
+	scheduleOn := `on:
+  schedule:
+    - cron: "0 * * * *"`
+
+	t.Run("none emits no workflow or job concurrency", func(t *testing.T) {
+		workflowData := &WorkflowData{
+			On:                  scheduleOn,
+			ConcurrencyDisabled: true,
+			EngineConfig:        &EngineConfig{ID: "copilot"},
+		}
+		assert.Empty(t, GenerateConcurrencyConfig(workflowData, false), "workflow-level concurrency should not be generated")
+		assert.Empty(t, GenerateJobConcurrencyConfig(workflowData), "job-level concurrency should not be generated")
+	})
+
+	t.Run("custom string group is used as-is", func(t *testing.T) {
+		workflowData := &WorkflowData{
+			On:           scheduleOn,
+			Concurrency:  "concurrency: my-group",
+			EngineConfig: &EngineConfig{ID: "copilot"},
+		}
+		assert.Equal(t, "concurrency: my-group", GenerateConcurrencyConfig(workflowData, false), "custom concurrency should be preserved")
+		assert.Equal(t, "concurrency:\n  group: \"gh-aw-copilot-${{ github.workflow }}\"", GenerateJobConcurrencyConfig(workflowData), "default job concurrency should still be generated")
+	})
+
+	t.Run("compiled lock file has no concurrency blocks", func(t *testing.T) {
+		for _, value := range []string{"none", "false"} {
+			tmpDir := testutil.TempDir(t, "concurrency-disabled-test")
+			testContent := `---
+on:
+  schedule:
+    - cron: "0 * * * *"
+concurrency: ` + value + `
+engine: copilot
+---
+
+# Ungrouped Workflow
+`
+			testFile := filepath.Join(tmpDir, "ungrouped.md")
+			require.NoError(t, os.WriteFile(testFile, []byte(testContent), 0644), "should write workflow")
+
+			compiler := NewCompiler()
+			require.NoError(t, compiler.CompileWorkflow(testFile), "concurrency: %s should compile", value)
+
+			lockContent, err := os.ReadFile(filepath.Join(tmpDir, "ungrouped.lock.yml"))
+			require.NoError(t, err, "should read lock file")
+			assert.NotContains(t, string(lockContent), "\nconcurrency:", "concurrency: %s should not emit a workflow-level block", value)
+			assert.NotContains(t, string(lockContent), "gh-aw-copilot-${{ github.workflow }}", "concurrency: %s should not emit the default agent job block", value)
+		}
+	})
+}
+
+// TestTriggerDetectionIgnoresInputNames verifies that workflow_dispatch input names
+// embedding an event name are not detected as triggers
+func TestTriggerDetectionIgnoresInputNames(t *testing.T) {
+	dispatchOn := `on:
+  workflow_dispatch:
+    inputs:
+      push_branch:
+        description: Branch to push
+      force_push:
+        type: boolean
+      pull_request_number:
+        type: string
+      issues_label:
+        type: string
+      discussion_category:
+        type: string`
+
+	assert.False(t, isPushWorkflow(workflowTriggers(dispatchOn)), "push_branch and force_push inputs should not be detected as push triggers")
+	assert.False(t, isPullRequestWorkflow(workflowTriggers(dispatchOn)), "pull_request_number input should not be detected as a pull_request trigger")
+	assert.False(t, isIssueWorkflow(workflowTriggers(dispatchOn)), "issues_label input should not be detected as an issues trigger")
+	assert.False(t, isDiscussionWorkflow(workflowTriggers(dispatchOn)), "discussion_category input should not be detected as a discussion trigger")
+	assert.True(t, isWorkflowDispatchOnly(workflowTriggers(dispatchOn)), "workflow should be workflow_dispatch-only")
+	assert.Equal(t, []string{"gh-aw", "${{ github.workflow }}"}, buildConcurrencyGroupKeys(&WorkflowData{On: dispatchOn}, false),
+		"dispatch-only workflow should not get a github.ref key")
+
+	tests := []struct {
+		name   string
+		on     string
+		detect func(TriggerSet) bool
+	}{
+		{name: "inline push", on: "on: push", detect: isPushWorkflow},
+		{name: "inline list", on: "on: [workflow_dispatch, pull_request]", detect: isPullRequestWorkflow},
+		{name: "quoted on key", on: "\"on\":\n  issue_comment:\n    types: [created]", detect: isIssueWorkflow},
+		{name: "pull_request_target", on: "on:\n  pull_request_target:\n    types: [opened]", detect: isPullRequestWorkflow},
+		{name: "discussion_comment", on: "on:\n  discussion_comment:\n    types: [created]", detect: isDiscussionWorkflow},
+		{name: "block list", on: "on:\n  - push\n  - workflow_dispatch", detect: isPushWorkflow},
+		{name: "push alongside inputs", on: dispatchOn + "\n  push:\n    branches: [main]", detect: isPushWorkflow},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.True(t, tt.detect(workflowTriggers(tt.on)), "trigger should be detected")
+		})
+	}
+}
+
+// TestTriggerDetectionIgnoresNestedKeys verifies that only the top-level events of the on
+// section count as triggers, not input names, choice options, or filter values
+func TestTriggerDetectionIgnoresNestedKeys(t *testing.T) {
+	on := `on:
+  workflow_dispatch:
+    inputs:
+      issues:
+        type: string
+      target:
+        type: choice
+        options:
+          - push
+          - release
+  schedule:
+    - cron: "0 9 * * 1"
+  pull_request_review:
+    branches:
+      - release`
+
+	assert.False(t, isIssueWorkflow(workflowTriggers(on)), "an input named issues should not be detected as an issues trigger")
+	assert.False(t, isPushWorkflow(workflowTriggers(on)), "a choice option should not be detected as a push trigger")
+	assert.False(t, isReleaseWorkflow(workflowTriggers(on)), "a branch filter should not be detected as a release trigger")
+	assert.True(t, isPullRequestWorkflow(workflowTriggers(on)), "top-level pull_request_review should be detected")
+
+	dispatchOnly := "on:\n  workflow_dispatch:\n    inputs:\n      issues:\n        type: string\n      mode:\n        type: choice\n        options:\n          - push"
+	assert.True(t, isWorkflowDispatchOnly(workflowTriggers(dispatchOnly)), "nested input names and options should not disqualify a dispatch-only workflow")
+	assert.False(t, isWorkflowDispatchOnly(workflowTriggers("on:\n  schedule:\n    - cron: \"0 9 * * 1\"\n  # workflow_dispatch:")), "a commented-out workflow_dispatch is not a trigger")
+}
+
+// TestBuildConcurrencyGroup verifies that the public group builder matches the group
+// and cancel-in-progress setting emitted by GenerateConcurrencyConfig
+func TestBuildConcurrencyGroup(t *testing.T) {
+	tests := []struct {
+		name             string
+		workflowData     *WorkflowData
+		isCommandTrigger bool
+		expectedGroup    string
+		expectedCancel   bool
+	}{
+		{
+			name:           "pull request workflow cancels in progress",
+			workflowData:   &WorkflowData{On: "on:\n  pull_request:\n    types: [opened]"},
+			expectedGroup:  "gh-aw-${{ github.workflow }}-${{ github.event.pull_request.number || github.ref || github.run_id }}",
+			expectedCancel: true,
+		},
+		{
+			name:          "push workflow keys on ref",
+			workflowData:  &WorkflowData{On: "on:\n  push:\n    branches: [main]"},
+			expectedGroup: "gh-aw-${{ github.workflow }}-${{ github.ref || github.run_id }}",
+		},
+		{
+			name:          "mixed pull_request and pull_request_target keeps the event condition intact",
+			workflowData:  &WorkflowData{On: "on:\n  pull_request:\n    types: [opened]\n  pull_request_target:\n    types: [labeled]"},
+			expectedGroup: "gh-aw-${{ github.workflow }}-${{ github.event_name == 'pull_request_target' && github.event.pull_request.node_id || github.event.pull_request.number || github.ref || github.run_id }}",
+		},
+		{
+			name:             "command trigger never cancels",
+			workflowData:     &WorkflowData{On: "on:\n  pull_request:\n    types: [opened]"},
+			isCommandTrigger: true,
+			expectedGroup:    "gh-aw-${{ github.workflow }}-${{ github.event.issue.number || github.event.pull_request.number || github.run_id }}",
+		},
+		{
+			name:          "custom key literal text is sanitized",
+			workflowData:  &WorkflowData{On: "on:\n  repository_dispatch:", ConcurrencyKey: "deploy target ${{ github.event.client_payload.env }}"},
+			expectedGroup: "gh-aw-${{ github.workflow }}-deploy-target-${{ github.event.client_payload.env }}",
+		},
+		{
+			name:          "overrides in workflow data are not applied",
+			workflowData:  &WorkflowData{On: "on:\n  schedule:\n    - cron: '0 9 * * 1'", Concurrency: "concurrency:\n  group: custom", ConcurrencyCancelInProgress: "true"},
+			expectedGroup: "gh-aw-${{ github.workflow }}",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			group, cancelInProgress := BuildConcurrencyGroup(tt.workflowData, tt.isCommandTrigger)
+			assert.Equal(t, tt.expectedGroup, group, "group should match the generated group")
+			assert.Equal(t, tt.expectedCancel, cancelInProgress, "cancel-in-progress should follow the trigger types")
+
+			if tt.workflowData.Concurrency == "" {
+				config := GenerateConcurrencyConfig(tt.workflowData, tt.isCommandTrigger)
+				assert.Contains(t, config, formatConcurrencyGroupValue(group), "generated config should use the built group")
+				assert.Equal(t, cancelInProgress, strings.Contains(config, "cancel-in-progress: true"), "generated config should use the built cancel-in-progress setting")
+			}
+		})
+	}
+}
+
+func TestBuildConcurrencyConfig(t *testing.T) {
+	enabled, disabled := true, false
+	tests := []struct {
+		name               string
+		workflowData       *WorkflowData
+		expectedGroup      string
+		expectedCancel     *bool
+		expectedExpression string
+		expectedYAML       string
+	}{
+		{
+			name:           "pull request workflow cancels in progress",
+			workflowData:   &WorkflowData{On: "on:\n  pull_request:\n    types: [opened]"},
+			expectedGroup:  "gh-aw-${{ github.workflow }}-${{ github.event.pull_request.number || github.ref || github.run_id }}",
+			expectedCancel: &enabled,
+			expectedYAML:   "concurrency:\n  group: \"gh-aw-${{ github.workflow }}-${{ github.event.pull_request.number || github.ref || github.run_id }}\"\n  cancel-in-progress: true",
+		},
+		{
+			name:          "push workflow omits cancel-in-progress",
+			workflowData:  &WorkflowData{On: "on:\n  push:\n    branches: [main]"},
+			expectedGroup: "gh-aw-${{ github.workflow }}-${{ github.ref || github.run_id }}",
+			expectedYAML:  "concurrency:\n  group: \"gh-aw-${{ github.workflow }}-${{ github.ref || github.run_id }}\"",
+		},
+		{
+			name:           "boolean override replaces the trigger heuristic",
+			workflowData:   &WorkflowData{On: "on:\n  pull_request:\n    types: [opened]", ConcurrencyCancelInProgress: "false"},
+			expectedGroup:  "gh-aw-${{ github.workflow }}-${{ github.event.pull_request.number || github.ref || github.run_id }}",
+			expectedCancel: &disabled,
+			expectedYAML:   "concurrency:\n  group: \"gh-aw-${{ github.workflow }}-${{ github.event.pull_request.number || github.ref || github.run_id }}\"\n  cancel-in-progress: false",
+		},
+		{
+			name:               "expression override has no boolean value",
+			workflowData:       &WorkflowData{On: "on:\n  push:", ConcurrencyCancelInProgress: "${{ github.ref != 'refs/heads/main' }}"},
+			expectedGroup:      "gh-aw-${{ github.workflow }}-${{ github.ref || github.run_id }}",
+			expectedExpression: "${{ github.ref != 'refs/heads/main' }}",
+			expectedYAML:       "concurrency:\n  group: \"gh-aw-${{ github.workflow }}-${{ github.ref || github.run_id }}\"\n  cancel-in-progress: ${{ github.ref != 'refs/heads/main' }}",
+		},
+		{
+			name:               "mixed pull_request and pull_request_target workflow cancels only pull_request runs",
+			workflowData:       &WorkflowData{On: "on:\n  pull_request:\n    types: [opened]\n  pull_request_target:\n    types: [labeled]"},
+			expectedGroup:      "gh-aw-${{ github.workflow }}-${{ github.event_name == 'pull_request_target' && github.event.pull_request.node_id || github.event.pull_request.number || github.ref || github.run_id }}",
+			expectedExpression: "${{ github.event_name != 'pull_request_target' }}",
+			expectedYAML:       "concurrency:\n  group: \"gh-aw-${{ github.workflow }}-${{ github.event_name == 'pull_request_target' && github.event.pull_request.node_id || github.event.pull_request.number || github.ref || github.run_id }}\"\n  cancel-in-progress: ${{ github.event_name != 'pull_request_target' }}",
+		},
+		{
+			name:          "mixed workflow keyed by label keeps pull_request_target runs uncancelled",
+			workflowData:  &WorkflowData{On: "on:\n  pull_request:\n    types: [labeled]\n  pull_request_target:\n    types: [labeled]", ConcurrencyGroupBy: "label"},
+			expectedGroup: "gh-aw-${{ github.workflow }}-${{ github.event.label.name || github.event_name == 'pull_request_target' && github.event.pull_request.node_id || github.event.pull_request.number || github.ref || github.run_id }}",
+			expectedYAML:  "concurrency:\n  group: \"gh-aw-${{ github.workflow }}-${{ github.event.label.name || github.event_name == 'pull_request_target' && github.event.pull_request.node_id || github.event.pull_request.number || github.ref || github.run_id }}\"",
+		},
+		{
+			name:          "pull_request_target-only workflow omits cancel-in-progress",
+			workflowData:  &WorkflowData{On: "on:\n  pull_request_target:\n    types: [opened]"},
+			expectedGroup: "gh-aw-${{ github.workflow }}-${{ github.event.pull_request.number || github.ref || github.run_id }}",
+			expectedYAML:  "concurrency:\n  group: \"gh-aw-${{ github.workflow }}-${{ github.event.pull_request.number || github.ref || github.run_id }}\"",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := BuildConcurrencyConfig(tt.workflowData, false)
+			assert.Equal(t, tt.expectedGroup, config.Group, "group should match the generated group")
+			assert.Equal(t, tt.expectedCancel, config.CancelInProgress, "cancel-in-progress should be structured")
+			assert.Equal(t, tt.expectedExpression, config.CancelInProgressExpression, "expression overrides should be kept verbatim")
+			assert.Equal(t, tt.expectedYAML, config.YAML(), "YAML should render the config")
+			assert.Equal(t, config.YAML(), GenerateConcurrencyConfig(tt.workflowData, false), "GenerateConcurrencyConfig should render the structured config")
+		})
+	}
+}
+
+func TestConcurrencyConfigYAMLScheduleComment(t *testing.T) {
+	config := ConcurrencyConfig{Group: "gh-aw-${{ github.workflow }}-" + scheduleConcurrencyKey}
+	assert.Equal(t, "concurrency:\n  "+scheduleConcurrencyComment+"\n  group: \"gh-aw-${{ github.workflow }}-"+scheduleConcurrencyKey+"\"", config.YAML(), "schedule-keyed groups should be annotated")
+}
+
+func TestShortenConcurrencyGroup(t *testing.T) {
+	group := "gh-aw-${{ github.workflow }}-${{ github.event.issue.number || github.run_id }}"
+
+	t.Run("short group is unchanged", func(t *testing.T) {
+		assert.Equal(t, group, shortenConcurrencyGroup(group, "Issue Triage"), "groups within the limit should not be shortened")
+	})
+
+	t.Run("long workflow name is truncated with a hash suffix", func(t *testing.T) {
+		longName := "Triage " + strings.Repeat("issue ", 40)
+		shortened := shortenConcurrencyGroup(group, longName)
+
+		assert.NotContains(t, shortened, "github.workflow", "workflow name expression should be replaced")
+		assert.True(t, strings.HasPrefix(shortened, "gh-aw-Triage-issue-"), "shortened group should keep a readable prefix: %s", shortened)
+		assert.NoError(t, validateConcurrencyGroupLength(shortened, longName), "shortened group should fit within the limit")
+		assert.Equal(t, shortened, shortenConcurrencyGroup(group, longName), "shortening should be deterministic")
+		assert.NotEqual(t, shortened, shortenConcurrencyGroup(group, longName+"x"), "different workflow names should not collide")
+	})
+}
+
+func TestHashConcurrencyGroupPrefix(t *testing.T) {
+	prKeys := []string{"gh-aw", "${{ github.workflow }}", "${{ github.event.pull_request.number || github.ref || github.run_id }}"}
+
+	t.Run("static prefix is replaced and expressions are kept", func(t *testing.T) {
+		hashed := hashConcurrencyGroupPrefix(prKeys, "PR Review")
+		require.Len(t, hashed, 2, "static keys should collapse into one hashed key")
+		assert.Regexp(t, `^gh-aw-[0-9a-f]{8}$`, hashed[0], "prefix should be gh-aw-<short-sha>")
+		assert.Equal(t, prKeys[2], hashed[1], "per-run expression should be preserved")
+	})
+
+	t.Run("hash is stable across runs", func(t *testing.T) {
+		first := hashConcurrencyGroupPrefix(prKeys, "PR Review")
+		for range 5 {
+			assert.Equal(t, first, hashConcurrencyGroupPrefix(slices.Clone(prKeys), "PR Review"), "same workflow should always produce the same hash")
+		}
+		assert.Equal(t, []string{"gh-aw-c3b1789e", prKeys[2]}, first, "hash should not change between releases")
+	})
+
+	t.Run("different workflows do not collide", func(t *testing.T) {
+		assert.NotEqual(t, hashConcurrencyGroupPrefix(prKeys, "PR Review")[0], hashConcurrencyGroupPrefix(prKeys, "PR Triage")[0], "workflow name should be part of the hash")
+	})
+
+	t.Run("schedule-only group is fully static", func(t *testing.T) {
+		hashed := hashConcurrencyGroupPrefix([]string{"gh-aw", "${{ github.workflow }}"}, "Nightly")
+		require.Len(t, hashed, 1, "a fully static group should become a single hashed key")
+		assert.Regexp(t, `^gh-aw-[0-9a-f]{8}$`, hashed[0], "prefix should be gh-aw-<short-sha>")
+	})
+}
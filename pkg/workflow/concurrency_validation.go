@@ -8,6 +8,12 @@
 // # Validation Functions
 //
 //   - validateConcurrencyGroupExpression() - Validates syntax of a single group expression
+//   - validateConcurrencyGroupBy() - Validates concurrency.group-by against the workflow triggers
+//   - validateConcurrencyKeyFallbacks() - Checks that each trigger resolves to its own key identifier
+//   - validateConcurrencyGroupLength() - Checks a group against GitHub's length limit
+//   - matrixCancelInProgressWarnings() - Explains per-leg cancellation for matrix job concurrency
+//   - serializedScheduleWarning() - Flags multi-schedule workflows serialized by the default job group
+//   - pullRequestTargetCancelInProgressWarning() - Flags opted-in cancellation of pull_request_target runs
 //
 // # Validation Coverage
 //
@@ -30,7 +36,9 @@ package workflow
 import (
 	"fmt"
 	"regexp"
+	"slices"
 	"strings"
+	"unicode/utf8"
 )
 
 var concurrencyValidationLog = newValidationLogger("concurrency")
@@ -326,3 +334,319 @@ func extractConcurrencyGroupFromYAML(concurrencyYAML string) string {
 
 	return ""
 }
+
+// maxConcurrencyGroupLength is the longest concurrency group GitHub Actions accepts
+const maxConcurrencyGroupLength = 255
+
+// concurrencyGroupExpressionReserve is the number of characters reserved for each ${{ }}
+// expression (other than github.workflow) when estimating the runtime length of a group.
+// It covers issue and PR numbers, run IDs, and commit SHAs; long branch refs may exceed it.
+const concurrencyGroupExpressionReserve = 40
+
+// githubWorkflowExpressionPattern matches the ${{ github.workflow }} expression, which
+// resolves to the workflow name known at compile time
+var githubWorkflowExpressionPattern = regexp.MustCompile(`\$\{\{\s*github\.workflow\s*\}\}`)
+
+// estimateConcurrencyGroupLength returns the length of the static portion of group, with
+// ${{ github.workflow }} resolved to workflowName and other expressions removed, and the
+// estimated runtime length with concurrencyGroupExpressionReserve characters per expression.
+func estimateConcurrencyGroupLength(group string, workflowName string) (int, int) {
+	resolved := githubWorkflowExpressionPattern.ReplaceAllLiteralString(group, workflowName)
+	expressions := len(concurrencyExpressionPattern.FindAllString(resolved, -1))
+	static := utf8.RuneCountInString(concurrencyExpressionPattern.ReplaceAllString(resolved, ""))
+	return static, static + expressions*concurrencyGroupExpressionReserve
+}
+
+// validateConcurrencyGroupLength returns a validation error when group, with
+// ${{ github.workflow }} resolved to workflowName, risks exceeding GitHub's concurrency
+// group length limit at runtime.
+func validateConcurrencyGroupLength(group string, workflowName string) error {
+	static, estimated := estimateConcurrencyGroupLength(group, workflowName)
+	if estimated <= maxConcurrencyGroupLength {
+		return nil
+	}
+
+	concurrencyValidationLog.Printf("Concurrency group may exceed length limit: static=%d, estimated=%d", static, estimated)
+	return NewValidationError(
+		"concurrency.group",
+		group,
+		fmt.Sprintf("concurrency group may exceed GitHub's %d character limit (static portion is %d characters, estimated %d at runtime)", maxConcurrencyGroupLength, static, estimated),
+		"Shorten the workflow name or the custom concurrency group. For compiler-generated groups, set hash-long-group to replace the workflow name with a short hash:\n\nconcurrency:\n  hash-long-group: true",
+	)
+}
+
+// universalConcurrencyIdentifiers are identifiers populated for every event. An event that
+// resolves to one of them never reaches the identifiers that follow it in a "||" chain.
+var universalConcurrencyIdentifiers = []string{"github.ref", "github.run_id", "github.event_name", "github.sha", "github.workflow"}
+
+// validateConcurrencyKeyFallbacks checks that the trigger-based key of the generated
+// concurrency group is coherent for every trigger event of the workflow. An event must not
+// resolve to an identifier that every event populates (such as github.ref) while an
+// identifier specific to that event appears later in the "||" chain, since the specific
+// identifier would never be used and runs for different entities would share a group.
+// See ExplainConcurrencyKeyResolution for the identifier each event resolves to.
+func validateConcurrencyKeyFallbacks(workflowData *WorkflowData, isCommandTrigger bool) error {
+	keys := buildConcurrencyGroupKeys(workflowData, isCommandTrigger)
+	if len(keys) < 3 {
+		return nil
+	}
+	triggers, err := ParseTriggerSet(workflowData.On)
+	if err != nil {
+		return nil
+	}
+
+	// The trigger-based key follows the gh-aw prefix and the workflow identity
+	key := keys[2]
+	identifiers := concurrencyKeyIdentifiers(key)
+	events := make([]string, 0, len(triggers))
+	for event := range triggers {
+		events = append(events, event)
+	}
+	slices.Sort(events)
+
+	for _, event := range events {
+		resolved, _ := resolveConcurrencyIdentifier(identifiers, event)
+		if !slices.Contains(universalConcurrencyIdentifiers, resolved) {
+			continue
+		}
+		for _, later := range identifiers[slices.Index(identifiers, resolved)+1:] {
+			if events, ok := concurrencyIdentifierEvents[later]; !ok || !slices.Contains(events, event) {
+				continue
+			}
+			concurrencyValidationLog.Printf("Concurrency key for %s events resolves to %s before %s", event, resolved, later)
+			field := "concurrency"
+			if workflowData.ConcurrencyKey != "" {
+				field = "concurrency.key"
+			}
+			return NewValidationError(
+				field,
+				key,
+				fmt.Sprintf("%s events always resolve to %s, so %s later in the key is never used", event, resolved, later),
+				fmt.Sprintf("Place %s before %s so that %s events are grouped by their own identifier.", later, resolved, event),
+			)
+		}
+	}
+	return nil
+}
+
+// labelTriggerEvents lists the events whose payload carries github.event.label when
+// the activity type is labeled or unlabeled.
+var labelTriggerEvents = []string{"issues", "pull_request", "pull_request_target"}
+
+// validateConcurrencyGroupBy validates the concurrency.group-by setting against the
+// rendered "on" section. Grouping by label is only meaningful for issue and pull request
+// workflows that can be triggered by labeled or unlabeled activity, since other events
+// do not populate github.event.label.
+func validateConcurrencyGroupBy(groupBy string, on string) error {
+	if groupBy == "" {
+		return nil
+	}
+
+	concurrencyValidationLog.Printf("Validating concurrency group-by: %s", groupBy)
+	triggers := workflowTriggers(on)
+
+	if groupBy == concurrencyGroupBySchedule {
+		if len(scheduleCronExpressions(triggers)) == 0 {
+			return NewValidationError(
+				"concurrency.group-by",
+				groupBy,
+				"grouping by schedule requires a schedule trigger",
+				"Add a schedule trigger, for example:\n\non:\n  schedule:\n    - cron: \"0 9 * * *\"\n\nor remove 'group-by: schedule'.",
+			)
+		}
+		concurrencyValidationLog.Print("Concurrency group-by validation passed")
+		return nil
+	}
+
+	if groupBy != concurrencyGroupByLabel {
+		return NewValidationError(
+			"concurrency.group-by",
+			groupBy,
+			"unsupported concurrency group-by value",
+			"Use 'group-by: label' to key issue and pull request workflows on the triggering label name, or 'group-by: schedule' to key schedule workflows on the triggering cron expression.",
+		)
+	}
+
+	if !hasLabelTrigger(triggers) {
+		return NewValidationError(
+			"concurrency.group-by",
+			groupBy,
+			"grouping by label requires an issues, pull_request, or pull_request_target trigger that fires on labeled or unlabeled activity",
+			"Add a label-related trigger, for example:\n\non:\n  issues:\n    types: [labeled]\n\nor remove 'group-by: label' to group by issue or pull request number.",
+		)
+	}
+
+	concurrencyValidationLog.Print("Concurrency group-by validation passed")
+	return nil
+}
+
+// hasLabelTrigger reports whether the trigger set contains an issue or pull request
+// trigger that fires on labeled or unlabeled activity. A trigger without a types filter
+// fires on all activity types, including labeled.
+func hasLabelTrigger(triggers TriggerSet) bool {
+	for _, event := range labelTriggerEvents {
+		config, exists := triggers[event]
+		if !exists {
+			continue
+		}
+		configMap, ok := config.(map[string]any)
+		if !ok {
+			// No configuration means all activity types
+			return true
+		}
+		switch types := configMap["types"].(type) {
+		case nil:
+			return true
+		case string:
+			if types == "labeled" || types == "unlabeled" {
+				return true
+			}
+		case []any:
+			if slices.Contains(types, any("labeled")) || slices.Contains(types, any("unlabeled")) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// matrixCancelInProgressWarnings returns a warning for each custom job whose concurrency
+// group references matrix values while cancel-in-progress is enabled, including the per-leg
+// groups keyed on the leg index that concurrency.matrix generates. Each matrix leg then
+// gets its own concurrency group, so a new run only cancels the in-progress legs with the
+// same matrix values rather than the whole previous run. That is usually desired, but can
+// be surprising, so the behavior is explained rather than rejected. Jobs are visited in
+// sorted order for deterministic output.
+func matrixCancelInProgressWarnings(workflowData *WorkflowData, isCommandTrigger bool) []string {
+	jobs := workflowData.Jobs
+	jobNames := make([]string, 0, len(jobs))
+	for name := range jobs {
+		jobNames = append(jobNames, name)
+	}
+	slices.Sort(jobNames)
+
+	var warnings []string
+	for _, jobName := range jobNames {
+		jobConfig, ok := jobs[jobName].(map[string]any)
+		if !ok {
+			continue
+		}
+		if hasGeneratedMatrixConcurrency(workflowData, jobConfig) {
+			if !isCancelInProgressEnabled(matrixJobCancelInProgress(workflowData, isCommandTrigger)) {
+				continue
+			}
+			concurrencyValidationLog.Printf("Job %s combines the generated matrix concurrency group with cancel-in-progress", jobName)
+			warnings = append(warnings, fmt.Sprintf(
+				"job '%s' gets a per-leg concurrency group from concurrency.matrix with cancel-in-progress enabled. "+
+					"Each matrix leg has its own concurrency group keyed on its index, so a new run only cancels the in-progress leg with the same index, not the entire previous run. "+
+					"Remove concurrency.matrix if a new run should cancel every leg.",
+				jobName,
+			))
+			continue
+		}
+		if !hasMatrixStrategy(jobConfig) {
+			continue
+		}
+		concurrency, ok := jobConfig["concurrency"].(map[string]any)
+		if !ok {
+			continue
+		}
+		group, _ := concurrency["group"].(string)
+		if !strings.Contains(group, "matrix.") || !isCancelInProgressEnabled(concurrency["cancel-in-progress"]) {
+			continue
+		}
+
+		concurrencyValidationLog.Printf("Job %s combines matrix concurrency group with cancel-in-progress", jobName)
+		warnings = append(warnings, fmt.Sprintf(
+			"job '%s' uses matrix values in its concurrency group with cancel-in-progress enabled. "+
+				"Each matrix leg has its own concurrency group, so a new run only cancels in-progress legs with the same matrix values, not the entire previous run. "+
+				"Remove the matrix values from the group if a new run should cancel every leg.",
+			jobName,
+		))
+	}
+	return warnings
+}
+
+// scheduleCronExpressions returns the cron expressions of the schedule trigger in the
+// trigger set, in declaration order
+func scheduleCronExpressions(triggers TriggerSet) []string {
+	entries, ok := triggers["schedule"].([]any)
+	if !ok {
+		return nil
+	}
+
+	var crons []string
+	for _, entry := range entries {
+		if entryMap, ok := entry.(map[string]any); ok {
+			if cron, ok := entryMap["cron"].(string); ok {
+				crons = append(crons, cron)
+			}
+		}
+	}
+	return crons
+}
+
+// serializedScheduleWarning returns a warning when a workflow with several cron schedules
+// relies on the default agent job concurrency group. That group only contains the engine
+// and workflow name, so every scheduled run is serialized, including runs of different
+// shards. Returns "" when the workflow is not affected.
+func serializedScheduleWarning(workflowData *WorkflowData) string {
+	crons := scheduleCronExpressions(workflowTriggers(workflowData.On))
+	if len(crons) < 2 {
+		return ""
+	}
+	if workflowData.ConcurrencyGroupBy == concurrencyGroupBySchedule || workflowData.ConcurrencyJobDiscriminator != "" ||
+		(workflowData.EngineConfig != nil && workflowData.EngineConfig.Concurrency != "") {
+		return ""
+	}
+	group := extractConcurrencyGroupFromYAML(GenerateJobConcurrencyConfig(workflowData))
+	if group == "" {
+		return ""
+	}
+
+	concurrencyValidationLog.Printf("Workflow with %d schedules uses default job concurrency group %s", len(crons), group)
+	return fmt.Sprintf(
+		"workflow has %d schedule entries but the agent job uses the default concurrency group '%s', which serializes every scheduled run of the workflow. "+
+			"If the schedules are shards that should run independently, give each schedule its own group:\n\nconcurrency:\n  group-by: schedule",
+		len(crons), group,
+	)
+}
+
+// pullRequestTargetCancelInProgressWarning returns a warning when a pull_request_target
+// workflow opts its generated concurrency group into cancel-in-progress. These runs hold a
+// privileged token and secrets, so cancelling one part-way can leave privileged side effects
+// (comments, labels, pushes) half applied. Cancellation is off by default for these
+// workflows; the warning confirms the opt-in was intended. Returns "" when not affected.
+func pullRequestTargetCancelInProgressWarning(workflowData *WorkflowData) string {
+	if workflowData.ConcurrencyDisabled || workflowData.Concurrency != "" || !isPullRequestTargetWorkflow(workflowTriggers(workflowData.On)) {
+		return ""
+	}
+	// The cancel-in-progress override takes precedence over the per-trigger cancel-policy
+	field, value := "concurrency.cancel-in-progress", workflowData.ConcurrencyCancelInProgress
+	if value == "" && workflowData.ConcurrencyCancelPolicy["pull_request_target"] {
+		field, value = "concurrency.cancel-policy.pull_request_target", "true"
+	}
+	if !isCancelInProgressEnabled(value) {
+		return ""
+	}
+
+	concurrencyValidationLog.Printf("pull_request_target workflow enables cancel-in-progress through %s: %s", field, value)
+	return fmt.Sprintf(
+		"%s is set to %s for a pull_request_target workflow. "+
+			"pull_request_target runs have a privileged token and access to secrets, so cancelling a run part-way can leave its changes half applied. "+
+			"Make sure every step tolerates being interrupted, or remove the setting to keep the default of not cancelling these runs.",
+		field, value,
+	)
+}
+
+// isCancelInProgressEnabled reports whether a cancel-in-progress value may enable cancellation.
+// Expressions are treated as enabled since they can evaluate to true at runtime.
+func isCancelInProgressEnabled(value any) bool {
+	switch v := value.(type) {
+	case bool:
+		return v
+	case string:
+		return v == "true" || strings.Contains(v, "${{")
+	}
+	return false
+}
@@ -3,6 +3,7 @@
 package workflow
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -756,3 +757,456 @@ func TestExtractConcurrencyGroupFromYAML(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateConcurrencyGroupBy(t *testing.T) {
+	tests := []struct {
+		name    string
+		groupBy string
+		on      string
+		wantErr string
+	}{
+		{
+			name:    "empty group-by is always valid",
+			groupBy: "",
+			on:      "on: push",
+		},
+		{
+			name:    "label with issues labeled trigger",
+			groupBy: "label",
+			on: `on:
+  issues:
+    types: [labeled]`,
+		},
+		{
+			name:    "label with pull_request unlabeled trigger",
+			groupBy: "label",
+			on: `on:
+  pull_request:
+    types: [opened, unlabeled]`,
+		},
+		{
+			name:    "label with pull_request_target labeled trigger",
+			groupBy: "label",
+			on: `on:
+  pull_request_target:
+    types: labeled`,
+		},
+		{
+			name:    "label with issues trigger without types filter",
+			groupBy: "label",
+			on: `on:
+  issues:
+  workflow_dispatch:`,
+		},
+		{
+			name:    "label with inline issues trigger",
+			groupBy: "label",
+			on:      "on: issues",
+		},
+		{
+			name:    "label with issues trigger that never fires on labels",
+			groupBy: "label",
+			on: `on:
+  issues:
+    types: [opened, edited]`,
+			wantErr: "grouping by label requires",
+		},
+		{
+			name:    "label with discussion labeled trigger",
+			groupBy: "label",
+			on: `on:
+  discussion:
+    types: [labeled]`,
+			wantErr: "grouping by label requires",
+		},
+		{
+			name:    "label with schedule trigger",
+			groupBy: "label",
+			on: `on:
+  schedule:
+    - cron: "0 9 * * 1"`,
+			wantErr: "grouping by label requires",
+		},
+		{
+			name:    "schedule with schedule trigger",
+			groupBy: "schedule",
+			on: `on:
+  schedule:
+    - cron: "0 9 * * *"
+    - cron: "0 21 * * *"`,
+		},
+		{
+			name:    "schedule without schedule trigger",
+			groupBy: "schedule",
+			on: `on:
+  issues:
+    types: [labeled]`,
+			wantErr: "grouping by schedule requires",
+		},
+		{
+			name:    "unsupported group-by value",
+			groupBy: "milestone",
+			on: `on:
+  issues:
+    types: [labeled]`,
+			wantErr: "unsupported concurrency group-by value",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateConcurrencyGroupBy(tt.groupBy, tt.on)
+			if tt.wantErr == "" {
+				assert.NoError(t, err, "group-by should be valid")
+				return
+			}
+			require.Error(t, err, "group-by should be rejected")
+			assert.Contains(t, err.Error(), tt.wantErr, "error message should explain the problem")
+		})
+	}
+}
+
+func TestMatrixCancelInProgressWarnings(t *testing.T) {
+	matrixJob := func(concurrency any) map[string]any {
+		return map[string]any{
+			"runs-on":     "ubuntu-latest",
+			"strategy":    map[string]any{"matrix": map[string]any{"os": []any{"linux", "windows"}}},
+			"concurrency": concurrency,
+		}
+	}
+
+	tests := []struct {
+		name         string
+		jobs         map[string]any
+		expectedJobs []string
+	}{
+		{
+			name: "matrix group with cancel-in-progress warns",
+			jobs: map[string]any{
+				"build": matrixJob(map[string]any{"group": "build-${{ matrix.os }}", "cancel-in-progress": true}),
+			},
+			expectedJobs: []string{"build"},
+		},
+		{
+			name: "expression cancel-in-progress warns",
+			jobs: map[string]any{
+				"build": matrixJob(map[string]any{"group": "build-${{ matrix.os }}", "cancel-in-progress": "${{ github.event_name == 'pull_request' }}"}),
+			},
+			expectedJobs: []string{"build"},
+		},
+		{
+			name: "matrix group without cancel-in-progress does not warn",
+			jobs: map[string]any{
+				"build": matrixJob(map[string]any{"group": "build-${{ matrix.os }}"}),
+			},
+		},
+		{
+			name: "cancel-in-progress without matrix values in group does not warn",
+			jobs: map[string]any{
+				"build": matrixJob(map[string]any{"group": "build-${{ github.ref }}", "cancel-in-progress": true}),
+			},
+		},
+		{
+			name: "job without matrix does not warn",
+			jobs: map[string]any{
+				"build": map[string]any{
+					"concurrency": map[string]any{"group": "build-${{ matrix.os }}", "cancel-in-progress": true},
+				},
+			},
+		},
+		{
+			name: "string concurrency does not warn",
+			jobs: map[string]any{
+				"build": matrixJob("build-${{ matrix.os }}"),
+			},
+		},
+		{
+			name: "multiple jobs are reported in sorted order",
+			jobs: map[string]any{
+				"test":  matrixJob(map[string]any{"group": "test-${{ matrix.os }}", "cancel-in-progress": true}),
+				"build": matrixJob(map[string]any{"group": "build-${{ matrix.os }}", "cancel-in-progress": "true"}),
+			},
+			expectedJobs: []string{"build", "test"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			warnings := matrixCancelInProgressWarnings(&WorkflowData{Jobs: tt.jobs}, false)
+			require.Len(t, warnings, len(tt.expectedJobs), "warning count should match")
+			for i, jobName := range tt.expectedJobs {
+				assert.Contains(t, warnings[i], "job '"+jobName+"'", "warning should name the job")
+				assert.Contains(t, warnings[i], "only cancels in-progress legs with the same matrix values", "warning should explain per-leg cancellation")
+			}
+		})
+	}
+}
+
+func TestMatrixCancelInProgressWarningsForGeneratedGroups(t *testing.T) {
+	matrixJob := map[string]any{
+		"runs-on":  "ubuntu-latest",
+		"strategy": map[string]any{"matrix": map[string]any{"os": []any{"linux", "windows"}}},
+	}
+	prOn := "on:\n  pull_request:\n    types: [opened]"
+
+	tests := []struct {
+		name         string
+		workflowData *WorkflowData
+		wantWarning  bool
+	}{
+		{
+			name:         "pull request workflow cancels generated matrix groups",
+			workflowData: &WorkflowData{On: prOn, ConcurrencyMatrix: true, Jobs: map[string]any{"build": matrixJob}},
+			wantWarning:  true,
+		},
+		{
+			name:         "cancel-in-progress override enables cancellation",
+			workflowData: &WorkflowData{On: "on:\n  push:", ConcurrencyMatrix: true, ConcurrencyCancelInProgress: "true", Jobs: map[string]any{"build": matrixJob}},
+			wantWarning:  true,
+		},
+		{
+			name:         "push workflow does not cancel generated matrix groups",
+			workflowData: &WorkflowData{On: "on:\n  push:", ConcurrencyMatrix: true, Jobs: map[string]any{"build": matrixJob}},
+		},
+		{
+			name:         "no generated group without concurrency.matrix",
+			workflowData: &WorkflowData{On: prOn, Jobs: map[string]any{"build": matrixJob}},
+		},
+		{
+			name:         "no generated group with concurrency: none",
+			workflowData: &WorkflowData{On: prOn, ConcurrencyMatrix: true, ConcurrencyDisabled: true, Jobs: map[string]any{"build": matrixJob}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			warnings := matrixCancelInProgressWarnings(tt.workflowData, false)
+			if !tt.wantWarning {
+				assert.Empty(t, warnings, "no warning expected")
+				return
+			}
+			require.Len(t, warnings, 1, "the generated matrix group should be reported")
+			assert.Contains(t, warnings[0], "job 'build' gets a per-leg concurrency group from concurrency.matrix", "warning should name the job")
+			assert.Contains(t, warnings[0], "only cancels the in-progress leg with the same index", "warning should explain per-leg cancellation")
+		})
+	}
+}
+
+func TestValidateConcurrencyGroupLength(t *testing.T) {
+	longName := strings.Repeat("a", 220)
+
+	tests := []struct {
+		name         string
+		group        string
+		workflowName string
+		wantErr      bool
+	}{
+		{
+			name:         "short generated group",
+			group:        "gh-aw-${{ github.workflow }}-${{ github.event.issue.number || github.run_id }}",
+			workflowName: "Issue Triage",
+		},
+		{
+			name:         "long workflow name with expression",
+			group:        "gh-aw-${{ github.workflow }}-${{ github.event.issue.number || github.run_id }}",
+			workflowName: longName,
+			wantErr:      true,
+		},
+		{
+			name:         "long workflow name without other expressions",
+			group:        "gh-aw-${{ github.workflow }}",
+			workflowName: longName,
+		},
+		{
+			name:    "long static custom group",
+			group:   "deploy-" + strings.Repeat("x", 250),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateConcurrencyGroupLength(tt.group, tt.workflowName)
+			if !tt.wantErr {
+				assert.NoError(t, err, "group should fit within the length limit")
+				return
+			}
+			require.Error(t, err, "group should be reported as too long")
+			var validationErr *WorkflowValidationError
+			require.ErrorAs(t, err, &validationErr, "error should be a validation error")
+			assert.Equal(t, "concurrency.group", validationErr.Field, "error should reference the concurrency group")
+			assert.Contains(t, err.Error(), "255 character limit", "error should mention the limit")
+		})
+	}
+}
+
+func TestScheduleCronExpressions(t *testing.T) {
+	on := "on:\n  schedule:\n    - cron: \"0 1 * * *\"\n    - cron: \"30 13 * * 1-5\"\n  workflow_dispatch:"
+	assert.Equal(t, []string{"0 1 * * *", "30 13 * * 1-5"}, scheduleCronExpressions(workflowTriggers(on)), "cron expressions should be returned in order")
+	assert.Empty(t, scheduleCronExpressions(workflowTriggers("on:\n  issues:\n    types: [opened]")), "workflows without a schedule have no cron expressions")
+	assert.Empty(t, scheduleCronExpressions(workflowTriggers("on: push")), "inline triggers have no cron expressions")
+}
+
+func TestSerializedScheduleWarning(t *testing.T) {
+	twoSchedules := "on:\n  schedule:\n    - cron: \"0 1 * * *\"\n    - cron: \"0 13 * * *\""
+	copilot := &EngineConfig{ID: "copilot"}
+
+	tests := []struct {
+		name         string
+		workflowData *WorkflowData
+		wantWarning  bool
+	}{
+		{
+			name:         "several schedules with the default group",
+			workflowData: &WorkflowData{On: twoSchedules, EngineConfig: copilot},
+			wantWarning:  true,
+		},
+		{
+			name:         "single schedule",
+			workflowData: &WorkflowData{On: "on:\n  schedule:\n    - cron: \"0 1 * * *\"", EngineConfig: copilot},
+		},
+		{
+			name:         "job discriminator provides a shard key",
+			workflowData: &WorkflowData{On: twoSchedules, EngineConfig: copilot, ConcurrencyJobDiscriminator: "${{ github.event.schedule }}"},
+		},
+		{
+			name:         "grouped per schedule",
+			workflowData: &WorkflowData{On: twoSchedules, EngineConfig: copilot, ConcurrencyGroupBy: "schedule"},
+		},
+		{
+			name:         "explicit engine concurrency",
+			workflowData: &WorkflowData{On: twoSchedules, EngineConfig: &EngineConfig{ID: "copilot", Concurrency: "concurrency:\n  group: custom"}},
+		},
+		{
+			name:         "special trigger skips the default group",
+			workflowData: &WorkflowData{On: twoSchedules + "\n  issues:\n    types: [opened]", EngineConfig: copilot},
+		},
+		{
+			name:         "concurrency disabled",
+			workflowData: &WorkflowData{On: twoSchedules, EngineConfig: copilot, ConcurrencyDisabled: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			warning := serializedScheduleWarning(tt.workflowData)
+			if !tt.wantWarning {
+				assert.Empty(t, warning, "no warning expected")
+				return
+			}
+			assert.Contains(t, warning, "2 schedule entries", "warning should count the schedules")
+			assert.Contains(t, warning, "group-by: schedule", "warning should suggest grouping per schedule")
+		})
+	}
+}
+
+func TestPullRequestTargetCancelInProgressWarning(t *testing.T) {
+	prTarget := "on:\n  pull_request_target:\n    types: [opened, synchronize]"
+
+	tests := []struct {
+		name         string
+		workflowData *WorkflowData
+		wantWarning  bool
+	}{
+		{
+			name:         "explicit opt-in",
+			workflowData: &WorkflowData{On: prTarget, ConcurrencyCancelInProgress: "true"},
+			wantWarning:  true,
+		},
+		{
+			name:         "expression opt-in",
+			workflowData: &WorkflowData{On: prTarget, ConcurrencyCancelInProgress: "${{ github.event.action == 'synchronize' }}"},
+			wantWarning:  true,
+		},
+		{
+			name:         "default without override",
+			workflowData: &WorkflowData{On: prTarget},
+		},
+		{
+			name:         "explicit opt-out",
+			workflowData: &WorkflowData{On: prTarget, ConcurrencyCancelInProgress: "false"},
+		},
+		{
+			name:         "plain pull_request opt-in",
+			workflowData: &WorkflowData{On: "on:\n  pull_request:\n    types: [opened]", ConcurrencyCancelInProgress: "true"},
+		},
+		{
+			name:         "custom concurrency block",
+			workflowData: &WorkflowData{On: prTarget, Concurrency: "concurrency:\n  group: custom\n  cancel-in-progress: true"},
+		},
+		{
+			name:         "concurrency disabled",
+			workflowData: &WorkflowData{On: prTarget, ConcurrencyCancelInProgress: "true", ConcurrencyDisabled: true},
+		},
+		{
+			name:         "cancel-policy opt-in",
+			workflowData: &WorkflowData{On: prTarget, ConcurrencyCancelPolicy: map[string]bool{"pull_request_target": true}},
+			wantWarning:  true,
+		},
+		{
+			name:         "cancel-in-progress override wins over cancel-policy",
+			workflowData: &WorkflowData{On: prTarget, ConcurrencyCancelInProgress: "false", ConcurrencyCancelPolicy: map[string]bool{"pull_request_target": true}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			warning := pullRequestTargetCancelInProgressWarning(tt.workflowData)
+			if !tt.wantWarning {
+				assert.Empty(t, warning, "no warning expected")
+				return
+			}
+			assert.Contains(t, warning, "pull_request_target", "warning should name the trigger")
+			assert.Contains(t, warning, tt.workflowData.ConcurrencyCancelInProgress, "warning should show the configured value")
+		})
+	}
+}
+
+func TestValidateConcurrencyKeyFallbacks(t *testing.T) {
+	tests := []struct {
+		name        string
+		data        *WorkflowData
+		errContains string
+	}{
+		{
+			name: "generated mixed issue and discussion key",
+			data: &WorkflowData{On: "on:\n  issues:\n    types: [opened]\n  discussion:\n    types: [created]"},
+		},
+		{
+			name: "custom key with unknown identifier first",
+			data: &WorkflowData{
+				On:             "on:\n  issues:\n    types: [opened]",
+				ConcurrencyKey: "${{ github.event.client_payload.id || github.event.issue.number }}",
+			},
+		},
+		{
+			name: "branch ref shadows issue number",
+			data: &WorkflowData{
+				On:             "on:\n  issues:\n    types: [opened]\n  push:",
+				ConcurrencyKey: "${{ github.ref || github.event.issue.number }}",
+			},
+			errContains: "issues events always resolve to github.ref, so github.event.issue.number later in the key is never used",
+		},
+		{
+			name: "run ID shadows discussion number",
+			data: &WorkflowData{
+				On:             "on:\n  discussion:\n    types: [created]",
+				ConcurrencyKey: "${{ github.run_id || github.event.discussion.number }}",
+			},
+			errContains: "discussion events always resolve to github.run_id",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateConcurrencyKeyFallbacks(tt.data, false)
+			if tt.errContains == "" {
+				assert.NoError(t, err, "coherent key should pass validation")
+				return
+			}
+			require.Error(t, err, "shadowed identifier should fail validation")
+			assert.Contains(t, err.Error(), tt.errContains, "error should name the shadowed identifier")
+			assert.Contains(t, err.Error(), "concurrency.key", "error should point at the custom key")
+		})
+	}
+}
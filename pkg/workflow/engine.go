@@ -236,16 +236,7 @@ func (c *Compiler) ExtractEngineConfig(frontmatter map[string]any) (string, *Eng
 			}
 
 			// Extract optional 'env' field (object/map of strings)
-			if env, hasEnv := engineObj["env"]; hasEnv {
-				if envMap, ok := env.(map[string]any); ok {
-					config.Env = make(map[string]string)
-					for key, value := range envMap {
-						if valueStr, ok := value.(string); ok {
-							config.Env[key] = valueStr
-						}
-					}
-				}
-			}
+			config.Env = getMapFieldAsStringMap(engineObj, "env")
 
 			// Extract optional 'config' field (additional TOML configuration)
 			if config_field, hasConfig := engineObj["config"]; hasConfig {
@@ -3,10 +3,8 @@ package workflow
 import (
 	"errors"
 	"fmt"
-	"os"
 	"strings"
 
-	"github.com/github/gh-aw/pkg/console"
 	"github.com/github/gh-aw/pkg/logger"
 )
 
@@ -86,8 +84,7 @@ func (c *Compiler) checkFirewallDisable(networkPermissions *NetworkPermissions)
 			}
 
 			// In non-strict mode, emit a warning
-			fmt.Fprintln(os.Stderr, console.FormatWarningMessage(message))
-			c.IncrementWarningCount()
+			c.emitWarning(message)
 		}
 	}
 
@@ -9,6 +9,7 @@
 // # Validation Functions
 //
 //   - validateEngine() - Validates that a given engine ID is supported
+//   - validateEngineID() - Validates that a resolved engine ID is a known engine
 //   - validateSingleEngineSpecification() - Validates that only one engine field exists across all files
 //
 // # Validation Pattern: Engine Registry
@@ -36,6 +37,7 @@ package workflow
 import (
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/github/gh-aw/pkg/constants"
@@ -44,6 +46,32 @@ import (
 
 var engineValidationLog = newValidationLogger("engine")
 
+// validateEngineID validates that id refers to a known engine in the global engine registry.
+// Prefix matches (e.g. "codex-experimental") are accepted for backward compatibility.
+// Unknown IDs return a validation error with a did-you-mean suggestion when one is available.
+func validateEngineID(id string) error {
+	registry := GetGlobalEngineRegistry()
+	if registry.IsValidEngine(id) {
+		return nil
+	}
+	if matched, err := registry.GetEngineByPrefix(id); err == nil {
+		engineValidationLog.Printf("Engine ID %q matched via prefix to engine %q", id, matched.GetID())
+		return nil
+	}
+
+	validEngines := registry.GetSupportedEngines()
+	sort.Strings(validEngines)
+	engineValidationLog.Printf("Unknown engine ID: %q", id)
+
+	suggestion := fmt.Sprintf("Use one of the known engines: %s.", strings.Join(validEngines, ", "))
+	if suggestions := parser.FindClosestMatches(id, validEngines, 1); len(suggestions) > 0 {
+		suggestion = fmt.Sprintf("Did you mean: %s? %s", suggestions[0], suggestion)
+	}
+	suggestion += " See: " + string(constants.DocsEnginesURL)
+
+	return NewValidationError("engine.id", id, "unknown engine ID", suggestion)
+}
+
 // validateEngineInlineDefinition validates an inline engine definition parsed from
 // engine.runtime + optional engine.provider in the workflow frontmatter.
 // Returns an error if:
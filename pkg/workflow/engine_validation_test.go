@@ -3,6 +3,7 @@
 package workflow
 
 import (
+	"errors"
 	"strings"
 	"testing"
 )
@@ -495,3 +496,46 @@ func TestValidatePluginSupportErrorMessage(t *testing.T) {
 		t.Errorf("Error message should provide actionable fixes, got: %s", errorMsg)
 	}
 }
+
+// TestValidateEngineID tests the validateEngineID function
+func TestValidateEngineID(t *testing.T) {
+	tests := []struct {
+		name           string
+		engineID       string
+		expectError    bool
+		expectedSubstr string
+	}{
+		{name: "copilot is known", engineID: "copilot"},
+		{name: "claude is known", engineID: "claude"},
+		{name: "codex is known", engineID: "codex"},
+		{name: "prefix match is accepted", engineID: "codex-experimental"},
+		{name: "typo suggests closest engine", engineID: "copilt", expectError: true, expectedSubstr: "Did you mean: copilot?"},
+		{name: "unknown engine lists known engines", engineID: "nonexistent-xyz", expectError: true, expectedSubstr: "Use one of the known engines:"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateEngineID(tt.engineID)
+			if !tt.expectError {
+				if err != nil {
+					t.Errorf("Expected no error for engine ID %q, got: %v", tt.engineID, err)
+				}
+				return
+			}
+
+			if err == nil {
+				t.Fatalf("Expected error for engine ID %q, got nil", tt.engineID)
+			}
+			var validationErr *WorkflowValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("Expected WorkflowValidationError, got %T", err)
+			}
+			if validationErr.Field != "engine.id" {
+				t.Errorf("Expected field 'engine.id', got %q", validationErr.Field)
+			}
+			if !strings.Contains(err.Error(), tt.expectedSubstr) {
+				t.Errorf("Expected error to contain %q, got: %s", tt.expectedSubstr, err.Error())
+			}
+		})
+	}
+}
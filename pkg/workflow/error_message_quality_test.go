@@ -142,7 +142,8 @@ func TestErrorMessageQuality(t *testing.T) {
 			name: "invalid secret name includes format and example",
 			testFunc: func() error {
 				secrets := []string{"my-secret"} // Invalid: contains hyphen
-				return validateSecretReferences(secrets)
+				_, err := validateSecretReferences(secrets, SecretNamingStrict, false)
+				return err
 			},
 			shouldContain: []string{
 				"invalid secret name",
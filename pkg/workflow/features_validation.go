@@ -5,12 +5,15 @@
 // This file validates feature flag values to ensure they meet requirements
 // before being used in workflow compilation. It ensures that:
 //   - action-tag uses full 40-character SHA when specified
+//   - Numeric features stay within the bounds declared in the feature registry
 //   - Other feature-specific constraints are met
 //
 // # Validation Functions
 //
 //   - validateFeatures() - Validates all feature flags in WorkflowData
 //   - validateActionTag() - Validates action-tag is a full SHA
+//   - validateFeatureRanges() - Validates numeric features against their declared bounds
+//   - validateFeatureReferences() - Validates that referenced feature names exist
 //   - isValidFullSHA() - Checks if a string is a valid 40-character SHA
 //
 // # When to Add Validation Here
@@ -25,12 +28,56 @@ package workflow
 import (
 	"fmt"
 	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/parser"
 )
 
 var featuresValidationLog = newValidationLogger("features")
 
 var shaRegex = regexp.MustCompile("^[0-9a-f]{40}$")
 
+// featureBounds declares the inclusive range accepted by a numeric feature. Integer
+// features must also be whole numbers.
+type featureBounds struct {
+	min     float64
+	max     float64
+	integer bool
+}
+
+// featureDefinition describes a feature recognized by the compiler. Features without
+// bounds accept any value and skip range validation.
+type featureDefinition struct {
+	name   string
+	bounds *featureBounds
+}
+
+// featureRegistry lists the features recognized by the compiler, including boolean
+// feature flags and value features such as action-tag
+var featureRegistry = []featureDefinition{
+	{name: string(constants.MCPScriptsFeatureFlag)},
+	{name: string(constants.MCPGatewayFeatureFlag)},
+	{name: string(constants.DisableXPIAPromptFeatureFlag)},
+	{name: string(constants.CopilotRequestsFeatureFlag)},
+	{name: "action-mode"},
+	{name: "action-tag"},
+}
+
+// knownFeatureNames lists the names of the features in featureRegistry
+var knownFeatureNames = featureNames(featureRegistry)
+
+// featureNames returns the names of the given feature definitions
+func featureNames(registry []featureDefinition) []string {
+	names := make([]string, 0, len(registry))
+	for _, def := range registry {
+		names = append(names, def.name)
+	}
+	return names
+}
+
 // validateFeatures validates all feature flags in the workflow data
 func validateFeatures(data *WorkflowData) error {
 	if data == nil || data.Features == nil {
@@ -50,6 +97,11 @@ func validateFeatures(data *WorkflowData) error {
 		featuresValidationLog.Print("Action-tag validation passed")
 	}
 
+	if err := validateFeatureRanges(data.Features, featureRegistry); err != nil {
+		featuresValidationLog.Printf("Feature range validation failed: %v", err)
+		return err
+	}
+
 	featuresValidationLog.Print("Features validation completed successfully")
 	return nil
 }
@@ -90,6 +142,71 @@ func validateActionTag(value any) error {
 	return nil
 }
 
+// validateFeatureRanges validates the merged features against the bounds declared in the
+// registry. Feature names are matched case-insensitively, matching isFeatureEnabled, and
+// features without declared bounds are skipped. Features are checked in sorted order so the
+// reported error is deterministic.
+func validateFeatureRanges(features map[string]any, registry []featureDefinition) error {
+	keys := make([]string, 0, len(features))
+	for key := range features {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		name := strings.ToLower(strings.TrimSpace(key))
+		idx := slices.IndexFunc(registry, func(def featureDefinition) bool { return def.name == name })
+		if idx < 0 || registry[idx].bounds == nil {
+			continue
+		}
+		bounds := registry[idx].bounds
+		value := features[key]
+		featuresValidationLog.Printf("Validating feature range: %s=%v", name, value)
+
+		if err := bounds.check(value, name); err != nil {
+			return NewValidationError(
+				"features."+name,
+				fmt.Sprintf("%v", value),
+				err.Error(),
+				fmt.Sprintf("Set %s to a %s between %g and %g. Example:\nfeatures:\n  %s: %g", name, bounds.kind(), bounds.min, bounds.max, name, bounds.min),
+			)
+		}
+	}
+	return nil
+}
+
+// check validates that value is a number of the right kind within the bounds
+func (b *featureBounds) check(value any, name string) error {
+	if b.integer {
+		n, ok := parseIntValue(value)
+		if f, isFloat := value.(float64); !ok || (isFloat && f != float64(n)) {
+			return fmt.Errorf("%s must be a whole number, got %v", name, value)
+		}
+		return validateIntRange(n, int(b.min), int(b.max), name)
+	}
+
+	var f float64
+	switch v := value.(type) {
+	case float64:
+		f = v
+	default:
+		n, ok := parseIntValue(value)
+		if !ok {
+			return fmt.Errorf("%s must be a number, got %v", name, value)
+		}
+		f = float64(n)
+	}
+	return validateFloatRange(f, b.min, b.max, name)
+}
+
+// kind describes the kind of number accepted by the bounds, for suggestions
+func (b *featureBounds) kind() string {
+	if b.integer {
+		return "whole number"
+	}
+	return "number"
+}
+
 // isValidFullSHA checks if a string is a valid 40-character hexadecimal SHA
 func isValidFullSHA(s string) bool {
 	if len(s) != 40 {
@@ -97,3 +214,37 @@ func isValidFullSHA(s string) bool {
 	}
 	return shaRegex.MatchString(s)
 }
+
+// validateFeatureReferences validates that every referenced feature name exists either
+// in the merged features map or in the set of features known to the compiler. A typo in a
+// referenced name would otherwise silently evaluate as disabled, so unknown names return a
+// validation error with a did-you-mean suggestion. Names are compared case-insensitively,
+// matching isFeatureEnabled.
+func validateFeatureReferences(references []string, features map[string]any) error {
+	validNames := slices.Clone(knownFeatureNames)
+	for name := range features {
+		validNames = append(validNames, strings.ToLower(name))
+	}
+	sort.Strings(validNames)
+	validNames = slices.Compact(validNames)
+
+	for _, reference := range references {
+		name := strings.ToLower(strings.TrimSpace(reference))
+		if slices.Contains(validNames, name) {
+			continue
+		}
+
+		featuresValidationLog.Printf("Unknown feature reference: %s", reference)
+		suggestion := "Known features: " + strings.Join(validNames, ", ")
+		if matches := parser.FindClosestMatches(name, validNames, 1); len(matches) > 0 {
+			suggestion = fmt.Sprintf("Did you mean: %s? %s", matches[0], suggestion)
+		}
+		return NewValidationError(
+			"features",
+			reference,
+			fmt.Sprintf("feature '%s' is referenced but is not defined in features or known to the compiler", reference),
+			suggestion,
+		)
+	}
+	return nil
+}
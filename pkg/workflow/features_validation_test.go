@@ -242,3 +242,87 @@ func TestValidateFeatures(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateFeatureReferences(t *testing.T) {
+	features := map[string]any{"my-custom-flag": true}
+
+	tests := []struct {
+		name        string
+		references  []string
+		wantErr     bool
+		errContains string
+	}{
+		{name: "no references", references: nil},
+		{name: "known compiler feature", references: []string{"mcp-gateway"}},
+		{name: "feature defined in merged map", references: []string{"my-custom-flag"}},
+		{name: "case-insensitive match", references: []string{"MCP-Gateway"}},
+		{name: "typo suggests closest feature", references: []string{"mcp-gatway"}, wantErr: true, errContains: "Did you mean: mcp-gateway?"},
+		{name: "typo in merged feature", references: []string{"my-custom-flg"}, wantErr: true, errContains: "Did you mean: my-custom-flag?"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateFeatureReferences(tt.references, features)
+			if !tt.wantErr {
+				if err != nil {
+					t.Errorf("Expected no error, got: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("Expected an error for unknown feature reference")
+			}
+			if !strings.Contains(err.Error(), tt.errContains) {
+				t.Errorf("Expected error to contain %q, got: %s", tt.errContains, err.Error())
+			}
+		})
+	}
+}
+
+func TestValidateFeatureRanges(t *testing.T) {
+	registry := []featureDefinition{
+		{name: "action-tag"},
+		{name: "max-retries", bounds: &featureBounds{min: 0, max: 10, integer: true}},
+		{name: "sample-rate", bounds: &featureBounds{min: 0, max: 1}},
+	}
+
+	tests := []struct {
+		name        string
+		features    map[string]any
+		wantErr     bool
+		errContains string
+	}{
+		{name: "no features", features: nil},
+		{name: "unbounded feature skips check", features: map[string]any{"action-tag": 42}},
+		{name: "unknown feature skips check", features: map[string]any{"my-custom-flag": 1000}},
+		{name: "integer at bounds", features: map[string]any{"max-retries": 10}},
+		{name: "integer parsed as uint64", features: map[string]any{"max-retries": uint64(3)}},
+		{name: "whole float for integer feature", features: map[string]any{"max-retries": 4.0}},
+		{name: "float within range", features: map[string]any{"sample-rate": 0.25}},
+		{name: "int for float feature", features: map[string]any{"sample-rate": 1}},
+		{name: "case-insensitive name", features: map[string]any{"Max-Retries": 11}, wantErr: true, errContains: "features.max-retries"},
+		{name: "integer above max", features: map[string]any{"max-retries": 11}, wantErr: true, errContains: "max-retries must be between 0 and 10, got 11"},
+		{name: "fractional value for integer feature", features: map[string]any{"max-retries": 2.5}, wantErr: true, errContains: "must be a whole number"},
+		{name: "string value for integer feature", features: map[string]any{"max-retries": "3"}, wantErr: true, errContains: "must be a whole number"},
+		{name: "float below min", features: map[string]any{"sample-rate": -0.5}, wantErr: true, errContains: "sample-rate must be between 0 and 1, got -0.5"},
+		{name: "string value for float feature", features: map[string]any{"sample-rate": "high"}, wantErr: true, errContains: "must be a number"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateFeatureRanges(tt.features, registry)
+			if !tt.wantErr {
+				if err != nil {
+					t.Errorf("Expected no error, got: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("Expected an error for out-of-range feature value")
+			}
+			if !strings.Contains(err.Error(), tt.errContains) {
+				t.Errorf("Expected error to contain %q, got: %s", tt.errContains, err.Error())
+			}
+		})
+	}
+}
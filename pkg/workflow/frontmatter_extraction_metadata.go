@@ -296,16 +296,7 @@ func extractPluginsFromFrontmatter(frontmatter map[string]any) *PluginInfo {
 					mcpConfig = &PluginMCPConfig{}
 
 					// Extract env variables
-					if envAny, hasEnv := mcpMap["env"]; hasEnv {
-						if envMap, ok := envAny.(map[string]any); ok {
-							mcpConfig.Env = make(map[string]string)
-							for k, v := range envMap {
-								if vStr, ok := v.(string); ok {
-									mcpConfig.Env[k] = vStr
-								}
-							}
-						}
-					}
+					mcpConfig.Env = getMapFieldAsStringMap(mcpMap, "env")
 				}
 			}
 
@@ -296,16 +296,7 @@ func (c *Compiler) extractAgentSandboxConfig(agentVal any) *AgentSandboxConfig {
 	}
 
 	// Extract env (environment variables to set on the step)
-	if envVal, hasEnv := agentObj["env"]; hasEnv {
-		if envObj, ok := envVal.(map[string]any); ok {
-			agentConfig.Env = make(map[string]string)
-			for key, value := range envObj {
-				if valueStr, ok := value.(string); ok {
-					agentConfig.Env[key] = valueStr
-				}
-			}
-		}
-	}
+	agentConfig.Env = getMapFieldAsStringMap(agentObj, "env")
 
 	// Extract mounts (container mounts for AWF)
 	if mountsVal, hasMounts := agentObj["mounts"]; hasMounts {
@@ -416,16 +407,7 @@ func (c *Compiler) extractMCPGatewayConfig(mcpVal any) *MCPGatewayRuntimeConfig
 	}
 
 	// Extract env (environment variables)
-	if envVal, hasEnv := mcpObj["env"]; hasEnv {
-		if envObj, ok := envVal.(map[string]any); ok {
-			mcpConfig.Env = make(map[string]string)
-			for key, value := range envObj {
-				if valueStr, ok := value.(string); ok {
-					mcpConfig.Env[key] = valueStr
-				}
-			}
-		}
-	}
+	mcpConfig.Env = getMapFieldAsStringMap(mcpObj, "env")
 
 	// Extract mounts (volume mounts for container)
 	if mountsVal, hasMounts := mcpObj["mounts"]; hasMounts {
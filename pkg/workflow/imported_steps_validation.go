@@ -25,12 +25,9 @@ package workflow
 
 import (
 	"fmt"
-	"os"
 	"strings"
 
 	"github.com/goccy/go-yaml"
-
-	"github.com/github/gh-aw/pkg/console"
 )
 
 var importedStepsValidationLog = newValidationLogger("imported_steps")
@@ -106,8 +103,7 @@ func (c *Compiler) validateCheckoutPersistCredentials(frontmatter map[string]any
 	}
 
 	// Non-strict mode: emit a warning
-	fmt.Fprintln(os.Stderr, console.FormatWarningMessage(msg))
-	c.IncrementWarningCount()
+	c.emitWarning(msg)
 	return nil
 }
 
@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"maps"
+	"reflect"
+	"slices"
 	"sort"
 	"strings"
 	"sync"
@@ -768,9 +770,55 @@ func mergeMessagesConfig(result, imported *SafeOutputMessagesConfig) *SafeOutput
 }
 
 // MergeFeatures merges features configurations from imports with top-level features
-// Features from top-level take precedence over imported features
+// Features from top-level take precedence over imported features, and earlier feature
+// maps take precedence over later ones (repository config defaults are passed last).
+// Empty or whitespace-only feature names are rejected in every layer.
+// A feature defined in several layers is taken whole from the layer with precedence;
+// use MergeFeaturesDeep to merge map-valued features key by key, or MergeFeaturesStrict
+// to reject conflicting definitions.
 func (c *Compiler) MergeFeatures(topFeatures map[string]any, importedFeatures []map[string]any) (map[string]any, error) {
-	importsLog.Print("Merging features from imports")
+	return mergeFeatures(topFeatures, importedFeatures, featureMergeShallow)
+}
+
+// MergeFeaturesDeep merges features like MergeFeatures, except that when a feature holds a
+// map in several layers the maps are merged recursively: sub-keys from imports are kept
+// unless a layer with precedence sets them too, in which case that layer's value wins.
+func (c *Compiler) MergeFeaturesDeep(topFeatures map[string]any, importedFeatures []map[string]any) (map[string]any, error) {
+	return mergeFeatures(topFeatures, importedFeatures, featureMergeDeep)
+}
+
+// MergeFeaturesStrict merges features like MergeFeatures, but instead of resolving a
+// feature defined with different values in several layers by precedence, it returns an
+// error listing every conflicting feature with its competing values. Identical
+// definitions in several layers are not conflicts.
+func (c *Compiler) MergeFeaturesStrict(topFeatures map[string]any, importedFeatures []map[string]any) (map[string]any, error) {
+	return mergeFeatures(topFeatures, importedFeatures, featureMergeStrict)
+}
+
+// featureMergeMode selects how mergeFeatures handles a feature defined in several layers
+type featureMergeMode int
+
+const (
+	// featureMergeShallow keeps the value of the layer with precedence
+	featureMergeShallow featureMergeMode = iota
+	// featureMergeDeep merges map values recursively, the layer with precedence winning per key
+	featureMergeDeep
+	// featureMergeStrict rejects features defined with different values
+	featureMergeStrict
+)
+
+// mergeFeatures implements MergeFeatures, MergeFeaturesDeep and MergeFeaturesStrict
+func mergeFeatures(topFeatures map[string]any, importedFeatures []map[string]any, mode featureMergeMode) (map[string]any, error) {
+	importsLog.Printf("Merging features from imports: mode=%d", mode)
+
+	if err := validateFeatureKeys(topFeatures); err != nil {
+		return nil, err
+	}
+	for _, importedFeaturesMap := range importedFeatures {
+		if err := validateFeatureKeys(importedFeaturesMap); err != nil {
+			return nil, err
+		}
+	}
 
 	// If no imported features, return top-level features as-is
 	if len(importedFeatures) == 0 {
@@ -778,6 +826,12 @@ func (c *Compiler) MergeFeatures(topFeatures map[string]any, importedFeatures []
 		return topFeatures, nil
 	}
 
+	if mode == featureMergeStrict {
+		if err := validateNoFeatureConflicts(topFeatures, importedFeatures); err != nil {
+			return nil, err
+		}
+	}
+
 	// Start with top-level features or create a new map
 	result := make(map[string]any)
 	if topFeatures != nil {
@@ -792,9 +846,18 @@ func (c *Compiler) MergeFeatures(topFeatures map[string]any, importedFeatures []
 		// Merge features - top-level features take precedence over imported ones
 		for featureName, featureValue := range importedFeaturesMap {
 			// Only add feature if it's not already defined in top-level
-			if _, exists := result[featureName]; !exists {
+			existingValue, exists := result[featureName]
+			if !exists {
 				importsLog.Printf("Merging feature from import: %s", featureName)
 				result[featureName] = featureValue
+				continue
+			}
+
+			existingMap, existingIsMap := existingValue.(map[string]any)
+			importedMap, importedIsMap := featureValue.(map[string]any)
+			if mode == featureMergeDeep && existingIsMap && importedIsMap {
+				importsLog.Printf("Deep merging imported feature (top-level keys take precedence): %s", featureName)
+				result[featureName] = deepMergeMaps(importedMap, existingMap)
 			} else {
 				importsLog.Printf("Skipping imported feature (top-level takes precedence): %s", featureName)
 			}
@@ -804,3 +867,63 @@ func (c *Compiler) MergeFeatures(topFeatures map[string]any, importedFeatures []
 	importsLog.Printf("Successfully merged features: total=%d", len(result))
 	return result, nil
 }
+
+// validateNoFeatureConflicts returns an error listing the features that are defined with
+// different values in more than one layer, with the value from each defining layer
+func validateNoFeatureConflicts(topFeatures map[string]any, importedFeatures []map[string]any) error {
+	type featureDefinition struct {
+		layer string
+		value any
+	}
+
+	definitions := make(map[string][]featureDefinition)
+	addLayer := func(layer string, features map[string]any) {
+		for featureName, featureValue := range features {
+			definitions[featureName] = append(definitions[featureName], featureDefinition{layer: layer, value: featureValue})
+		}
+	}
+	addLayer("top-level", topFeatures)
+	for i, importedFeaturesMap := range importedFeatures {
+		addLayer(fmt.Sprintf("import %d", i+1), importedFeaturesMap)
+	}
+
+	collector := NewErrorCollector(false)
+	for _, featureName := range slices.Sorted(maps.Keys(definitions)) {
+		featureDefinitions := definitions[featureName]
+		conflicting := false
+		for _, definition := range featureDefinitions[1:] {
+			if !reflect.DeepEqual(definition.value, featureDefinitions[0].value) {
+				conflicting = true
+				break
+			}
+		}
+		if !conflicting {
+			continue
+		}
+
+		values := make([]string, 0, len(featureDefinitions))
+		for _, definition := range featureDefinitions {
+			values = append(values, fmt.Sprintf("%s: %v", definition.layer, definition.value))
+		}
+		importsLog.Printf("Feature %s has conflicting values in %d layers", featureName, len(featureDefinitions))
+		_ = collector.Add(fmt.Errorf("feature '%s' has conflicting values (%s)", featureName, strings.Join(values, ", ")))
+	}
+	return collector.FormattedError("feature conflict")
+}
+
+// validateFeatureKeys rejects empty or whitespace-only feature names, which YAML can
+// produce (e.g. `"": true`) and which never name a real feature
+func validateFeatureKeys(features map[string]any) error {
+	for featureName := range features {
+		if isEmptyOrNil(featureName) {
+			importsLog.Printf("Rejecting empty feature name: %q", featureName)
+			return NewValidationError(
+				"features",
+				fmt.Sprintf("%q", featureName),
+				"feature names cannot be empty or whitespace-only",
+				"Remove the empty key from the features section or give it a feature name. Example:\nfeatures:\n  action-mode: \"release\"",
+			)
+		}
+	}
+	return nil
+}
@@ -205,3 +205,179 @@ func TestMergeFeaturesPreservesTopLevelWhenImportsHaveSameFeature(t *testing.T)
 	assert.Equal(t, false, result["feature"], "Top-level value should be preserved")
 	assert.Len(t, result, 1, "Should have 1 feature")
 }
+
+func TestMergeFeaturesRejectsEmptyFeatureKeys(t *testing.T) {
+	tests := []struct {
+		name             string
+		topFeatures      map[string]any
+		importedFeatures []map[string]any
+	}{
+		{
+			name:        "empty top-level key without imports",
+			topFeatures: map[string]any{"": true},
+		},
+		{
+			name:             "whitespace-only top-level key",
+			topFeatures:      map[string]any{"   ": true},
+			importedFeatures: []map[string]any{{"feature": true}},
+		},
+		{
+			name:             "empty key in a later import",
+			topFeatures:      map[string]any{"feature1": true},
+			importedFeatures: []map[string]any{{"feature2": true}, {"\t": false}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			compiler := NewCompiler()
+			result, err := compiler.MergeFeatures(tt.topFeatures, tt.importedFeatures)
+			require.Error(t, err, "MergeFeatures should reject empty feature keys")
+			assert.Nil(t, result, "No features should be returned on error")
+			assert.Contains(t, err.Error(), "feature names cannot be empty or whitespace-only", "error should explain the empty key")
+		})
+	}
+}
+
+func TestMergeFeaturesDeepWithVariousValueTypes(t *testing.T) {
+	compiler := NewCompiler()
+	topFeatures := map[string]any{
+		"bool-feature":   true,
+		"string-feature": "enabled",
+		"array-feature":  []any{"x"},
+		"map-feature": map[string]any{
+			"nested":   "top",
+			"settings": map[string]any{"level": 2},
+		},
+	}
+	importedFeatures := []map[string]any{
+		{
+			"int-feature":    42,
+			"float-feature":  3.14,
+			"nil-feature":    nil,
+			"string-feature": "disabled",
+			"array-feature":  []any{"a", "b", "c"},
+			"map-feature": map[string]any{
+				"nested":   "import",
+				"extra":    "value",
+				"settings": map[string]any{"level": 1, "mode": "strict"},
+			},
+		},
+	}
+
+	result, err := compiler.MergeFeaturesDeep(topFeatures, importedFeatures)
+	require.NoError(t, err, "MergeFeaturesDeep should not error")
+	assert.Equal(t, true, result["bool-feature"], "Should include bool feature")
+	assert.Equal(t, "enabled", result["string-feature"], "Top-level scalar should take precedence")
+	assert.Equal(t, 42, result["int-feature"], "Should include int feature")
+	assert.InDelta(t, 3.14, result["float-feature"], 0.001, "Should include float feature")
+	assert.Nil(t, result["nil-feature"], "Should include nil feature")
+	assert.Equal(t, []any{"x"}, result["array-feature"], "Top-level array should replace the imported one")
+	assert.Equal(t, map[string]any{
+		"nested":   "top",
+		"extra":    "value",
+		"settings": map[string]any{"level": 2, "mode": "strict"},
+	}, result["map-feature"], "Map features should be merged recursively with top-level precedence")
+	assert.Equal(t, map[string]any{"nested": "top", "settings": map[string]any{"level": 2}}, topFeatures["map-feature"],
+		"Top-level features should not be modified")
+}
+
+func TestMergeFeaturesDeepMultipleImports(t *testing.T) {
+	compiler := NewCompiler()
+	topFeatures := map[string]any{
+		"map-feature": map[string]any{"a": 1},
+	}
+	importedFeatures := []map[string]any{
+		{"map-feature": map[string]any{"a": 2, "b": 2}},
+		{"map-feature": map[string]any{"b": 3, "c": 3}},
+	}
+
+	result, err := compiler.MergeFeaturesDeep(topFeatures, importedFeatures)
+	require.NoError(t, err, "MergeFeaturesDeep should not error")
+	assert.Equal(t, map[string]any{"a": 1, "b": 2, "c": 3}, result["map-feature"], "Earlier layers should take precedence per sub-key")
+}
+
+func TestMergeFeaturesDeepMapConflictsWithScalar(t *testing.T) {
+	compiler := NewCompiler()
+	topFeatures := map[string]any{
+		"feature": true,
+	}
+	importedFeatures := []map[string]any{
+		{"feature": map[string]any{"nested": "value"}},
+	}
+
+	result, err := compiler.MergeFeaturesDeep(topFeatures, importedFeatures)
+	require.NoError(t, err, "MergeFeaturesDeep should not error")
+	assert.Equal(t, true, result["feature"], "Top-level scalar should replace an imported map")
+}
+
+func TestMergeFeaturesKeepsShallowMerge(t *testing.T) {
+	compiler := NewCompiler()
+	topFeatures := map[string]any{
+		"map-feature": map[string]any{"nested": "top"},
+	}
+	importedFeatures := []map[string]any{
+		{"map-feature": map[string]any{"nested": "import", "extra": "value"}},
+	}
+
+	result, err := compiler.MergeFeatures(topFeatures, importedFeatures)
+	require.NoError(t, err, "MergeFeatures should not error")
+	assert.Equal(t, map[string]any{"nested": "top"}, result["map-feature"], "MergeFeatures should keep the top-level map whole")
+}
+
+func TestMergeFeaturesStrictTopLevelConflict(t *testing.T) {
+	compiler := NewCompiler()
+	topFeatures := map[string]any{
+		"feature": false,
+		"shared":  "same",
+	}
+	importedFeatures := []map[string]any{
+		{
+			"feature": true,
+			"shared":  "same",
+		},
+	}
+
+	result, err := compiler.MergeFeaturesStrict(topFeatures, importedFeatures)
+	require.Error(t, err, "MergeFeaturesStrict should reject conflicting values")
+	assert.Nil(t, result, "No features should be returned on error")
+	assert.Contains(t, err.Error(), "feature 'feature' has conflicting values (top-level: false, import 1: true)", "error should list the competing values")
+	assert.NotContains(t, err.Error(), "shared", "identical definitions should not conflict")
+}
+
+func TestMergeFeaturesStrictImportConflicts(t *testing.T) {
+	compiler := NewCompiler()
+	topFeatures := map[string]any{
+		"top-only": true,
+	}
+	importedFeatures := []map[string]any{
+		{"alpha": "one", "beta": 1},
+		{"alpha": "two", "beta": 2},
+	}
+
+	result, err := compiler.MergeFeaturesStrict(topFeatures, importedFeatures)
+	require.Error(t, err, "MergeFeaturesStrict should reject conflicting imports")
+	assert.Nil(t, result, "No features should be returned on error")
+	assert.Contains(t, err.Error(), "Found 2 feature conflict errors", "every conflicting feature should be reported")
+	assert.Contains(t, err.Error(), "feature 'alpha' has conflicting values (import 1: one, import 2: two)", "error should list the alpha values")
+	assert.Contains(t, err.Error(), "feature 'beta' has conflicting values (import 1: 1, import 2: 2)", "error should list the beta values")
+}
+
+func TestMergeFeaturesStrictWithoutConflicts(t *testing.T) {
+	compiler := NewCompiler()
+	topFeatures := map[string]any{
+		"feature1": true,
+		"nested":   map[string]any{"key": "value"},
+	}
+	importedFeatures := []map[string]any{
+		{"feature2": false, "nested": map[string]any{"key": "value"}},
+	}
+
+	result, err := compiler.MergeFeaturesStrict(topFeatures, importedFeatures)
+	require.NoError(t, err, "MergeFeaturesStrict should accept identical definitions")
+	assert.Equal(t, map[string]any{
+		"feature1": true,
+		"feature2": false,
+		"nested":   map[string]any{"key": "value"},
+	}, result, "features should be merged")
+}
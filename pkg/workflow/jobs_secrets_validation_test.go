@@ -130,7 +130,8 @@ func TestValidateSecretsExpression(t *testing.T) {
 		{"invalid plaintext", "my-secret", true},
 		{"invalid GitHub PAT", "ghp_1234567890abcdef", true},
 		{"invalid env reference", "${{ env.MY_TOKEN }}", true},
-		{"invalid vars reference", "${{ vars.MY_TOKEN }}", true},
+		{"valid vars reference", "${{ vars.MY_TOKEN }}", false},
+		{"valid secret with vars fallback", "${{ secrets.MY_TOKEN || vars.MY_TOKEN }}", false},
 		{"invalid github context", "${{ github.token }}", true},
 		{"invalid missing closing", "${{ secrets.MY_TOKEN", true},
 		{"invalid missing opening", "secrets.MY_TOKEN }}", true},
@@ -178,6 +179,20 @@ Test for schema validation.`,
 			expectError: true,
 			errorMsg:    "does not match pattern",
 		},
+		{
+			name: "schema accepts vars expression",
+			markdown: `---
+on: workflow_dispatch
+engine: codex
+jobs:
+  deploy:
+    uses: ./.github/workflows/deploy.yml
+    secrets:
+      url: ${{ secrets.DEPLOY_URL || vars.DEPLOY_URL }}
+---
+Test for schema validation.`,
+			expectError: false,
+		},
 		{
 			name: "schema accepts valid secret expression",
 			markdown: `---
@@ -0,0 +1,317 @@
+// This file provides semantic diffing of compiled lock files.
+//
+// # Lock File Diff
+//
+// DiffLockFiles parses two .lock.yml documents as YAML and reports structural
+// differences, so reviewers can see what a source change actually does to the
+// compiled workflow instead of reading a noisy text diff. Comments (including the
+// gh-aw metadata header), key order, quoting, and indentation are ignored.
+//
+// Each change is classified into a section so the meaningful parts of a workflow
+// stand out: triggers (the top-level on: block), concurrency, permissions, steps,
+// and everything else.
+//
+// Sequences whose items are all mappings with a unique name (e.g. steps) are
+// matched by name rather than by index, so inserting a step reports one added
+// step instead of a change for every step that follows it.
+
+package workflow
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/goccy/go-yaml"
+)
+
+var lockDiffLog = logger.New("workflow:lock_diff")
+
+// LockDiffChangeKind describes how a value differs between two lock files
+type LockDiffChangeKind string
+
+const (
+	LockDiffAdded   LockDiffChangeKind = "added"
+	LockDiffRemoved LockDiffChangeKind = "removed"
+	LockDiffChanged LockDiffChangeKind = "changed"
+)
+
+// Lock diff sections used to classify changes
+const (
+	LockDiffSectionTriggers    = "triggers"
+	LockDiffSectionConcurrency = "concurrency"
+	LockDiffSectionPermissions = "permissions"
+	LockDiffSectionSteps       = "steps"
+	LockDiffSectionOther       = "other"
+)
+
+// LockDiffChange is a single structural difference between two lock files
+type LockDiffChange struct {
+	Path    string             `json:"path"`             // Location of the change (e.g., "jobs.agent.steps[name=Checkout].with.ref")
+	Kind    LockDiffChangeKind `json:"kind"`             // added, removed, or changed
+	Section string             `json:"section"`          // triggers, concurrency, permissions, steps, or other
+	Before  any                `json:"before,omitempty"` // Value in the first lock file (nil when added)
+	After   any                `json:"after,omitempty"`  // Value in the second lock file (nil when removed)
+}
+
+// LockDiff is the set of structural differences between two lock files
+type LockDiff struct {
+	Changes []LockDiffChange `json:"changes"`
+}
+
+// HasChanges reports whether the two lock files differ structurally
+func (d LockDiff) HasChanges() bool {
+	return len(d.Changes) > 0
+}
+
+// BySection returns the changes belonging to the given section
+func (d LockDiff) BySection(section string) []LockDiffChange {
+	var changes []LockDiffChange
+	for _, change := range d.Changes {
+		if change.Section == section {
+			changes = append(changes, change)
+		}
+	}
+	return changes
+}
+
+// String renders the diff as one line per change, grouped by section
+func (d LockDiff) String() string {
+	if !d.HasChanges() {
+		return "No structural changes"
+	}
+
+	var sb strings.Builder
+	for _, section := range []string{LockDiffSectionTriggers, LockDiffSectionConcurrency, LockDiffSectionPermissions, LockDiffSectionSteps, LockDiffSectionOther} {
+		changes := d.BySection(section)
+		if len(changes) == 0 {
+			continue
+		}
+		fmt.Fprintf(&sb, "%s:\n", section)
+		for _, change := range changes {
+			switch change.Kind {
+			case LockDiffAdded:
+				fmt.Fprintf(&sb, "  + %s: %s\n", change.Path, formatLockDiffValue(change.After))
+			case LockDiffRemoved:
+				fmt.Fprintf(&sb, "  - %s: %s\n", change.Path, formatLockDiffValue(change.Before))
+			default:
+				fmt.Fprintf(&sb, "  ~ %s: %s -> %s\n", change.Path, formatLockDiffValue(change.Before), formatLockDiffValue(change.After))
+			}
+		}
+	}
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+// DiffLockFiles parses the contents of two lock files as YAML and returns the
+// structural differences from a to b. Returns an error if either document is not
+// a valid YAML mapping.
+func DiffLockFiles(a, b string) (LockDiff, error) {
+	before, err := parseLockDocument(a)
+	if err != nil {
+		return LockDiff{}, fmt.Errorf("failed to parse first lock file: %w", err)
+	}
+	after, err := parseLockDocument(b)
+	if err != nil {
+		return LockDiff{}, fmt.Errorf("failed to parse second lock file: %w", err)
+	}
+
+	diff := LockDiff{Changes: []LockDiffChange{}}
+	diffLockValues(nil, before, after, &diff)
+	lockDiffLog.Printf("Computed lock file diff: %d change(s)", len(diff.Changes))
+	return diff, nil
+}
+
+// parseLockDocument parses a lock file document into a mapping; an empty document is an empty mapping
+func parseLockDocument(content string) (map[string]any, error) {
+	doc := map[string]any{}
+	if strings.TrimSpace(content) == "" {
+		return doc, nil
+	}
+	if err := yaml.Unmarshal([]byte(content), &doc); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// diffLockValues recursively compares before and after at path, appending changes to diff
+func diffLockValues(path []string, before, after any, diff *LockDiff) {
+	beforeMap, beforeIsMap := before.(map[string]any)
+	afterMap, afterIsMap := after.(map[string]any)
+	if beforeIsMap && afterIsMap {
+		diffLockMaps(path, beforeMap, afterMap, diff)
+		return
+	}
+
+	beforeList, beforeIsList := before.([]any)
+	afterList, afterIsList := after.([]any)
+	if beforeIsList && afterIsList {
+		diffLockLists(path, beforeList, afterList, diff)
+		return
+	}
+
+	if !reflect.DeepEqual(before, after) {
+		diff.addChange(path, LockDiffChanged, before, after)
+	}
+}
+
+// diffLockMaps compares two mappings key by key in sorted order
+func diffLockMaps(path []string, before, after map[string]any, diff *LockDiff) {
+	keys := make([]string, 0, len(before)+len(after))
+	for key := range before {
+		keys = append(keys, key)
+	}
+	for key := range after {
+		if _, exists := before[key]; !exists {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		childPath := append(slices.Clone(path), key)
+		beforeValue, inBefore := before[key]
+		afterValue, inAfter := after[key]
+		switch {
+		case !inBefore:
+			diff.addChange(childPath, LockDiffAdded, nil, afterValue)
+		case !inAfter:
+			diff.addChange(childPath, LockDiffRemoved, beforeValue, nil)
+		default:
+			diffLockValues(childPath, beforeValue, afterValue, diff)
+		}
+	}
+}
+
+// diffLockLists compares two sequences, matching items by name when possible and by index otherwise
+func diffLockLists(path []string, before, after []any, diff *LockDiff) {
+	beforeNames, beforeNamed := lockListItemNames(before)
+	afterNames, afterNamed := lockListItemNames(after)
+	if !beforeNamed || !afterNamed {
+		diffLockListsByIndex(path, before, after, diff)
+		return
+	}
+
+	itemPath := func(name string) []string {
+		p := slices.Clone(path)
+		p[len(p)-1] = fmt.Sprintf("%s[name=%s]", p[len(p)-1], name)
+		return p
+	}
+
+	afterIndex := make(map[string]int, len(afterNames))
+	for i, name := range afterNames {
+		afterIndex[name] = i
+	}
+	beforeIndex := make(map[string]int, len(beforeNames))
+	for i, name := range beforeNames {
+		beforeIndex[name] = i
+		if j, exists := afterIndex[name]; exists {
+			diffLockValues(itemPath(name), before[i], after[j], diff)
+		} else {
+			diff.addChange(itemPath(name), LockDiffRemoved, before[i], nil)
+		}
+	}
+	for j, name := range afterNames {
+		if _, exists := beforeIndex[name]; !exists {
+			diff.addChange(itemPath(name), LockDiffAdded, nil, after[j])
+		}
+	}
+}
+
+// diffLockListsByIndex compares two sequences position by position
+func diffLockListsByIndex(path []string, before, after []any, diff *LockDiff) {
+	itemPath := func(i int) []string {
+		p := slices.Clone(path)
+		p[len(p)-1] = fmt.Sprintf("%s[%d]", p[len(p)-1], i)
+		return p
+	}
+
+	for i := 0; i < max(len(before), len(after)); i++ {
+		switch {
+		case i >= len(before):
+			diff.addChange(itemPath(i), LockDiffAdded, nil, after[i])
+		case i >= len(after):
+			diff.addChange(itemPath(i), LockDiffRemoved, before[i], nil)
+		default:
+			diffLockValues(itemPath(i), before[i], after[i], diff)
+		}
+	}
+}
+
+// lockListItemNames returns the name of each item when every item is a mapping with
+// a unique, non-empty string "name" field
+func lockListItemNames(items []any) ([]string, bool) {
+	if len(items) == 0 {
+		return nil, true
+	}
+	names := make([]string, 0, len(items))
+	seen := make(map[string]bool, len(items))
+	for _, item := range items {
+		itemMap, ok := item.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		name, ok := itemMap["name"].(string)
+		if !ok || name == "" || seen[name] {
+			return nil, false
+		}
+		seen[name] = true
+		names = append(names, name)
+	}
+	return names, true
+}
+
+// addChange records a change at path, classifying it into a section
+func (d *LockDiff) addChange(path []string, kind LockDiffChangeKind, before, after any) {
+	d.Changes = append(d.Changes, LockDiffChange{
+		Path:    strings.Join(path, "."),
+		Kind:    kind,
+		Section: lockDiffSection(path),
+		Before:  before,
+		After:   after,
+	})
+}
+
+// lockDiffSection classifies a change path into a lock diff section
+func lockDiffSection(path []string) string {
+	if len(path) == 0 {
+		return LockDiffSectionOther
+	}
+	if path[0] == "on" {
+		return LockDiffSectionTriggers
+	}
+	for _, segment := range path {
+		// Strip list item suffixes (e.g., "steps[name=Checkout]" -> "steps")
+		key, _, _ := strings.Cut(segment, "[")
+		switch key {
+		case "concurrency":
+			return LockDiffSectionConcurrency
+		case "permissions":
+			return LockDiffSectionPermissions
+		case "steps":
+			return LockDiffSectionSteps
+		}
+	}
+	return LockDiffSectionOther
+}
+
+// formatLockDiffValue renders a value compactly for display
+func formatLockDiffValue(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case string:
+		return fmt.Sprintf("%q", v)
+	case map[string]any, []any:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprintf("%v", v)
+		}
+		return string(data)
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
@@ -0,0 +1,132 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const lockDiffBase = `# gh-aw-metadata: {"schema_version":"v2"}
+name: "Test"
+on:
+  issues:
+    types: [opened]
+permissions: {}
+concurrency:
+  group: "gh-aw-${{ github.workflow }}"
+jobs:
+  agent:
+    runs-on: ubuntu-latest
+    permissions:
+      contents: read
+    steps:
+      - name: Checkout
+        uses: actions/checkout@v4
+      - name: Run agent
+        run: echo run
+`
+
+func TestDiffLockFilesIgnoresFormatting(t *testing.T) {
+	reformatted := `# gh-aw-metadata: {"schema_version":"v2","frontmatter_hash":"abc"}
+# A different comment
+jobs:
+  agent:
+    steps:
+    - uses: actions/checkout@v4
+      name: Checkout
+    - name: "Run agent"
+      run: 'echo run'
+    permissions: {contents: read}
+    runs-on: ubuntu-latest
+concurrency: {group: "gh-aw-${{ github.workflow }}"}
+permissions: {}
+on:
+  issues:
+    types:
+      - opened
+name: Test
+`
+	diff, err := DiffLockFiles(lockDiffBase, reformatted)
+	require.NoError(t, err, "diff should succeed")
+	assert.False(t, diff.HasChanges(), "formatting-only changes should not be reported: %s", diff)
+}
+
+func TestDiffLockFilesReportsSections(t *testing.T) {
+	changed := `name: "Test"
+on:
+  issues:
+    types: [opened, labeled]
+  workflow_dispatch:
+permissions: {}
+concurrency:
+  group: "gh-aw-${{ github.workflow }}-${{ github.event.issue.number }}"
+jobs:
+  agent:
+    runs-on: ubuntu-latest
+    permissions:
+      contents: read
+      issues: write
+    steps:
+      - name: Setup
+        run: echo setup
+      - name: Checkout
+        uses: actions/checkout@v5
+      - name: Run agent
+        run: echo run
+`
+	diff, err := DiffLockFiles(lockDiffBase, changed)
+	require.NoError(t, err, "diff should succeed")
+
+	assert.Equal(t, []LockDiffChange{
+		{Path: "on.issues.types[1]", Kind: LockDiffAdded, Section: LockDiffSectionTriggers, After: "labeled"},
+		{Path: "on.workflow_dispatch", Kind: LockDiffAdded, Section: LockDiffSectionTriggers},
+	}, diff.BySection(LockDiffSectionTriggers), "trigger changes should be reported")
+
+	assert.Equal(t, []LockDiffChange{
+		{
+			Path:    "concurrency.group",
+			Kind:    LockDiffChanged,
+			Section: LockDiffSectionConcurrency,
+			Before:  "gh-aw-${{ github.workflow }}",
+			After:   "gh-aw-${{ github.workflow }}-${{ github.event.issue.number }}",
+		},
+	}, diff.BySection(LockDiffSectionConcurrency), "concurrency change should be reported")
+
+	assert.Equal(t, []LockDiffChange{
+		{Path: "jobs.agent.permissions.issues", Kind: LockDiffAdded, Section: LockDiffSectionPermissions, After: "write"},
+	}, diff.BySection(LockDiffSectionPermissions), "permission change should be reported")
+
+	steps := diff.BySection(LockDiffSectionSteps)
+	require.Len(t, steps, 2, "only the changed and inserted steps should be reported")
+	assert.Equal(t, "jobs.agent.steps[name=Checkout].uses", steps[0].Path, "changed step should be matched by name")
+	assert.Equal(t, LockDiffChanged, steps[0].Kind, "checkout step should be changed")
+	assert.Equal(t, "jobs.agent.steps[name=Setup]", steps[1].Path, "inserted step should be reported once")
+	assert.Equal(t, LockDiffAdded, steps[1].Kind, "setup step should be added")
+
+	assert.Empty(t, diff.BySection(LockDiffSectionOther), "no other changes expected")
+	assert.Contains(t, diff.String(), "~ concurrency.group:", "rendered diff should include the concurrency change")
+}
+
+func TestDiffLockFilesUnnamedListsByIndex(t *testing.T) {
+	before := "jobs:\n  a:\n    needs: [x, y]\n"
+	after := "jobs:\n  a:\n    needs: [x]\n"
+
+	diff, err := DiffLockFiles(before, after)
+	require.NoError(t, err, "diff should succeed")
+	assert.Equal(t, []LockDiffChange{
+		{Path: "jobs.a.needs[1]", Kind: LockDiffRemoved, Section: LockDiffSectionOther, Before: "y"},
+	}, diff.Changes, "removed list item should be reported by index")
+}
+
+func TestDiffLockFilesInvalidYAML(t *testing.T) {
+	_, err := DiffLockFiles("on: [push", "on: push")
+	require.Error(t, err, "invalid YAML should fail")
+	assert.Contains(t, err.Error(), "first lock file", "error should identify the invalid document")
+}
+
+func TestLockDiffStringNoChanges(t *testing.T) {
+	assert.Equal(t, "No structural changes", LockDiff{}.String(), "empty diff should render a placeholder")
+}
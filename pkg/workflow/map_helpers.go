@@ -18,9 +18,34 @@
 //
 // Type Conversion:
 //   - parseIntValue() - Safely parse numeric types to int with truncation warnings
+//   - parseIntValueOrString() - Like parseIntValue, but also parse numeric strings such as "30"
+//   - coerceToStringSlice() - Convert a single string or a list of strings to []string
+//   - getMapFieldAsIntInRange() - Read an integer map field and validate it against bounds
+//   - getMapFieldAsStringCoerce() - Read a map field as a string, stringifying numbers and booleans
+//   - getMapFieldAsBoolCoerce() - Read a map field as a bool, accepting truthy and falsy strings and 0/1
+//   - getMapFieldAsFloat64() - Read a numeric map field as float64 with a fallback
+//   - getMapFieldAsDuration() - Read a duration string or seconds count with a fallback
 //
 // Map Operations:
 //   - filterMapKeys() - Create new map excluding specified keys
+//   - filterMapKeysFunc() - Create new map keeping the keys accepted by a predicate
+//   - filterMapKeysGlob() - Create new map excluding keys that match glob patterns such as x-*
+//   - deepMergeMaps() - Recursively merge an overlay map into a base map
+//   - flattenMap() - Flatten nested maps into dotted keys such as tools.playwright.allowed
+//   - getMapFieldAsStringSlice() - Read a string or list of strings from a map field
+//   - getMapFieldAsStringMap() - Read a nested map with scalar values as map[string]string
+//
+// Required Fields:
+//   - requireMapFieldAsString() - Read a required string field or return a validation error
+//   - requireMapFieldAsMap() - Read a required nested map field or return a validation error
+//
+// Case-Insensitive Lookups:
+//   - lookupMapFieldIgnoreCase() - Find a map field whose key matches ignoring case
+//   - getMapFieldAsStringIgnoreCase() - Read a string map field, matching the key ignoring case
+//   - getMapFieldAsBoolIgnoreCase() - Read a boolean map field, matching the key ignoring case
+//   - getMapFieldAsMapIgnoreCase() - Read a nested map field, matching the key ignoring case
+//
+// The other getters match keys exactly; the case-insensitive variants are opt-in.
 //
 // These utilities handle common type conversion and map manipulation patterns that
 // occur frequently during YAML-to-struct parsing and configuration processing.
@@ -28,10 +53,18 @@
 package workflow
 
 import (
+	"fmt"
+	"math"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
 	"github.com/github/gh-aw/pkg/logger"
 )
 
-var mapHelpersLog = logger.New("workflow:map_helpers")
+var mapHelpersLog = newSecretRedactingLogger(logger.New("workflow:map_helpers"))
 
 // parseIntValue safely parses various numeric types to int
 // This is a common utility used across multiple parsing functions
@@ -61,6 +94,53 @@ func parseIntValue(value any) (int, bool) {
 	}
 }
 
+// parseIntValueOrString parses value like parseIntValue, and also accepts numeric strings,
+// which YAML produces for quoted numbers such as "30". Float strings are truncated and
+// logged, as float64 values are; other strings return false.
+func parseIntValueOrString(value any) (int, bool) {
+	str, ok := value.(string)
+	if !ok {
+		return parseIntValue(value)
+	}
+	if n, err := strconv.Atoi(str); err == nil {
+		return n, true
+	}
+	f, err := strconv.ParseFloat(str, 64)
+	if err != nil || math.IsNaN(f) || math.IsInf(f, 0) || f < math.MinInt || f >= math.MaxInt {
+		return 0, false
+	}
+	intVal := int(f)
+	mapHelpersLog.Printf("Numeric string %q parsed as float and truncated to integer %d", str, intVal)
+	return intVal, true
+}
+
+// coerceToStringSlice converts a value that may be either a single string or a list of
+// strings, as frontmatter fields such as tools or on allow, to a []string. A string yields
+// a one-element slice, a list yields its elements and nil yields nil. Returns false when
+// the value is of any other type or a list contains a non-string element.
+func coerceToStringSlice(value any) ([]string, bool) {
+	switch v := value.(type) {
+	case nil:
+		return nil, true
+	case string:
+		return []string{v}, true
+	case []string:
+		return slices.Clone(v), true
+	case []any:
+		result := make([]string, 0, len(v))
+		for _, item := range v {
+			str, ok := item.(string)
+			if !ok {
+				return nil, false
+			}
+			result = append(result, str)
+		}
+		return result, true
+	default:
+		return nil, false
+	}
+}
+
 // filterMapKeys creates a new map excluding the specified keys
 func filterMapKeys(original map[string]any, excludeKeys ...string) map[string]any {
 	excludeSet := make(map[string]bool)
@@ -68,11 +148,453 @@ func filterMapKeys(original map[string]any, excludeKeys ...string) map[string]an
 		excludeSet[key] = true
 	}
 
+	return filterMapKeysFunc(original, func(key string) bool {
+		return !excludeSet[key]
+	})
+}
+
+// filterMapKeysFunc creates a new map with only the keys for which keep returns true
+func filterMapKeysFunc(original map[string]any, keep func(key string) bool) map[string]any {
 	result := make(map[string]any)
 	for key, value := range original {
-		if !excludeSet[key] {
+		if keep(key) {
 			result[key] = value
 		}
 	}
 	return result
 }
+
+// filterMapKeysGlob creates a new map excluding the keys that match any of the glob
+// patterns, using path.Match syntax (e.g. "x-*" strips all extension fields). A pattern
+// without wildcards excludes only that exact key. Malformed patterns match nothing.
+func filterMapKeysGlob(original map[string]any, excludePatterns ...string) map[string]any {
+	return filterMapKeysFunc(original, func(key string) bool {
+		for _, pattern := range excludePatterns {
+			matched, err := path.Match(pattern, key)
+			if err != nil {
+				mapHelpersLog.Printf("Ignoring malformed key pattern %q: %v", pattern, err)
+				continue
+			}
+			if matched {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// deepMergeMaps returns a new map with overlay merged into base. When both sides hold a
+// nested map[string]any under the same key, the nested maps are merged recursively; any
+// other overlay value, including a slice, replaces the base value. Nested maps are copied,
+// so neither input is modified or shared with the result.
+func deepMergeMaps(base, overlay map[string]any) map[string]any {
+	result := make(map[string]any, len(base)+len(overlay))
+	for key, value := range base {
+		if nested, ok := value.(map[string]any); ok {
+			value = deepMergeMaps(nested, nil)
+		}
+		result[key] = value
+	}
+
+	for key, value := range overlay {
+		overlayMap, ok := value.(map[string]any)
+		if !ok {
+			result[key] = value
+			continue
+		}
+		if baseMap, ok := result[key].(map[string]any); ok {
+			result[key] = deepMergeMaps(baseMap, overlayMap)
+		} else {
+			result[key] = deepMergeMaps(overlayMap, nil)
+		}
+	}
+	return result
+}
+
+// flattenMap returns a single-level copy of source in which the keys of nested maps are
+// joined with dots, e.g. {"tools": {"playwright": {"allowed": [...]}}} becomes
+// {"tools.playwright.allowed": [...]}. Slices and other values are kept as-is, and an
+// empty nested map is kept as the value of its own key so that it is not lost.
+func flattenMap(source map[string]any) map[string]any {
+	result := make(map[string]any)
+	flattenMapInto(result, "", source)
+	return result
+}
+
+// flattenMapInto adds the flattened entries of source to result, prefixing keys with prefix
+func flattenMapInto(result map[string]any, prefix string, source map[string]any) {
+	for key, value := range source {
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+		if nested, ok := value.(map[string]any); ok && len(nested) > 0 {
+			flattenMapInto(result, key, nested)
+			continue
+		}
+		result[key] = value
+	}
+}
+
+// getMapFieldAsStringSlice returns the strings stored under fieldKey in source.
+// A list yields its string elements, skipping (and logging) any other elements, and a
+// single string yields a one-element slice. Returns nil when the key is missing or
+// holds any other type.
+func getMapFieldAsStringSlice(source map[string]any, fieldKey string) []string {
+	value, exists := source[fieldKey]
+	if !exists {
+		return nil
+	}
+
+	switch v := value.(type) {
+	case string:
+		return []string{v}
+	case []string:
+		return slices.Clone(v)
+	case []any:
+		result := make([]string, 0, len(v))
+		for i, item := range v {
+			str, ok := item.(string)
+			if !ok {
+				mapHelpersLog.Printf("Skipping non-string element %d of field %s: got %T", i, fieldKey, item)
+				continue
+			}
+			result = append(result, str)
+		}
+		return result
+	default:
+		mapHelpersLog.Printf("Field %s is not a string or list of strings: got %T", fieldKey, value)
+		return nil
+	}
+}
+
+// getMapFieldAsStringMap returns the nested map stored under fieldKey in source with its
+// values as strings. Numbers and booleans are stringified the way YAML writes them (e.g.
+// 1 becomes "1" and true becomes "true"), matching how GitHub Actions reads env values.
+// Other values (nested maps, lists, null) are skipped and logged. Returns nil when the
+// key is missing or does not hold a map.
+func getMapFieldAsStringMap(source map[string]any, fieldKey string) map[string]string {
+	value, exists := source[fieldKey]
+	if !exists {
+		return nil
+	}
+
+	nested, ok := value.(map[string]any)
+	if !ok {
+		mapHelpersLog.Printf("Field %s is not a map: got %T", fieldKey, value)
+		return nil
+	}
+
+	result := make(map[string]string, len(nested))
+	for key, item := range nested {
+		switch v := item.(type) {
+		case string:
+			result[key] = v
+		case bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+			result[key] = fmt.Sprint(v)
+		default:
+			mapHelpersLog.Printf("Skipping key %s of field %s: got %T, expected a scalar value", key, fieldKey, item)
+		}
+	}
+	return result
+}
+
+// getMapFieldAsIntInRange returns the integer stored under fieldKey in source, validated
+// against the inclusive range [min, max]. Returns fallback when source is nil or the key is
+// missing, and a validation error when the value is not an integer or is out of range.
+// Quoted numbers such as "30" are accepted.
+//
+// Example:
+//
+//	retentionDays, err := getMapFieldAsIntInRange(configMap, "retention-days", 7, 1, 90)
+func getMapFieldAsIntInRange(source map[string]any, fieldKey string, fallback, min, max int) (int, error) {
+	value, exists := source[fieldKey]
+	if !exists {
+		return fallback, nil
+	}
+
+	suggestion := fmt.Sprintf("Set %s to a whole number between %d and %d. Example:\n%s: %d", fieldKey, min, max, fieldKey, min)
+	n, ok := parseIntValueOrString(value)
+	if !ok {
+		mapHelpersLog.Printf("Field %s is not an integer: got %T", fieldKey, value)
+		return fallback, NewValidationError(fieldKey, fmt.Sprint(value), fieldKey+" must be an integer", suggestion)
+	}
+	if err := validateIntRange(n, min, max, fieldKey); err != nil {
+		mapHelpersLog.Printf("Field %s is out of range: %v", fieldKey, err)
+		return fallback, NewValidationError(fieldKey, strconv.Itoa(n), err.Error(), suggestion)
+	}
+	return n, nil
+}
+
+// getMapFieldAsStringCoerce returns the value stored under fieldKey in source as a string.
+// Unlike a plain string lookup it keeps scalars that YAML parsed as another type, such as a
+// version 1.5 read as a float: integers and booleans use their canonical form and floats
+// the shortest decimal form without an exponent. Returns fallback when source is nil, the
+// key is missing, or the value is not a scalar (maps, lists, null).
+func getMapFieldAsStringCoerce(source map[string]any, fieldKey string, fallback string) string {
+	value, exists := source[fieldKey]
+	if !exists {
+		return fallback
+	}
+
+	switch v := value.(type) {
+	case string:
+		return v
+	case bool:
+		return strconv.FormatBool(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32)
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return fmt.Sprint(v)
+	default:
+		mapHelpersLog.Printf("Field %s is not a scalar: got %T, using fallback", fieldKey, value)
+		return fallback
+	}
+}
+
+// coercibleBoolValues maps the strings accepted by getMapFieldAsBoolCoerce, after trimming
+// and lowercasing, to their boolean value
+var coercibleBoolValues = map[string]bool{
+	"true":  true,
+	"yes":   true,
+	"on":    true,
+	"1":     true,
+	"false": false,
+	"no":    false,
+	"off":   false,
+	"0":     false,
+}
+
+// getMapFieldAsBoolCoerce returns the value stored under fieldKey in source as a bool.
+// Besides real booleans it accepts the strings "true", "yes", "on", "1" and "false", "no",
+// "off", "0" (case-insensitive, surrounding whitespace ignored), which YAML yields when a
+// boolean is quoted, and the integers 0 and 1. Returns fallback when source is nil, the key
+// is missing, or the value is anything else.
+func getMapFieldAsBoolCoerce(source map[string]any, fieldKey string, fallback bool) bool {
+	value, exists := source[fieldKey]
+	if !exists {
+		return fallback
+	}
+
+	var text string
+	switch v := value.(type) {
+	case bool:
+		return v
+	case string:
+		text = strings.ToLower(strings.TrimSpace(v))
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		text = fmt.Sprint(v)
+	}
+
+	if b, ok := coercibleBoolValues[text]; ok {
+		return b
+	}
+	mapHelpersLog.Printf("Field %s is not a recognized boolean: got %T, using fallback %t", fieldKey, value, fallback)
+	return fallback
+}
+
+// getMapFieldAsFloat64 returns the number stored under fieldKey in source as a float64.
+// Floating-point and integer values are accepted, integers being promoted. Returns fallback
+// when source is nil, the key is missing, or the value is not a number.
+func getMapFieldAsFloat64(source map[string]any, fieldKey string, fallback float64) float64 {
+	value, exists := source[fieldKey]
+	if !exists {
+		return fallback
+	}
+
+	switch v := value.(type) {
+	case float64:
+		return v
+	case float32:
+		return float64(v)
+	case int:
+		return float64(v)
+	case int8:
+		return float64(v)
+	case int16:
+		return float64(v)
+	case int32:
+		return float64(v)
+	case int64:
+		return float64(v)
+	case uint:
+		return float64(v)
+	case uint8:
+		return float64(v)
+	case uint16:
+		return float64(v)
+	case uint32:
+		return float64(v)
+	case uint64:
+		return float64(v)
+	default:
+		mapHelpersLog.Printf("Field %s is not a number: got %T, using fallback %v", fieldKey, value, fallback)
+		return fallback
+	}
+}
+
+// getMapFieldAsDuration returns the duration stored under fieldKey in source. The value may
+// be a Go duration string such as "30s", "5m" or "1h30m", or a bare integer, which is
+// interpreted as a number of seconds. Returns fallback when source is nil, the key is
+// missing, or the value is not a valid non-negative duration.
+func getMapFieldAsDuration(source map[string]any, fieldKey string, fallback time.Duration) time.Duration {
+	value, exists := source[fieldKey]
+	if !exists {
+		return fallback
+	}
+
+	var duration time.Duration
+	if str, ok := value.(string); ok {
+		parsed, err := time.ParseDuration(str)
+		if err != nil {
+			mapHelpersLog.Printf("Field %s is not a valid duration: %v, using fallback %s", fieldKey, err, fallback)
+			return fallback
+		}
+		duration = parsed
+	} else if seconds, ok := parseIntValue(value); ok {
+		duration = time.Duration(seconds) * time.Second
+	} else {
+		mapHelpersLog.Printf("Field %s is not a duration: got %T, using fallback %s", fieldKey, value, fallback)
+		return fallback
+	}
+
+	if duration < 0 {
+		mapHelpersLog.Printf("Field %s is a negative duration %s, using fallback %s", fieldKey, duration, fallback)
+		return fallback
+	}
+	return duration
+}
+
+// requireMapFieldAsString returns the string stored under fieldKey in source. Returns a
+// validation error naming the field when the key is missing, null, or not a string.
+func requireMapFieldAsString(source map[string]any, fieldKey string) (string, error) {
+	value, err := requireMapField(source, fieldKey)
+	if err != nil {
+		return "", err
+	}
+
+	str, ok := value.(string)
+	if !ok {
+		return "", newMapFieldTypeError(fieldKey, "a string", value)
+	}
+	return str, nil
+}
+
+// requireMapFieldAsMap returns the nested map stored under fieldKey in source. Returns a
+// validation error naming the field when the key is missing, null, or not a map.
+func requireMapFieldAsMap(source map[string]any, fieldKey string) (map[string]any, error) {
+	value, err := requireMapField(source, fieldKey)
+	if err != nil {
+		return nil, err
+	}
+
+	nested, ok := value.(map[string]any)
+	if !ok {
+		return nil, newMapFieldTypeError(fieldKey, "a map", value)
+	}
+	return nested, nil
+}
+
+// requireMapField returns the non-null value stored under fieldKey in source, or a
+// validation error when the key is missing or null
+func requireMapField(source map[string]any, fieldKey string) (any, error) {
+	value, exists := source[fieldKey]
+	if !exists || value == nil {
+		mapHelpersLog.Printf("Required field %s is missing", fieldKey)
+		return nil, NewValidationError(
+			fieldKey,
+			"",
+			fmt.Sprintf("required field '%s' is missing", fieldKey),
+			fmt.Sprintf("Add the '%s' field to the configuration", fieldKey),
+		)
+	}
+	return value, nil
+}
+
+// newMapFieldTypeError returns the validation error for a field holding a value of the wrong type
+func newMapFieldTypeError(fieldKey, expected string, value any) *WorkflowValidationError {
+	mapHelpersLog.Printf("Field %s is not %s: got %T", fieldKey, expected, value)
+	return NewValidationError(
+		fieldKey,
+		fmt.Sprintf("%T", value),
+		fmt.Sprintf("field '%s' must be %s", fieldKey, expected),
+		fmt.Sprintf("Change the '%s' field to %s", fieldKey, expected),
+	)
+}
+
+// lookupMapFieldIgnoreCase returns the value stored under the key of source that matches
+// fieldKey ignoring case. An exact match is preferred. When several keys differ from
+// fieldKey only in case (e.g. "Timeout" and "TIMEOUT"), the collision is logged and the
+// lexically smallest key wins so that the result does not depend on map iteration order.
+func lookupMapFieldIgnoreCase(source map[string]any, fieldKey string) (any, bool) {
+	var matches []string
+	for key := range source {
+		if strings.EqualFold(key, fieldKey) {
+			matches = append(matches, key)
+		}
+	}
+	if len(matches) == 0 {
+		return nil, false
+	}
+
+	slices.Sort(matches)
+	selected := matches[0]
+	if _, exact := source[fieldKey]; exact {
+		selected = fieldKey
+	}
+	if len(matches) > 1 {
+		mapHelpersLog.Printf("Field %s matches several keys ignoring case: %s, using %s", fieldKey, strings.Join(matches, ", "), selected)
+	}
+	return source[selected], true
+}
+
+// getMapFieldAsStringIgnoreCase returns the string stored under the key of source that
+// matches fieldKey ignoring case. Returns fallback when no key matches or the value is not
+// a string.
+func getMapFieldAsStringIgnoreCase(source map[string]any, fieldKey string, fallback string) string {
+	value, exists := lookupMapFieldIgnoreCase(source, fieldKey)
+	if !exists {
+		return fallback
+	}
+
+	str, ok := value.(string)
+	if !ok {
+		mapHelpersLog.Printf("Field %s is not a string: got %T, using fallback", fieldKey, value)
+		return fallback
+	}
+	return str
+}
+
+// getMapFieldAsBoolIgnoreCase returns the boolean stored under the key of source that
+// matches fieldKey ignoring case. Returns fallback when no key matches or the value is not
+// a boolean.
+func getMapFieldAsBoolIgnoreCase(source map[string]any, fieldKey string, fallback bool) bool {
+	value, exists := lookupMapFieldIgnoreCase(source, fieldKey)
+	if !exists {
+		return fallback
+	}
+
+	b, ok := value.(bool)
+	if !ok {
+		mapHelpersLog.Printf("Field %s is not a boolean: got %T, using fallback %t", fieldKey, value, fallback)
+		return fallback
+	}
+	return b
+}
+
+// getMapFieldAsMapIgnoreCase returns the nested map stored under the key of source that
+// matches fieldKey ignoring case. Returns nil when no key matches or the value is not a map.
+func getMapFieldAsMapIgnoreCase(source map[string]any, fieldKey string) map[string]any {
+	value, exists := lookupMapFieldIgnoreCase(source, fieldKey)
+	if !exists {
+		return nil
+	}
+
+	nested, ok := value.(map[string]any)
+	if !ok {
+		mapHelpersLog.Printf("Field %s is not a map: got %T", fieldKey, value)
+		return nil
+	}
+	return nested
+}
@@ -4,6 +4,10 @@ package workflow
 
 import (
 	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestParseIntValue(t *testing.T) {
@@ -70,6 +74,90 @@ func TestParseIntValue(t *testing.T) {
 	}
 }
 
+func TestParseIntValueOrString(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    any
+		expected int
+		ok       bool
+	}{
+		{
+			name:     "int value",
+			value:    42,
+			expected: 42,
+			ok:       true,
+		},
+		{
+			name:     "integer string",
+			value:    "30",
+			expected: 30,
+			ok:       true,
+		},
+		{
+			name:     "negative integer string",
+			value:    "-5",
+			expected: -5,
+			ok:       true,
+		},
+		{
+			name:     "float string",
+			value:    "30.0",
+			expected: 30,
+			ok:       true,
+		},
+		{
+			name:     "fractional float string truncated",
+			value:    "30.7",
+			expected: 30,
+			ok:       true,
+		},
+		{
+			name:     "non-numeric string",
+			value:    "abc",
+			expected: 0,
+			ok:       false,
+		},
+		{
+			name:     "empty string",
+			value:    "",
+			expected: 0,
+			ok:       false,
+		},
+		{
+			name:     "string with spaces",
+			value:    " 30 ",
+			expected: 0,
+			ok:       false,
+		},
+		{
+			name:     "NaN string",
+			value:    "NaN",
+			expected: 0,
+			ok:       false,
+		},
+		{
+			name:     "out of range float string",
+			value:    "1e30",
+			expected: 0,
+			ok:       false,
+		},
+		{
+			name:     "bool value (not supported)",
+			value:    true,
+			expected: 0,
+			ok:       false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, ok := parseIntValueOrString(tt.value)
+			assert.Equal(t, tt.ok, ok, "ok should match")
+			assert.Equal(t, tt.expected, result, "parsed value should match")
+		})
+	}
+}
+
 // TestParseIntValueTruncation tests float truncation scenarios
 func TestParseIntValueTruncation(t *testing.T) {
 	tests := []struct {
@@ -246,3 +334,560 @@ func TestFilterMapKeys(t *testing.T) {
 		})
 	}
 }
+
+func TestGetMapFieldAsStringSlice(t *testing.T) {
+	tests := []struct {
+		name     string
+		source   map[string]any
+		expected []string
+	}{
+		{
+			name:     "missing key",
+			source:   map[string]any{"other": "value"},
+			expected: nil,
+		},
+		{
+			name:     "list of strings",
+			source:   map[string]any{"allowed": []any{"github.com", "api.github.com"}},
+			expected: []string{"github.com", "api.github.com"},
+		},
+		{
+			name:     "typed string slice",
+			source:   map[string]any{"allowed": []string{"github.com"}},
+			expected: []string{"github.com"},
+		},
+		{
+			name:     "single string",
+			source:   map[string]any{"allowed": "github.com"},
+			expected: []string{"github.com"},
+		},
+		{
+			name:     "mixed list skips non-strings",
+			source:   map[string]any{"allowed": []any{"github.com", 42, map[string]any{"k": "v"}, "api.github.com"}},
+			expected: []string{"github.com", "api.github.com"},
+		},
+		{
+			name:     "empty list",
+			source:   map[string]any{"allowed": []any{}},
+			expected: []string{},
+		},
+		{
+			name:     "unsupported type",
+			source:   map[string]any{"allowed": 42},
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, getMapFieldAsStringSlice(tt.source, "allowed"), "strings should be read from the field")
+		})
+	}
+}
+
+func TestDeepMergeMaps(t *testing.T) {
+	tests := []struct {
+		name     string
+		base     map[string]any
+		overlay  map[string]any
+		expected map[string]any
+	}{
+		{
+			name: "nested maps merged key by key",
+			base: map[string]any{
+				"tools": map[string]any{
+					"github": map[string]any{"toolsets": []any{"repos"}, "read-only": true},
+					"bash":   true,
+				},
+			},
+			overlay: map[string]any{
+				"tools": map[string]any{
+					"github":     map[string]any{"lockdown": true},
+					"web-search": nil,
+				},
+			},
+			expected: map[string]any{
+				"tools": map[string]any{
+					"github":     map[string]any{"toolsets": []any{"repos"}, "read-only": true, "lockdown": true},
+					"bash":       true,
+					"web-search": nil,
+				},
+			},
+		},
+		{
+			name:     "overlay wins on scalar conflicts",
+			base:     map[string]any{"timeout": 10, "name": "base", "keep": "yes"},
+			overlay:  map[string]any{"timeout": 20, "name": "overlay"},
+			expected: map[string]any{"timeout": 20, "name": "overlay", "keep": "yes"},
+		},
+		{
+			name:     "slices are replaced",
+			base:     map[string]any{"allowed": []any{"a", "b"}},
+			overlay:  map[string]any{"allowed": []any{"c"}},
+			expected: map[string]any{"allowed": []any{"c"}},
+		},
+		{
+			name:     "map replaces scalar",
+			base:     map[string]any{"network": "defaults"},
+			overlay:  map[string]any{"network": map[string]any{"allowed": []any{"python"}}},
+			expected: map[string]any{"network": map[string]any{"allowed": []any{"python"}}},
+		},
+		{
+			name:     "scalar replaces map",
+			base:     map[string]any{"network": map[string]any{"allowed": []any{"python"}}},
+			overlay:  map[string]any{"network": "defaults"},
+			expected: map[string]any{"network": "defaults"},
+		},
+		{
+			name:     "nil inputs",
+			base:     nil,
+			overlay:  nil,
+			expected: map[string]any{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, deepMergeMaps(tt.base, tt.overlay), "maps should be deep merged")
+		})
+	}
+}
+
+func TestDeepMergeMapsDoesNotModifyInputs(t *testing.T) {
+	base := map[string]any{"tools": map[string]any{"bash": true}}
+	overlay := map[string]any{"tools": map[string]any{"edit": nil}}
+
+	result := deepMergeMaps(base, overlay)
+	result["tools"].(map[string]any)["github"] = nil
+
+	assert.Equal(t, map[string]any{"tools": map[string]any{"bash": true}}, base, "base should not be modified")
+	assert.Equal(t, map[string]any{"tools": map[string]any{"edit": nil}}, overlay, "overlay should not be modified")
+}
+
+func TestGetMapFieldAsStringMap(t *testing.T) {
+	tests := []struct {
+		name     string
+		source   map[string]any
+		expected map[string]string
+	}{
+		{name: "nil source", source: nil, expected: nil},
+		{name: "missing key", source: map[string]any{"other": map[string]any{}}, expected: nil},
+		{name: "not a map", source: map[string]any{"env": "FOO=bar"}, expected: nil},
+		{
+			name:     "string map",
+			source:   map[string]any{"env": map[string]any{"FOO": "bar", "TOKEN": "${{ secrets.TOKEN }}"}},
+			expected: map[string]string{"FOO": "bar", "TOKEN": "${{ secrets.TOKEN }}"},
+		},
+		{
+			name:     "scalars are stringified",
+			source:   map[string]any{"env": map[string]any{"COUNT": 3, "RATIO": 0.5, "WHOLE": 2.0, "DEBUG": true, "NAME": "x"}},
+			expected: map[string]string{"COUNT": "3", "RATIO": "0.5", "WHOLE": "2", "DEBUG": "true", "NAME": "x"},
+		},
+		{
+			name:     "non-scalar values are skipped",
+			source:   map[string]any{"env": map[string]any{"FOO": "bar", "LIST": []any{"a"}, "NESTED": map[string]any{"a": "b"}, "EMPTY": nil}},
+			expected: map[string]string{"FOO": "bar"},
+		},
+		{name: "empty map", source: map[string]any{"env": map[string]any{}}, expected: map[string]string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, getMapFieldAsStringMap(tt.source, "env"), "string map should be read from the field")
+		})
+	}
+}
+
+func TestGetMapFieldAsIntInRange(t *testing.T) {
+	tests := []struct {
+		name     string
+		source   map[string]any
+		expected int
+		wantErr  string
+	}{
+		{name: "nil source", source: nil, expected: 7},
+		{name: "missing key", source: map[string]any{"other": 3}, expected: 7},
+		{name: "in range", source: map[string]any{"retention-days": 30}, expected: 30},
+		{name: "at minimum", source: map[string]any{"retention-days": 1}, expected: 1},
+		{name: "at maximum", source: map[string]any{"retention-days": 90}, expected: 90},
+		{name: "numeric string in range", source: map[string]any{"retention-days": "14"}, expected: 14},
+		{name: "below minimum", source: map[string]any{"retention-days": 0}, expected: 7, wantErr: "retention-days must be between 1 and 90, got 0"},
+		{name: "above maximum", source: map[string]any{"retention-days": 91}, expected: 7, wantErr: "retention-days must be between 1 and 90, got 91"},
+		{name: "not an integer", source: map[string]any{"retention-days": "forever"}, expected: 7, wantErr: "retention-days must be an integer"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, err := getMapFieldAsIntInRange(tt.source, "retention-days", 7, 1, 90)
+			assert.Equal(t, tt.expected, value, "value should be read or fall back")
+			if tt.wantErr == "" {
+				require.NoError(t, err, "value should be accepted")
+				return
+			}
+			var validationErr *WorkflowValidationError
+			require.ErrorAs(t, err, &validationErr, "error should be a validation error")
+			assert.Equal(t, "retention-days", validationErr.Field, "error should name the field")
+			assert.Contains(t, err.Error(), tt.wantErr, "error should explain the problem")
+		})
+	}
+}
+
+func TestGetMapFieldAsStringCoerce(t *testing.T) {
+	tests := []struct {
+		name     string
+		source   map[string]any
+		expected string
+	}{
+		{name: "nil source", source: nil, expected: "fallback"},
+		{name: "missing key", source: map[string]any{"other": "x"}, expected: "fallback"},
+		{name: "string", source: map[string]any{"version": "v1"}, expected: "v1"},
+		{name: "float", source: map[string]any{"version": 1.5}, expected: "1.5"},
+		{name: "whole float", source: map[string]any{"version": 2.0}, expected: "2"},
+		{name: "large float without exponent", source: map[string]any{"version": 1e21}, expected: "1000000000000000000000"},
+		{name: "float32", source: map[string]any{"version": float32(0.1)}, expected: "0.1"},
+		{name: "int", source: map[string]any{"version": 3}, expected: "3"},
+		{name: "uint64", source: map[string]any{"version": uint64(18446744073709551615)}, expected: "18446744073709551615"},
+		{name: "bool", source: map[string]any{"version": true}, expected: "true"},
+		{name: "nested map", source: map[string]any{"version": map[string]any{"major": 1}}, expected: "fallback"},
+		{name: "slice", source: map[string]any{"version": []any{"1"}}, expected: "fallback"},
+		{name: "null", source: map[string]any{"version": nil}, expected: "fallback"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, getMapFieldAsStringCoerce(tt.source, "version", "fallback"), "scalar should be stringified")
+		})
+	}
+}
+
+func TestFilterMapKeysFunc(t *testing.T) {
+	original := map[string]any{"name": "a", "x-internal": 1, "on": "push"}
+
+	result := filterMapKeysFunc(original, func(key string) bool { return key != "on" })
+
+	assert.Equal(t, map[string]any{"name": "a", "x-internal": 1}, result, "only accepted keys should be kept")
+	assert.Len(t, original, 3, "original map should not be modified")
+}
+
+func TestGetMapFieldAsFloat64(t *testing.T) {
+	tests := []struct {
+		name     string
+		source   map[string]any
+		expected float64
+	}{
+		{name: "nil source", source: nil, expected: 0.5},
+		{name: "missing key", source: map[string]any{"other": 1.0}, expected: 0.5},
+		{name: "float64", source: map[string]any{"threshold": 0.75}, expected: 0.75},
+		{name: "float32", source: map[string]any{"threshold": float32(0.25)}, expected: 0.25},
+		{name: "int promoted", source: map[string]any{"threshold": 2}, expected: 2},
+		{name: "int64 promoted", source: map[string]any{"threshold": int64(-3)}, expected: -3},
+		{name: "uint64 promoted", source: map[string]any{"threshold": uint64(7)}, expected: 7},
+		{name: "non-numeric string", source: map[string]any{"threshold": "0.9"}, expected: 0.5},
+		{name: "bool", source: map[string]any{"threshold": true}, expected: 0.5},
+		{name: "nil value", source: map[string]any{"threshold": nil}, expected: 0.5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.expected, getMapFieldAsFloat64(tt.source, "threshold", 0.5), 1e-9, "number should be read from the field")
+		})
+	}
+}
+
+func TestGetMapFieldAsDuration(t *testing.T) {
+	const fallback = 10 * time.Minute
+	tests := []struct {
+		name     string
+		source   map[string]any
+		expected time.Duration
+	}{
+		{name: "nil source", source: nil, expected: fallback},
+		{name: "missing key", source: map[string]any{"other": "5m"}, expected: fallback},
+		{name: "seconds string", source: map[string]any{"timeout": "30s"}, expected: 30 * time.Second},
+		{name: "minutes string", source: map[string]any{"timeout": "5m"}, expected: 5 * time.Minute},
+		{name: "compound string", source: map[string]any{"timeout": "1h30m"}, expected: 90 * time.Minute},
+		{name: "integer seconds", source: map[string]any{"timeout": 45}, expected: 45 * time.Second},
+		{name: "whole float seconds", source: map[string]any{"timeout": 60.0}, expected: time.Minute},
+		{name: "unparseable string", source: map[string]any{"timeout": "five minutes"}, expected: fallback},
+		{name: "string without unit", source: map[string]any{"timeout": "30"}, expected: fallback},
+		{name: "negative duration", source: map[string]any{"timeout": "-5m"}, expected: fallback},
+		{name: "unsupported type", source: map[string]any{"timeout": true}, expected: fallback},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, getMapFieldAsDuration(tt.source, "timeout", fallback), "duration should be read from the field")
+		})
+	}
+}
+
+func TestLookupMapFieldIgnoreCase(t *testing.T) {
+	tests := []struct {
+		name      string
+		source    map[string]any
+		expected  any
+		found     bool
+		collision bool
+	}{
+		{name: "nil source", source: nil},
+		{name: "missing key", source: map[string]any{"other": 1}},
+		{name: "exact match", source: map[string]any{"timeout": 5}, expected: 5, found: true},
+		{name: "differing case", source: map[string]any{"Timeout": 10}, expected: 10, found: true},
+		{name: "exact match wins over collision", source: map[string]any{"TIMEOUT": 1, "timeout": 2}, expected: 2, found: true, collision: true},
+		{name: "collision without exact match", source: map[string]any{"Timeout": 1, "TIMEOUT": 2}, expected: 2, found: true, collision: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			printer := &capturingPrinter{}
+			original := mapHelpersLog
+			mapHelpersLog = newSecretRedactingLogger(printer)
+			t.Cleanup(func() { mapHelpersLog = original })
+
+			value, found := lookupMapFieldIgnoreCase(tt.source, "timeout")
+			assert.Equal(t, tt.found, found, "key should be found ignoring case")
+			assert.Equal(t, tt.expected, value, "matching value should be returned")
+			if tt.collision {
+				assert.Contains(t, printer.output(), "matches several keys ignoring case", "ambiguous keys should be logged")
+			} else {
+				assert.Empty(t, printer.messages, "unambiguous lookups should not log")
+			}
+		})
+	}
+}
+
+func TestGetMapFieldIgnoreCaseVariants(t *testing.T) {
+	source := map[string]any{
+		"Name":    "agent",
+		"ENABLED": true,
+		"Env":     map[string]any{"KEY": "value"},
+		"count":   3,
+	}
+
+	assert.Equal(t, "agent", getMapFieldAsStringIgnoreCase(source, "name", "default"), "string should match ignoring case")
+	assert.Equal(t, "default", getMapFieldAsStringIgnoreCase(source, "count", "default"), "non-string should use fallback")
+	assert.Equal(t, "default", getMapFieldAsStringIgnoreCase(source, "missing", "default"), "missing key should use fallback")
+
+	assert.True(t, getMapFieldAsBoolIgnoreCase(source, "enabled", false), "bool should match ignoring case")
+	assert.True(t, getMapFieldAsBoolIgnoreCase(source, "name", true), "non-bool should use fallback")
+	assert.False(t, getMapFieldAsBoolIgnoreCase(source, "missing", false), "missing key should use fallback")
+
+	assert.Equal(t, map[string]any{"KEY": "value"}, getMapFieldAsMapIgnoreCase(source, "env"), "map should match ignoring case")
+	assert.Nil(t, getMapFieldAsMapIgnoreCase(source, "name"), "non-map should return nil")
+	assert.Nil(t, getMapFieldAsMapIgnoreCase(source, "missing"), "missing key should return nil")
+}
+
+func TestFilterMapKeysGlob(t *testing.T) {
+	original := map[string]any{
+		"name":       "workflow",
+		"x-owner":    "team",
+		"x-internal": true,
+		"on":         "push",
+		"xray":       1,
+	}
+
+	tests := []struct {
+		name     string
+		patterns []string
+		expected map[string]any
+	}{
+		{
+			name:     "glob pattern",
+			patterns: []string{"x-*"},
+			expected: map[string]any{"name": "workflow", "on": "push", "xray": 1},
+		},
+		{
+			name:     "exact key",
+			patterns: []string{"on"},
+			expected: map[string]any{"name": "workflow", "x-owner": "team", "x-internal": true, "xray": 1},
+		},
+		{
+			name:     "no match",
+			patterns: []string{"y-*"},
+			expected: original,
+		},
+		{
+			name:     "malformed pattern is ignored",
+			patterns: []string{"[", "name"},
+			expected: map[string]any{"x-owner": "team", "x-internal": true, "on": "push", "xray": 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, filterMapKeysGlob(original, tt.patterns...), "matching keys should be excluded")
+		})
+	}
+}
+
+func TestGetMapFieldAsBoolCoerce(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    any
+		fallback bool
+		expected bool
+	}{
+		{name: "real bool", value: true, fallback: false, expected: true},
+		{name: "yes", value: "yes", fallback: false, expected: true},
+		{name: "mixed case with whitespace", value: " True ", fallback: false, expected: true},
+		{name: "off", value: "off", fallback: true, expected: false},
+		{name: "string zero", value: "0", fallback: true, expected: false},
+		{name: "integer one", value: 1, fallback: false, expected: true},
+		{name: "integer zero", value: uint64(0), fallback: true, expected: false},
+		{name: "ambiguous string", value: "maybe", fallback: true, expected: true},
+		{name: "other integer", value: 2, fallback: false, expected: false},
+		{name: "float", value: 1.0, fallback: false, expected: false},
+		{name: "null", value: nil, fallback: true, expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source := map[string]any{"enabled": tt.value}
+			assert.Equal(t, tt.expected, getMapFieldAsBoolCoerce(source, "enabled", tt.fallback), "value should be coerced or fall back")
+		})
+	}
+
+	assert.True(t, getMapFieldAsBoolCoerce(nil, "enabled", true), "missing key should use fallback")
+}
+
+func TestGetMapFieldAsBoolCoerceLogsAmbiguousValues(t *testing.T) {
+	printer := &capturingPrinter{}
+	original := mapHelpersLog
+	mapHelpersLog = newSecretRedactingLogger(printer)
+	t.Cleanup(func() { mapHelpersLog = original })
+
+	getMapFieldAsBoolCoerce(map[string]any{"enabled": "maybe"}, "enabled", false)
+
+	assert.Contains(t, printer.output(), "Field enabled is not a recognized boolean", "ambiguous values should be logged")
+}
+
+func TestRequireMapFieldAsString(t *testing.T) {
+	tests := []struct {
+		name     string
+		source   map[string]any
+		expected string
+		wantErr  string
+	}{
+		{name: "present", source: map[string]any{"name": "agent"}, expected: "agent"},
+		{name: "empty string is present", source: map[string]any{"name": ""}, expected: ""},
+		{name: "missing", source: map[string]any{"other": "x"}, wantErr: "required field 'name' is missing"},
+		{name: "nil source", source: nil, wantErr: "required field 'name' is missing"},
+		{name: "null", source: map[string]any{"name": nil}, wantErr: "required field 'name' is missing"},
+		{name: "wrong type", source: map[string]any{"name": 42}, wantErr: "field 'name' must be a string"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, err := requireMapFieldAsString(tt.source, "name")
+			if tt.wantErr == "" {
+				require.NoError(t, err, "present string field should be accepted")
+				assert.Equal(t, tt.expected, value, "field value should be returned")
+				return
+			}
+			var validationErr *WorkflowValidationError
+			require.ErrorAs(t, err, &validationErr, "error should be a validation error")
+			assert.Equal(t, "name", validationErr.Field, "error should name the field")
+			assert.Contains(t, err.Error(), tt.wantErr, "error should explain the problem")
+			assert.Empty(t, value, "no value should be returned on error")
+		})
+	}
+}
+
+func TestRequireMapFieldAsMap(t *testing.T) {
+	tests := []struct {
+		name     string
+		source   map[string]any
+		expected map[string]any
+		wantErr  string
+	}{
+		{name: "present", source: map[string]any{"env": map[string]any{"KEY": "value"}}, expected: map[string]any{"KEY": "value"}},
+		{name: "missing", source: map[string]any{}, wantErr: "required field 'env' is missing"},
+		{name: "null", source: map[string]any{"env": nil}, wantErr: "required field 'env' is missing"},
+		{name: "wrong type", source: map[string]any{"env": []any{"KEY=value"}}, wantErr: "field 'env' must be a map"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, err := requireMapFieldAsMap(tt.source, "env")
+			if tt.wantErr == "" {
+				require.NoError(t, err, "present map field should be accepted")
+				assert.Equal(t, tt.expected, value, "field value should be returned")
+				return
+			}
+			var validationErr *WorkflowValidationError
+			require.ErrorAs(t, err, &validationErr, "error should be a validation error")
+			assert.Equal(t, "env", validationErr.Field, "error should name the field")
+			assert.Contains(t, err.Error(), tt.wantErr, "error should explain the problem")
+			assert.Nil(t, value, "no value should be returned on error")
+		})
+	}
+}
+
+func TestCoerceToStringSlice(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    any
+		expected []string
+		ok       bool
+	}{
+		{name: "string", value: "push", expected: []string{"push"}, ok: true},
+		{name: "list", value: []any{"push", "pull_request"}, expected: []string{"push", "pull_request"}, ok: true},
+		{name: "string slice", value: []string{"push"}, expected: []string{"push"}, ok: true},
+		{name: "empty list", value: []any{}, expected: []string{}, ok: true},
+		{name: "nil", value: nil, expected: nil, ok: true},
+		{name: "list with non-string", value: []any{"push", 42}, expected: nil, ok: false},
+		{name: "map", value: map[string]any{"push": nil}, expected: nil, ok: false},
+		{name: "number", value: 42, expected: nil, ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, ok := coerceToStringSlice(tt.value)
+			assert.Equal(t, tt.ok, ok, "compatibility should be reported")
+			assert.Equal(t, tt.expected, result, "value should be converted")
+		})
+	}
+}
+
+func TestFlattenMap(t *testing.T) {
+	tests := []struct {
+		name     string
+		source   map[string]any
+		expected map[string]any
+	}{
+		{
+			name: "two-level nesting",
+			source: map[string]any{
+				"name":  "workflow",
+				"tools": map[string]any{"github": map[string]any{"mode": "remote"}, "edit": nil},
+			},
+			expected: map[string]any{"name": "workflow", "tools.github.mode": "remote", "tools.edit": nil},
+		},
+		{
+			name:     "slice value",
+			source:   map[string]any{"tools": map[string]any{"playwright": map[string]any{"allowed": []any{"github.com"}}}},
+			expected: map[string]any{"tools.playwright.allowed": []any{"github.com"}},
+		},
+		{
+			name:     "empty nested map",
+			source:   map[string]any{"tools": map[string]any{"bash": map[string]any{}}},
+			expected: map[string]any{"tools.bash": map[string]any{}},
+		},
+		{
+			name:     "empty map",
+			source:   map[string]any{},
+			expected: map[string]any{},
+		},
+		{
+			name:     "nil map",
+			source:   nil,
+			expected: map[string]any{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, flattenMap(tt.source), "nested keys should be joined with dots")
+		})
+	}
+}
@@ -18,13 +18,7 @@ func selectSerenaContainer(serenaTool any) string {
 
 	if toolMap, ok := serenaTool.(map[string]any); ok {
 		// Check for short syntax (array of language names)
-		if langs, ok := toolMap["langs"].([]any); ok {
-			for _, lang := range langs {
-				if langStr, ok := lang.(string); ok {
-					requestedLanguages = append(requestedLanguages, langStr)
-				}
-			}
-		}
+		requestedLanguages = getMapFieldAsStringSlice(toolMap, "langs")
 
 		// Check for detailed language configuration
 		if langs, ok := toolMap["languages"].(map[string]any); ok {
@@ -0,0 +1,95 @@
+// This file provides validation of workflow permissions against the repository policy.
+//
+// # Permissions Policy Validation
+//
+// Locked-down repositories can restrict the permission scopes agentic workflows may
+// grant with a permissions policy in .github/gh-aw.yml:
+//
+//	permissions:
+//	  allowed:          # maximum level per scope; unlisted scopes may not be granted
+//	    contents: read
+//	    issues: write
+//	  denied: [id-token] # scopes that may never be granted
+//
+// The policy is checked against the workflow's own permissions (which apply to the
+// agent job) and the permissions computed for the safe outputs job, so a forbidden
+// scope is reported at compile time instead of being granted at runtime. A scope is
+// granted when its level is read or write; none is always allowed.
+//
+// For general validation, see validation.go.
+// For detailed documentation, see scratchpad/validation-architecture.md
+
+package workflow
+
+import (
+	"fmt"
+	"slices"
+)
+
+var permissionsPolicyValidationLog = newValidationLogger("permissions_policy")
+
+// validatePermissionsPolicy validates the workflow permissions and the computed safe
+// outputs permissions against workflowData.PermissionsPolicy. Returns nil when the
+// workflow has no policy.
+func validatePermissionsPolicy(workflowData *WorkflowData) error {
+	policy := workflowData.PermissionsPolicy
+	if policy == nil {
+		return nil
+	}
+
+	permissionsPolicyValidationLog.Printf("Validating permissions against repository policy: %s", policy.ConfigPath)
+	if workflowData.Permissions != "" {
+		if permissions := NewPermissionsParser(workflowData.Permissions).ToPermissions(); permissions != nil {
+			if err := policy.check(permissions, "the workflow"); err != nil {
+				return err
+			}
+		}
+	}
+	if workflowData.SafeOutputs != nil {
+		if err := policy.check(ComputePermissionsForSafeOutputs(workflowData.SafeOutputs), "the safe outputs job"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// check returns a validation error for the first scope in permissions that the policy
+// forbids. grantedBy describes where the permissions come from for the error message.
+func (p *PermissionsPolicy) check(permissions *Permissions, grantedBy string) error {
+	scopes := append(GetAllPermissionScopes(), PermissionCopilotRequests)
+	for _, scope := range scopes {
+		level, granted := permissions.Get(scope)
+		if !granted || level == PermissionNone {
+			continue
+		}
+
+		grant := fmt.Sprintf("%s: %s", scope, level)
+		if slices.Contains(p.Denied, scope) {
+			permissionsPolicyValidationLog.Printf("Denied scope granted by %s: %s", grantedBy, grant)
+			return p.violation(grant, fmt.Sprintf("%s grants '%s', but the '%s' scope is denied by the repository permissions policy", grantedBy, grant, scope))
+		}
+		if p.Allowed == nil {
+			continue
+		}
+		maxLevel, listed := p.Allowed[scope]
+		if !listed || maxLevel == PermissionNone {
+			permissionsPolicyValidationLog.Printf("Unlisted scope granted by %s: %s", grantedBy, grant)
+			return p.violation(grant, fmt.Sprintf("%s grants '%s', but the '%s' scope is not in the repository permissions allowlist", grantedBy, grant, scope))
+		}
+		if level == PermissionWrite && maxLevel == PermissionRead {
+			permissionsPolicyValidationLog.Printf("Scope exceeds allowed level in %s: %s", grantedBy, grant)
+			return p.violation(grant, fmt.Sprintf("%s grants '%s', but the repository permissions policy allows at most '%s: read'", grantedBy, grant, scope))
+		}
+	}
+	return nil
+}
+
+// violation builds the validation error for a forbidden grant
+func (p *PermissionsPolicy) violation(grant string, reason string) error {
+	return NewValidationError(
+		"permissions",
+		grant,
+		reason,
+		fmt.Sprintf("Remove or reduce the permission (and any safe output that requires it), or update the permissions policy in %s.", p.ConfigPath),
+	)
+}
@@ -0,0 +1,127 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidatePermissionsPolicy(t *testing.T) {
+	policy := &PermissionsPolicy{
+		Allowed: map[PermissionScope]PermissionLevel{
+			PermissionContents: PermissionRead,
+			PermissionIssues:   PermissionWrite,
+			PermissionActions:  PermissionNone,
+		},
+		Denied:     []PermissionScope{PermissionIdToken},
+		ConfigPath: ".github/gh-aw.yml",
+	}
+
+	tests := []struct {
+		name         string
+		workflowData *WorkflowData
+		wantErr      string
+	}{
+		{
+			name:         "no policy",
+			workflowData: &WorkflowData{Permissions: "permissions: write-all"},
+		},
+		{
+			name:         "permissions within the allowlist",
+			workflowData: &WorkflowData{Permissions: "permissions:\n  contents: read\n  issues: write\n  actions: none", PermissionsPolicy: policy},
+		},
+		{
+			name:         "write exceeds allowed read",
+			workflowData: &WorkflowData{Permissions: "permissions:\n  contents: write", PermissionsPolicy: policy},
+			wantErr:      "allows at most 'contents: read'",
+		},
+		{
+			name:         "unlisted scope",
+			workflowData: &WorkflowData{Permissions: "permissions:\n  pull-requests: read", PermissionsPolicy: policy},
+			wantErr:      "not in the repository permissions allowlist",
+		},
+		{
+			name:         "scope allowed at none",
+			workflowData: &WorkflowData{Permissions: "permissions:\n  actions: read", PermissionsPolicy: policy},
+			wantErr:      "not in the repository permissions allowlist",
+		},
+		{
+			name:         "denied scope",
+			workflowData: &WorkflowData{Permissions: "permissions:\n  id-token: write", PermissionsPolicy: policy},
+			wantErr:      "'id-token' scope is denied",
+		},
+		{
+			name:         "shorthand grants every scope",
+			workflowData: &WorkflowData{Permissions: "permissions: read-all", PermissionsPolicy: policy},
+			wantErr:      "not in the repository permissions allowlist",
+		},
+		{
+			name: "safe outputs permissions are checked",
+			workflowData: &WorkflowData{
+				Permissions:       "permissions:\n  contents: read",
+				SafeOutputs:       &SafeOutputsConfig{CreatePullRequests: &CreatePullRequestsConfig{}},
+				PermissionsPolicy: policy,
+			},
+			wantErr: "the safe outputs job grants",
+		},
+		{
+			name: "denylist without allowlist",
+			workflowData: &WorkflowData{
+				Permissions:       "permissions:\n  contents: write\n  id-token: write",
+				PermissionsPolicy: &PermissionsPolicy{Denied: []PermissionScope{PermissionIdToken}, ConfigPath: ".github/gh-aw.yml"},
+			},
+			wantErr: "'id-token' scope is denied",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validatePermissionsPolicy(tt.workflowData)
+			if tt.wantErr == "" {
+				assert.NoError(t, err, "permissions should satisfy the policy")
+				return
+			}
+			require.Error(t, err, "forbidden permission should be rejected")
+			var validationErr *WorkflowValidationError
+			require.ErrorAs(t, err, &validationErr, "error should be a validation error")
+			assert.Equal(t, "permissions", validationErr.Field, "error should reference the permissions field")
+			assert.Contains(t, err.Error(), tt.wantErr, "error should explain the violation")
+			assert.Contains(t, err.Error(), ".github/gh-aw.yml", "error should reference the policy file")
+		})
+	}
+}
+
+func TestCompileWorkflowRepoPermissionsPolicy(t *testing.T) {
+	_, workflowsDir := setupRepoConfigTest(t, `permissions:
+  allowed:
+    contents: read
+    issues: read
+  denied: [id-token]
+`)
+	workflowContent := func(permissions string) string {
+		return `---
+on: issues
+permissions:
+` + permissions + `engine: copilot
+---
+
+# Policy Workflow
+`
+	}
+
+	allowedPath := filepath.Join(workflowsDir, "allowed.md")
+	require.NoError(t, os.WriteFile(allowedPath, []byte(workflowContent("  contents: read\n  issues: read\n")), 0644), "should write workflow")
+	require.NoError(t, NewCompiler().CompileWorkflow(allowedPath), "workflow within the policy should compile")
+
+	forbiddenPath := filepath.Join(workflowsDir, "forbidden.md")
+	require.NoError(t, os.WriteFile(forbiddenPath, []byte(workflowContent("  contents: read\n  pull-requests: read\n")), 0644), "should write workflow")
+	err := NewCompiler().CompileWorkflow(forbiddenPath)
+	require.Error(t, err, "workflow exceeding the policy should fail")
+	assert.Contains(t, err.Error(), "'pull-requests: read'", "error should name the forbidden grant")
+	assert.Contains(t, err.Error(), "not in the repository permissions allowlist", "error should explain the violation")
+}
@@ -1,11 +1,7 @@
 package workflow
 
 import (
-	"fmt"
-	"os"
 	"strings"
-
-	"github.com/github/gh-aw/pkg/console"
 )
 
 var pushToPullRequestBranchValidationLog = newValidationLogger("push_to_pull_request_branch_validation")
@@ -46,8 +42,7 @@ func (c *Compiler) validatePushToPullRequestBranchWarnings(safeOutputs *SafeOutp
 			"    fetch: [\"*\"]      # fetch all remote branches",
 			"    fetch-depth: 0   # fetch full history",
 		}, "\n")
-		fmt.Fprintln(os.Stderr, console.FormatWarningMessage(msg))
-		c.IncrementWarningCount()
+		c.emitWarning(msg)
 	}
 
 	// Warning 2: no constraints restricting which PRs can be targeted.
@@ -63,8 +58,7 @@ func (c *Compiler) validatePushToPullRequestBranchWarnings(safeOutputs *SafeOutp
 			"    title-prefix: \"[bot] \"  # only PRs whose title starts with this prefix",
 			"    labels: [automated]      # only PRs that carry all of these labels",
 		}, "\n")
-		fmt.Fprintln(os.Stderr, console.FormatWarningMessage(msg))
-		c.IncrementWarningCount()
+		c.emitWarning(msg)
 	}
 }
 
@@ -186,11 +186,36 @@ func TestValidateSecretReferences(t *testing.T) {
 			secrets: []string{"API KEY"},
 			wantErr: true,
 		},
+		{
+			name:    "automatic GITHUB_TOKEN",
+			secrets: []string{"GITHUB_TOKEN"},
+			wantErr: false,
+		},
+		{
+			name:    "reserved GITHUB_ prefix",
+			secrets: []string{"API_KEY", "GITHUB_FOO"},
+			wantErr: true,
+		},
+		{
+			name:    "secret name at the maximum length",
+			secrets: []string{strings.Repeat("A", maxSecretNameLength)},
+			wantErr: false,
+		},
+		{
+			name:    "secret name over the maximum length",
+			secrets: []string{strings.Repeat("A", maxSecretNameLength+1)},
+			wantErr: true,
+		},
+		{
+			name:    "GITHUB in the middle of the name",
+			secrets: []string{"MY_GITHUB_FOO", "GITHUBFOO"},
+			wantErr: false,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := validateSecretReferences(tt.secrets)
+			_, err := validateSecretReferences(tt.secrets, SecretNamingStrict, false)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("validateSecretReferences() error = %v, wantErr %v", err, tt.wantErr)
 			}
@@ -0,0 +1,187 @@
+// This file provides loading of the repository-level gh-aw configuration file.
+//
+// # Repository Configuration
+//
+// A repository may define defaults shared by every workflow in .github/gh-aw.yml:
+//
+//	features:
+//	  mcp-gateway: true
+//	permissions:
+//	  allowed:
+//	    contents: read
+//	    issues: write
+//	  denied: [id-token]
+//
+// Repository feature defaults are merged at the lowest precedence: features from
+// imports and from the workflow's own frontmatter override them. The permissions
+// policy limits the scopes any workflow may grant (see permissions_policy_validation.go).
+// The file is validated against the repository config schema and errors are reported
+// with the config file path.
+//
+// The config file is located by walking up from the workflow's directory to the
+// repository root (the first directory containing .git), and is loaded at most once
+// per compiler instance.
+
+package workflow
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/parser"
+	"github.com/goccy/go-yaml"
+)
+
+var repoConfigLog = logger.New("workflow:repo_config")
+
+// RepoConfigFileName is the name of the repository-level config file inside .github
+const RepoConfigFileName = "gh-aw.yml"
+
+// RepoConfig is the repository-level gh-aw configuration
+type RepoConfig struct {
+	Features    map[string]any     `yaml:"features,omitempty"`    // Default features for every workflow
+	Permissions *PermissionsPolicy `yaml:"permissions,omitempty"` // Permission scopes workflows may grant
+}
+
+// PermissionsPolicy restricts the permissions that workflows in a repository may grant
+type PermissionsPolicy struct {
+	Allowed    map[PermissionScope]PermissionLevel // Maximum level per scope; when set, unlisted scopes may not be granted
+	Denied     []PermissionScope                   // Scopes that may never be granted at any level
+	ConfigPath string                              // Path of the config file that defined the policy (for error messages)
+}
+
+// findRepoConfigPath returns the path of the .github/gh-aw.yml file that applies to the
+// workflow at markdownPath, or "" when none exists. The search stops at the repository root.
+func findRepoConfigPath(markdownPath string) string {
+	dir, err := filepath.Abs(filepath.Dir(markdownPath))
+	if err != nil {
+		return ""
+	}
+	for {
+		candidate := filepath.Join(dir, ".github", RepoConfigFileName)
+		if info, err := os.Stat(candidate); err == nil && !info.IsDir() {
+			return candidate
+		}
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			return ""
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// LoadRepoConfig reads, parses, and validates the repository config file at configPath
+func LoadRepoConfig(configPath string) (*RepoConfig, error) {
+	repoConfigLog.Printf("Loading repository config: %s", configPath)
+	content, err := os.ReadFile(configPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read repository config %s: %w", configPath, err)
+	}
+
+	raw := map[string]any{}
+	if err := yaml.Unmarshal(content, &raw); err != nil {
+		return nil, formatCompilerError(configPath, "error", "failed to parse repository config: "+err.Error(), err)
+	}
+	if err := parser.ValidateRepoConfigWithSchema(raw, configPath); err != nil {
+		return nil, err
+	}
+
+	config := &RepoConfig{}
+	if features, ok := raw["features"].(map[string]any); ok {
+		config.Features = features
+	}
+	if permissions, ok := raw["permissions"].(map[string]any); ok {
+		config.Permissions = parsePermissionsPolicy(permissions, configPath)
+	}
+	repoConfigLog.Printf("Loaded repository config: features=%d, permissions policy=%t", len(config.Features), config.Permissions != nil)
+	return config, nil
+}
+
+// parsePermissionsPolicy converts the schema-validated permissions section of a repository
+// config into a PermissionsPolicy
+func parsePermissionsPolicy(permissions map[string]any, configPath string) *PermissionsPolicy {
+	policy := &PermissionsPolicy{ConfigPath: configPath}
+	if allowed, ok := permissions["allowed"].(map[string]any); ok {
+		policy.Allowed = make(map[PermissionScope]PermissionLevel, len(allowed))
+		for scope, level := range allowed {
+			if levelStr, ok := level.(string); ok {
+				policy.Allowed[PermissionScope(scope)] = PermissionLevel(levelStr)
+			}
+		}
+	}
+	if denied, ok := permissions["denied"].([]any); ok {
+		for _, scope := range denied {
+			if scopeStr, ok := scope.(string); ok {
+				policy.Denied = append(policy.Denied, PermissionScope(scopeStr))
+			}
+		}
+	}
+	return policy
+}
+
+// repoConfigFor returns the repository config that applies to the workflow at markdownPath,
+// or nil when there is none. An empty markdownPath skips the lookup (e.g., for string-based
+// compilation). Loaded configs are cached per config path.
+func (c *Compiler) repoConfigFor(markdownPath string) (*RepoConfig, error) {
+	if markdownPath == "" {
+		return nil, nil
+	}
+	configPath := findRepoConfigPath(markdownPath)
+	if configPath == "" {
+		return nil, nil
+	}
+	if config, ok := c.repoConfigs[configPath]; ok {
+		return config, nil
+	}
+
+	config, err := LoadRepoConfig(configPath)
+	if err != nil {
+		return nil, err
+	}
+	if c.repoConfigs == nil {
+		c.repoConfigs = make(map[string]*RepoConfig)
+	}
+	c.repoConfigs[configPath] = config
+	return config, nil
+}
+
+// repoPermissionsPolicyFor returns the permissions policy from the repository config that
+// applies to the workflow at markdownPath, or nil when there is none
+func (c *Compiler) repoPermissionsPolicyFor(markdownPath string) (*PermissionsPolicy, error) {
+	repoConfig, err := c.repoConfigFor(markdownPath)
+	if err != nil || repoConfig == nil {
+		return nil, err
+	}
+	return repoConfig.Permissions, nil
+}
+
+// mergeWorkflowFeatures merges imported features and repository feature defaults into the
+// workflow's top-level features. Precedence: top-level > imports (in order) > repository config.
+func (c *Compiler) mergeWorkflowFeatures(workflowData *WorkflowData, importedFeatures []map[string]any, markdownPath string) error {
+	repoConfig, err := c.repoConfigFor(markdownPath)
+	if err != nil {
+		return err
+	}
+
+	featureLayers := importedFeatures
+	if repoConfig != nil && len(repoConfig.Features) > 0 {
+		repoConfigLog.Printf("Applying %d repository feature defaults", len(repoConfig.Features))
+		featureLayers = append(featureLayers[:len(featureLayers):len(featureLayers)], repoConfig.Features)
+	}
+	if len(featureLayers) == 0 {
+		return nil
+	}
+
+	mergedFeatures, err := c.MergeFeatures(workflowData.Features, featureLayers)
+	if err != nil {
+		return errors.New("failed to merge features: " + err.Error())
+	}
+	workflowData.Features = mergedFeatures
+	return nil
+}
@@ -0,0 +1,167 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupRepoConfigTest creates a repository with a workflows directory and an optional repo config file
+func setupRepoConfigTest(t *testing.T, repoConfig string) (string, string) {
+	t.Helper()
+	repoDir := testutil.TempDir(t, "repo-config-*")
+	workflowsDir := filepath.Join(repoDir, ".github", "workflows")
+	require.NoError(t, os.MkdirAll(workflowsDir, 0755), "should create workflows directory")
+	require.NoError(t, os.MkdirAll(filepath.Join(repoDir, ".git"), 0755), "should create .git directory")
+	if repoConfig != "" {
+		configPath := filepath.Join(repoDir, ".github", RepoConfigFileName)
+		require.NoError(t, os.WriteFile(configPath, []byte(repoConfig), 0644), "should write repo config")
+	}
+	return repoDir, workflowsDir
+}
+
+func TestFindRepoConfigPath(t *testing.T) {
+	repoDir, workflowsDir := setupRepoConfigTest(t, "features: {}\n")
+	assert.Equal(t, filepath.Join(repoDir, ".github", RepoConfigFileName), findRepoConfigPath(filepath.Join(workflowsDir, "test.md")), "config should be found from the workflows directory")
+
+	_, workflowsDir = setupRepoConfigTest(t, "")
+	assert.Empty(t, findRepoConfigPath(filepath.Join(workflowsDir, "test.md")), "search should stop at the repository root")
+}
+
+func TestLoadRepoConfig(t *testing.T) {
+	tests := []struct {
+		name         string
+		content      string
+		wantFeatures map[string]any
+		wantErr      string
+	}{
+		{
+			name:         "features",
+			content:      "features:\n  mcp-gateway: true\n  action-mode: release\n",
+			wantFeatures: map[string]any{"mcp-gateway": true, "action-mode": "release"},
+		},
+		{
+			name:    "empty file",
+			content: "# no settings\n",
+		},
+		{
+			name:    "unknown property",
+			content: "feature:\n  mcp-gateway: true\n",
+			wantErr: "invalid repository config",
+		},
+		{
+			name:    "invalid feature value",
+			content: "features:\n  mcp-gateway: [true]\n",
+			wantErr: "invalid repository config",
+		},
+		{
+			name:    "invalid YAML",
+			content: "features: [\n",
+			wantErr: "failed to parse repository config",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configPath := filepath.Join(testutil.TempDir(t, "repo-config-*"), RepoConfigFileName)
+			require.NoError(t, os.WriteFile(configPath, []byte(tt.content), 0644), "should write repo config")
+
+			config, err := LoadRepoConfig(configPath)
+			if tt.wantErr != "" {
+				require.Error(t, err, "invalid config should be rejected")
+				assert.Contains(t, err.Error(), tt.wantErr, "error should describe the problem")
+				assert.Contains(t, err.Error(), configPath, "error should reference the config file path")
+				return
+			}
+			require.NoError(t, err, "valid config should load")
+			if tt.wantFeatures == nil {
+				assert.Empty(t, config.Features, "no features should be loaded")
+			} else {
+				assert.Equal(t, tt.wantFeatures, config.Features, "features should be loaded")
+			}
+		})
+	}
+}
+
+func TestLoadRepoConfigPermissionsPolicy(t *testing.T) {
+	configPath := filepath.Join(testutil.TempDir(t, "repo-config-*"), RepoConfigFileName)
+	content := "permissions:\n  allowed:\n    contents: read\n    issues: write\n  denied: [id-token]\n"
+	require.NoError(t, os.WriteFile(configPath, []byte(content), 0644), "should write repo config")
+
+	config, err := LoadRepoConfig(configPath)
+	require.NoError(t, err, "valid permissions policy should load")
+	require.NotNil(t, config.Permissions, "permissions policy should be loaded")
+	assert.Equal(t, map[PermissionScope]PermissionLevel{PermissionContents: PermissionRead, PermissionIssues: PermissionWrite}, config.Permissions.Allowed, "allowlist should be parsed")
+	assert.Equal(t, []PermissionScope{PermissionIdToken}, config.Permissions.Denied, "denylist should be parsed")
+	assert.Equal(t, configPath, config.Permissions.ConfigPath, "policy should record its config path")
+
+	require.NoError(t, os.WriteFile(configPath, []byte("permissions:\n  denied: [secrets]\n"), 0644), "should write repo config")
+	_, err = LoadRepoConfig(configPath)
+	require.Error(t, err, "unknown permission scope should be rejected")
+	assert.Contains(t, err.Error(), "invalid repository config", "error should come from schema validation")
+}
+
+func TestCompileWorkflowRepoConfigFeaturePrecedence(t *testing.T) {
+	_, workflowsDir := setupRepoConfigTest(t, `features:
+  from-repo: repo
+  shared: repo
+  overridden: repo
+`)
+	sharedDir := filepath.Join(workflowsDir, "shared")
+	require.NoError(t, os.MkdirAll(sharedDir, 0755), "should create shared directory")
+	require.NoError(t, os.WriteFile(filepath.Join(sharedDir, "features.md"), []byte(`---
+features:
+  shared: import
+  overridden: import
+---
+`), 0644), "should write shared workflow")
+
+	workflowPath := filepath.Join(workflowsDir, "test.md")
+	require.NoError(t, os.WriteFile(workflowPath, []byte(`---
+on: issues
+permissions:
+  contents: read
+engine: copilot
+imports:
+  - shared/features.md
+features:
+  overridden: top
+---
+
+# Test
+`), 0644), "should write workflow")
+
+	compiler := NewCompiler()
+	workflowData, err := compiler.ParseWorkflowFile(workflowPath)
+	require.NoError(t, err, "workflow should parse")
+	assert.Equal(t, map[string]any{
+		"from-repo":  "repo",
+		"shared":     "import",
+		"overridden": "top",
+	}, workflowData.Features, "top-level should override imports, which override repository defaults")
+}
+
+func TestCompileWorkflowInvalidRepoConfig(t *testing.T) {
+	_, workflowsDir := setupRepoConfigTest(t, "unknown: true\n")
+	workflowPath := filepath.Join(workflowsDir, "test.md")
+	require.NoError(t, os.WriteFile(workflowPath, []byte(`---
+on: issues
+permissions:
+  contents: read
+engine: copilot
+---
+
+# Test
+`), 0644), "should write workflow")
+
+	compiler := NewCompiler()
+	err := compiler.CompileWorkflow(workflowPath)
+	require.Error(t, err, "invalid repository config should fail compilation")
+	assert.Contains(t, err.Error(), filepath.Join(".github", RepoConfigFileName), "error should reference the config file")
+}
@@ -523,4 +523,5 @@ func (c *Compiler) addScheduleWarning(warning string) {
 		c.scheduleWarnings = []string{}
 	}
 	c.scheduleWarnings = append(c.scheduleWarnings, warning)
+	c.recordWarning(c.markdownPath, warning)
 }
@@ -8,7 +8,7 @@ import (
 	"github.com/github/gh-aw/pkg/logger"
 )
 
-var secretLog = logger.New("workflow:secret_extraction")
+var secretLog = newSecretRedactingLogger(logger.New("workflow:secret_extraction"))
 
 // Pre-compiled regex for secret extraction (performance optimization)
 // Matches: ${{ secrets.SECRET_NAME }} or ${{ secrets.SECRET_NAME || 'default' }}
@@ -61,7 +61,7 @@ func ExtractSecretsFromValue(value string) map[string]string {
 				varName := match[1]
 				// Store the full expression that contains this secret
 				secrets[varName] = expr
-				secretLog.Printf("Extracted secret: %s from expression: %s", varName, expr)
+				secretLog.Printf("Extracted secret from expression: %s", expr)
 			}
 		}
 	}
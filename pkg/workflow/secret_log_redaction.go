@@ -0,0 +1,61 @@
+// This file provides redaction of secret references in debug log output.
+//
+// Debug loggers print whatever they are given, so a message that includes frontmatter
+// values can leak the names of the secrets a workflow uses. The loggers of the secrets
+// and map helper paths are wrapped in a secretRedactingLogger, which masks every
+// secrets.NAME reference before the message reaches the log sink.
+
+package workflow
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// secretReferenceLogPattern matches secret references such as secrets.API_KEY
+var secretReferenceLogPattern = regexp.MustCompile(`secrets\.[A-Za-z_][A-Za-z0-9_]*`)
+
+// redactedSecretReference replaces every secret reference in redacted log messages
+const redactedSecretReference = "secrets.***"
+
+// redactSecretReferences masks the secret name of every secrets.NAME reference in message
+func redactSecretReferences(message string) string {
+	return secretReferenceLogPattern.ReplaceAllLiteralString(message, redactedSecretReference)
+}
+
+// debugPrinter is the subset of *logger.Logger that secretRedactingLogger writes to
+type debugPrinter interface {
+	Enabled() bool
+	Print(args ...any)
+}
+
+// secretRedactingLogger is a debug logger that redacts secret references from its messages
+type secretRedactingLogger struct {
+	printer debugPrinter
+}
+
+// newSecretRedactingLogger wraps printer (usually a *logger.Logger) in a redacting logger
+func newSecretRedactingLogger(printer debugPrinter) *secretRedactingLogger {
+	return &secretRedactingLogger{printer: printer}
+}
+
+// Enabled returns whether the underlying logger is enabled
+func (l *secretRedactingLogger) Enabled() bool {
+	return l.printer.Enabled()
+}
+
+// Printf formats and prints a message with secret references redacted, if the logger is enabled
+func (l *secretRedactingLogger) Printf(format string, args ...any) {
+	if !l.printer.Enabled() {
+		return
+	}
+	l.printer.Print(redactSecretReferences(fmt.Sprintf(format, args...)))
+}
+
+// Print prints a message with secret references redacted, if the logger is enabled
+func (l *secretRedactingLogger) Print(args ...any) {
+	if !l.printer.Enabled() {
+		return
+	}
+	l.printer.Print(redactSecretReferences(fmt.Sprint(args...)))
+}
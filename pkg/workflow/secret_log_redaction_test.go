@@ -0,0 +1,73 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// capturingPrinter records the messages printed to it
+type capturingPrinter struct {
+	messages []string
+}
+
+func (p *capturingPrinter) Enabled() bool { return true }
+
+func (p *capturingPrinter) Print(args ...any) { p.messages = append(p.messages, fmt.Sprint(args...)) }
+
+func (p *capturingPrinter) output() string { return strings.Join(p.messages, "\n") }
+
+func TestRedactSecretReferences(t *testing.T) {
+	tests := []struct {
+		name    string
+		message string
+		want    string
+	}{
+		{name: "no references", message: "Validating 3 secrets", want: "Validating 3 secrets"},
+		{name: "expression", message: "value: ${{ secrets.API_KEY }}", want: "value: ${{ secrets.*** }}"},
+		{name: "fallback chain", message: "${{ secrets.A || secrets.b_2 || 'x' }}", want: "${{ secrets.*** || secrets.*** || 'x' }}"},
+		{name: "vars are kept", message: "${{ vars.API_URL }}", want: "${{ vars.API_URL }}"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, redactSecretReferences(tt.message), "secret names should be masked")
+		})
+	}
+}
+
+func TestSecretRedactingLogger(t *testing.T) {
+	printer := &capturingPrinter{}
+	log := newSecretRedactingLogger(printer)
+
+	log.Printf("Extracted secret from expression: %s", "${{ secrets.API_KEY }}")
+	log.Print("value ", "secrets.OTHER_KEY")
+
+	assert.Equal(t, []string{"Extracted secret from expression: ${{ secrets.*** }}", "value secrets.***"}, printer.messages, "messages should be redacted")
+}
+
+func TestParseJobSecretsTypeMismatchDoesNotLogSecretNames(t *testing.T) {
+	printer := &capturingPrinter{}
+	original := secretsValidationLog
+	secretsValidationLog = newSecretRedactingLogger(printer)
+	t.Cleanup(func() { secretsValidationLog = original })
+
+	jobSecrets, err := parseJobSecrets(map[string]any{
+		"token":  []any{"${{ secrets.API_KEY }}"},
+		"nested": map[string]any{"value": "${{ secrets.NESTED_KEY }}"},
+		"valid":  "${{ secrets.VALID_KEY }}",
+	})
+	require.NoError(t, err, "non-string values should be ignored")
+	assert.Equal(t, map[string]string{"valid": "${{ secrets.VALID_KEY }}"}, jobSecrets, "only string values should be kept")
+
+	output := printer.output()
+	assert.Contains(t, output, "unsupported type", "type mismatches should be logged")
+	for _, name := range []string{"API_KEY", "NESTED_KEY", "VALID_KEY"} {
+		assert.NotContains(t, output, name, "secret names should never reach the log")
+	}
+}
@@ -0,0 +1,163 @@
+//go:build !js && !wasm
+
+// This file provides the opt-in check that referenced secrets exist.
+//
+// # Secrets Existence Validation
+//
+// A workflow that references a secret which was never created only fails at runtime.
+// When enabled with SetCheckSecretsExist (gh aw compile --check-secrets-exist), the
+// compiler lists the names of the repository secrets and of the organization secrets
+// shared with the repository through the GitHub API, and warns about references that
+// cannot be resolved. Only secret names are fetched, never values.
+//
+// An expression with fallbacks, such as ${{ secrets.A || secrets.B }}, is satisfied when
+// any of its secrets exists. GITHUB_TOKEN is always available.
+//
+// The name lists are fetched once per repository and cached for the rest of the run.
+
+package workflow
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+var secretsExistenceLog = newSecretRedactingLogger(newValidationLogger("secrets_existence"))
+
+// secretExpressionBlockPattern matches a single ${{ }} expression
+var secretExpressionBlockPattern = regexp.MustCompile(`\$\{\{[^}]+\}\}`)
+
+// secretNamesCacheEntry holds the secret names available to a repository
+type secretNamesCacheEntry struct {
+	names  map[string]bool
+	err    error
+	warned bool // Whether the fetch error has already been reported
+}
+
+// secretNamesCache caches the secret names per repository for the duration of the run
+var secretNamesCache = struct {
+	mu      sync.Mutex
+	entries map[string]*secretNamesCacheEntry
+}{entries: make(map[string]*secretNamesCacheEntry)}
+
+// listSecretNames lists the secret names returned by a GitHub API secrets endpoint.
+// It is a variable so that tests can avoid network access.
+var listSecretNames = func(endpoint string) ([]string, error) {
+	output, err := RunGH("Fetching secret names...", "api", "--paginate", "--jq", ".secrets[].name", endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list secrets from %s: %w", endpoint, err)
+	}
+	return strings.Fields(string(output)), nil
+}
+
+// clearSecretNamesCache clears the cached secret names
+func clearSecretNamesCache() {
+	secretNamesCache.mu.Lock()
+	defer secretNamesCache.mu.Unlock()
+	secretNamesCache.entries = make(map[string]*secretNamesCacheEntry)
+}
+
+// getSecretNames returns the secret names available to repo, fetching them on first use.
+// The second return value reports whether a fetch error is returned for the first time.
+func getSecretNames(repo string) (map[string]bool, bool, error) {
+	secretNamesCache.mu.Lock()
+	defer secretNamesCache.mu.Unlock()
+
+	entry, ok := secretNamesCache.entries[repo]
+	if !ok {
+		entry = &secretNamesCacheEntry{}
+		entry.names, entry.err = fetchSecretNames(repo)
+		secretNamesCache.entries[repo] = entry
+	}
+	if entry.err != nil {
+		firstReport := !entry.warned
+		entry.warned = true
+		return nil, firstReport, entry.err
+	}
+	return entry.names, false, nil
+}
+
+// fetchSecretNames lists the repository secrets and the organization secrets shared with repo
+func fetchSecretNames(repo string) (map[string]bool, error) {
+	secretsExistenceLog.Printf("Fetching secret names for: %s", repo)
+	names := map[string]bool{"GITHUB_TOKEN": true}
+	for _, endpoint := range []string{
+		"repos/" + repo + "/actions/secrets",
+		"repos/" + repo + "/actions/organization-secrets",
+	} {
+		endpointNames, err := listSecretNames(endpoint)
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range endpointNames {
+			// Secret names are case insensitive; the API returns them in uppercase
+			names[strings.ToUpper(name)] = true
+		}
+	}
+	secretsExistenceLog.Printf("Fetched %d secret names for: %s", len(names), repo)
+	return names, nil
+}
+
+// findMissingSecrets returns, for each expression in yamlContent whose secret references
+// all fail to resolve, the names it references. Each group is reported once.
+func findMissingSecrets(yamlContent string, existing map[string]bool) [][]string {
+	var missing [][]string
+	seen := make(map[string]bool)
+	for _, expr := range secretExpressionBlockPattern.FindAllString(yamlContent, -1) {
+		var names []string
+		resolved := false
+		for _, match := range secretReferencePattern.FindAllStringSubmatch(expr, -1) {
+			if existing[match[1]] {
+				resolved = true
+				break
+			}
+			names = append(names, match[1])
+		}
+		if resolved || len(names) == 0 {
+			continue
+		}
+		key := strings.Join(names, "|")
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		missing = append(missing, names)
+	}
+	return missing
+}
+
+// validateSecretsExist warns about secret references in the compiled workflow that do not
+// resolve to a repository or organization secret. It never fails compilation.
+func (c *Compiler) validateSecretsExist(yamlContent string, markdownPath string) {
+	if !c.checkSecretsExist {
+		return
+	}
+
+	repo, err := getCurrentRepository()
+	if err != nil {
+		secretsExistenceLog.Printf("Could not determine repository: %v", err)
+		c.emitCompilerWarning(markdownPath, fmt.Sprintf("could not check that secrets exist: %v", err))
+		return
+	}
+
+	existing, firstReport, err := getSecretNames(repo)
+	if err != nil {
+		if firstReport {
+			c.emitCompilerWarning(markdownPath, fmt.Sprintf("could not check that secrets exist in %s (listing secrets requires authentication with access to the repository secrets): %v", repo, err))
+		}
+		return
+	}
+
+	for _, names := range findMissingSecrets(yamlContent, existing) {
+		secretsExistenceLog.Printf("Found %d unresolved secret reference(s)", len(names))
+		var message string
+		if len(names) == 1 {
+			message = fmt.Sprintf("secret %s is not defined in repository %s or its organization", names[0], repo)
+		} else {
+			message = fmt.Sprintf("none of the secrets %s is defined in repository %s or its organization", strings.Join(names, ", "), repo)
+		}
+		c.emitCompilerWarning(markdownPath, message+" (environment secrets are not checked)")
+	}
+}
@@ -0,0 +1,104 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubSecretNames replaces the GitHub API listing and the current repository for a test
+func stubSecretNames(t *testing.T, names map[string][]string, err error) *int {
+	t.Helper()
+	calls := 0
+	original := listSecretNames
+	listSecretNames = func(endpoint string) ([]string, error) {
+		calls++
+		if err != nil {
+			return nil, err
+		}
+		return names[endpoint], nil
+	}
+	ClearRepositoryFeaturesCache()
+	clearSecretNamesCache()
+	currentRepositoryCache.mu.Lock()
+	currentRepositoryCache.result = "octo/repo"
+	currentRepositoryCache.done = true
+	currentRepositoryCache.mu.Unlock()
+	t.Cleanup(func() {
+		listSecretNames = original
+		ClearRepositoryFeaturesCache()
+		clearSecretNamesCache()
+	})
+	return &calls
+}
+
+func TestFindMissingSecrets(t *testing.T) {
+	existing := map[string]bool{"GITHUB_TOKEN": true, "API_KEY": true}
+	yamlContent := `
+env:
+  API_KEY: ${{ secrets.API_KEY }}
+  TOKEN: ${{ secrets.GH_AW_GITHUB_TOKEN || secrets.GITHUB_TOKEN }}
+  MISSING: ${{ secrets.MISSING_KEY }}
+  MISSING_AGAIN: ${{ secrets.MISSING_KEY }}
+  CHAIN: ${{ secrets.FIRST || secrets.SECOND }}
+  URL: ${{ vars.API_URL }}
+`
+
+	assert.Equal(t, [][]string{{"MISSING_KEY"}, {"FIRST", "SECOND"}}, findMissingSecrets(yamlContent, existing),
+		"only expressions without any existing secret should be reported, once each")
+}
+
+func TestValidateSecretsExistWarnsAboutMissingSecrets(t *testing.T) {
+	calls := stubSecretNames(t, map[string][]string{
+		"repos/octo/repo/actions/secrets":              {"API_KEY"},
+		"repos/octo/repo/actions/organization-secrets": {"org_token"},
+	}, nil)
+
+	compiler := NewCompiler()
+	compiler.SetCheckSecretsExist(true)
+	yamlContent := "a: ${{ secrets.API_KEY }}\nb: ${{ secrets.ORG_TOKEN }}\nc: ${{ secrets.TYPO_KEY }}\nd: ${{ secrets.GITHUB_TOKEN }}\n"
+
+	output := testutil.CaptureStderr(t, func() {
+		compiler.validateSecretsExist(yamlContent, "workflow.md")
+		compiler.validateSecretsExist(yamlContent, "other.md")
+	})
+
+	assert.Equal(t, 2, *calls, "secret names should be fetched once per run")
+	assert.Contains(t, output, "secret TYPO_KEY is not defined in repository octo/repo", "missing secret should be warned about")
+	assert.NotContains(t, output, "API_KEY", "repository secrets should resolve")
+	assert.NotContains(t, output, "ORG_TOKEN", "organization secrets should resolve case insensitively")
+	assert.Equal(t, 2, compiler.GetWarningCount(), "each workflow should get a warning")
+}
+
+func TestValidateSecretsExistFetchFailure(t *testing.T) {
+	calls := stubSecretNames(t, nil, errors.New("HTTP 403"))
+
+	compiler := NewCompiler()
+	compiler.SetCheckSecretsExist(true)
+
+	output := testutil.CaptureStderr(t, func() {
+		compiler.validateSecretsExist("a: ${{ secrets.API_KEY }}\n", "workflow.md")
+		compiler.validateSecretsExist("a: ${{ secrets.API_KEY }}\n", "other.md")
+	})
+
+	assert.Equal(t, 1, *calls, "a failed fetch should not be retried")
+	assert.Contains(t, output, "could not check that secrets exist in octo/repo", "fetch failure should be warned about")
+	assert.Equal(t, 1, compiler.GetWarningCount(), "fetch failure should be reported once")
+}
+
+func TestValidateSecretsExistDisabled(t *testing.T) {
+	calls := stubSecretNames(t, nil, nil)
+
+	compiler := NewCompiler()
+	output := testutil.CaptureStderr(t, func() {
+		compiler.validateSecretsExist("a: ${{ secrets.API_KEY }}\n", "workflow.md")
+	})
+
+	require.Empty(t, output, "check should be opt-in")
+	assert.Zero(t, *calls, "no API calls should be made unless enabled")
+}
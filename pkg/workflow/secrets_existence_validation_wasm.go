@@ -0,0 +1,5 @@
+//go:build js || wasm
+
+package workflow
+
+func (c *Compiler) validateSecretsExist(yamlContent string, markdownPath string) {}
@@ -2,48 +2,438 @@ package workflow
 
 import (
 	"errors"
+	"fmt"
+	"maps"
 	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/parser"
 )
 
-var secretsValidationLog = newValidationLogger("secrets")
+var secretsValidationLog = newSecretRedactingLogger(newValidationLogger("secrets"))
 
 // secretsExpressionPattern matches GitHub Actions secrets expressions for jobs.secrets validation.
-// Pattern matches: ${{ secrets.NAME }} or ${{ secrets.NAME1 || secrets.NAME2 }}
-// This is the same pattern used in the github_token schema definition ($defs/github_token).
-var secretsExpressionPattern = regexp.MustCompile(`^\$\{\{\s*secrets\.[A-Za-z_][A-Za-z0-9_]*(\s*\|\|\s*secrets\.[A-Za-z_][A-Za-z0-9_]*)*\s*\}\}$`)
+// Pattern matches: ${{ secrets.NAME }}, ${{ vars.NAME }} or OR chains of both such as
+// ${{ secrets.NAME1 || vars.NAME2 }}. The last OR operand may be a single-quoted string literal
+// used as a default, e.g. ${{ secrets.NAME || 'none' }}; literals anywhere else are rejected, as are
+// other contexts (e.g., github.token).
+// This is the same pattern used in the secrets_expression schema definition ($defs/secrets_expression).
+var secretsExpressionPattern = regexp.MustCompile(`^\$\{\{\s*(secrets|vars)\.[A-Za-z_][A-Za-z0-9_]*(\s*\|\|\s*(secrets|vars)\.[A-Za-z_][A-Za-z0-9_]*)*(\s*\|\|\s*'([^']|'')*')?\s*\}\}$`)
+
+// secretsOrEnvExpressionPattern is secretsExpressionPattern extended to env references, for values
+// that may route a token through the env context.
+// Pattern matches: ${{ env.NAME }} or ${{ secrets.NAME1 || env.NAME2 }}, in any combination with vars
+var secretsOrEnvExpressionPattern = regexp.MustCompile(`^\$\{\{\s*(secrets|vars|env)\.[A-Za-z_][A-Za-z0-9_]*(\s*\|\|\s*(secrets|vars|env)\.[A-Za-z_][A-Za-z0-9_]*)*(\s*\|\|\s*'([^']|'')*')?\s*\}\}$`)
+
+// SecretNamingPolicy controls which secret names validateSecretReferences accepts. It is set with
+// the --secret-naming flag of the compile and lint commands.
+type SecretNamingPolicy string
+
+const (
+	// SecretNamingStrict accepts environment variable style names: an uppercase letter followed
+	// by uppercase letters (A-Z), digits (0-9) and underscores. This is the default.
+	SecretNamingStrict SecretNamingPolicy = "strict"
+
+	// SecretNamingPermissive accepts any name GitHub accepts: a letter (a-z, A-Z) or underscore
+	// followed by letters, digits (0-9) and underscores
+	SecretNamingPermissive SecretNamingPolicy = "permissive"
+)
+
+// String returns the string representation of the secret naming policy
+func (p SecretNamingPolicy) String() string {
+	return string(p)
+}
+
+// IsValid checks if the secret naming policy is valid
+func (p SecretNamingPolicy) IsValid() bool {
+	return p == SecretNamingStrict || p == SecretNamingPermissive
+}
 
 // secretNamePattern validates that a secret name follows environment variable naming conventions
 var secretNamePattern = regexp.MustCompile(`^[A-Z][A-Z0-9_]*$`)
 
+// permissiveSecretNamePattern validates that a secret name is one GitHub accepts
+var permissiveSecretNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// maxSecretNameLength is the longest secret name GitHub accepts
+const maxSecretNameLength = 255
+
+// secretNameReferencePattern matches a secrets context reference inside an expression. Unlike
+// secretReferencePattern it accepts names of any shape, so that invalid names can be reported.
+var secretNameReferencePattern = regexp.MustCompile(`\bsecrets\.([A-Za-z0-9_-]+)`)
+
 // validateSecretsExpression validates that a value is a proper GitHub Actions secrets expression.
-// Returns an error if the value is not in the format: ${{ secrets.NAME }} or ${{ secrets.NAME || secrets.NAME2 }}
+// Returns an error if the value is not in the format: ${{ secrets.NAME }}, ${{ vars.NAME }} or
+// an OR chain of those such as ${{ secrets.NAME || vars.NAME2 }}, optionally ending in a
+// single-quoted default such as ${{ secrets.NAME || 'default' }}
 // Note: This function intentionally does not accept the secret key name as a parameter to prevent
 // CodeQL from detecting a data flow of sensitive information (secret key names) to logging or error outputs.
 func validateSecretsExpression(value string) error {
 	if !secretsExpressionPattern.MatchString(value) {
 		secretsValidationLog.Printf("Invalid secret expression detected")
-		return errors.New("invalid secrets expression: must be a GitHub Actions expression with secrets reference (e.g., '${{ secrets.MY_SECRET }}' or '${{ secrets.SECRET1 || secrets.SECRET2 }}')")
+		return errors.New("invalid secrets expression: must be a GitHub Actions expression with secrets. or vars. references, optionally ending in a quoted default (e.g., '${{ secrets.MY_SECRET }}', '${{ vars.MY_VARIABLE }}', '${{ secrets.SECRET1 || secrets.SECRET2 }}' or '${{ secrets.MY_SECRET || '' }}')")
 	}
 	secretsValidationLog.Printf("Valid secret expression validated")
 	return nil
 }
 
-// validateSecretReferences validates that secret references are valid
-func validateSecretReferences(secrets []string) error {
-	secretsValidationLog.Printf("Validating secret references: checking %d secrets", len(secrets))
-	// Secret names must be valid environment variable names
+// parseJobSecrets converts the secrets map of a reusable workflow job, validating that each
+// value is a secrets expression. Values that are not strings are ignored.
+func parseJobSecrets(secrets map[string]any) (map[string]string, error) {
+	jobSecrets := make(map[string]string, len(secrets))
+	for key, val := range secrets {
+		valStr, ok := val.(string)
+		if !ok {
+			// The value may itself contain secret references; the logger redacts them
+			secretsValidationLog.Printf("Ignoring jobs.secrets value of unsupported type %T: %v", val, val)
+			continue
+		}
+		// Validate that the secret value is a proper GitHub Actions expression
+		// Note: We don't pass the key to validateSecretsExpression to prevent
+		// CodeQL from detecting sensitive data flow to error messages/logs
+		if err := validateSecretsExpression(valStr); err != nil {
+			return nil, err
+		}
+		jobSecrets[key] = valStr
+	}
+	return jobSecrets, nil
+}
+
+// validateSecretsOrEnvExpression validates that a value is a GitHub Actions expression referencing
+// secrets, configuration variables, env variables, or an OR chain of those, such as
+// ${{ secrets.NAME || env.NAME2 }}.
+// It validates on.github-token, which is used in activation steps where the env context is
+// available; the env context is not available in jobs.<job_id>.secrets, which is validated with
+// validateSecretsExpression.
+// Like validateSecretsExpression, it does not accept or log the key name, to prevent CodeQL from
+// detecting a data flow of sensitive information to logging or error outputs.
+func validateSecretsOrEnvExpression(value string) error {
+	if !secretsOrEnvExpressionPattern.MatchString(value) {
+		secretsValidationLog.Printf("Invalid secret or env expression detected")
+		return errors.New("invalid secrets expression: must be a GitHub Actions expression with secrets., vars. or env. references (e.g., '${{ secrets.MY_SECRET }}', '${{ env.MY_TOKEN }}' or '${{ secrets.SECRET1 || env.TOKEN2 }}')")
+	}
+	secretsValidationLog.Printf("Valid secret or env expression validated")
+	return nil
+}
+
+// validateSecretReferences validates that secret references are valid under the given naming
+// policy; an empty policy is treated as SecretNamingStrict.
+// Every invalid name is reported, so that all of them can be fixed in one pass.
+//
+// Names referenced more than once (compared case-sensitively) are errors in strict mode. Imports
+// can legitimately re-declare a secret, so outside strict mode they are returned as warnings
+// instead. The warnings leave out the secret name, as they are printed and recorded in reports.
+func validateSecretReferences(secrets []string, policy SecretNamingPolicy, strictMode bool) ([]string, error) {
+	return validateSecretReferencesAt(secrets, nil, policy, strictMode)
+}
 
+// validateFrontmatterSecretReferences validates the secrets referenced by the frontmatter with
+// validateSecretReferences. Each invalid name is reported against the dotted frontmatter field
+// that references it, such as engine.env.API_KEY.
+func validateFrontmatterSecretReferences(frontmatter map[string]any, policy SecretNamingPolicy, strictMode bool) ([]string, error) {
+	return validateSecretReferencesAt(collectFrontmatterSecretReferences(frontmatter), frontmatterSecretFields(frontmatter), policy, strictMode)
+}
+
+// validateSecretReferencesAt implements validateSecretReferences. fields maps a secret name to
+// the field that references it; names without a field are reported against "secrets".
+func validateSecretReferencesAt(secrets []string, fields map[string]string, policy SecretNamingPolicy, strictMode bool) ([]string, error) {
+	secretsValidationLog.Printf("Validating secret references: checking %d secrets with %q naming policy", len(secrets), policy)
+
+	counts := make(map[string]int, len(secrets))
+	for _, secret := range secrets {
+		counts[secret]++
+	}
+
+	var warnings []string
+	collector := NewErrorCollector(false)
 	for _, secret := range secrets {
-		if !secretNamePattern.MatchString(secret) {
-			secretsValidationLog.Printf("Invalid secret name format: %s", secret)
+		count := counts[secret]
+		if count == 0 {
+			// Already reported
+			continue
+		}
+		counts[secret] = 0
+
+		if err := validateSecretName(secret, policy); err != nil {
+			if field, ok := fields[secret]; ok {
+				err.Field = field
+			}
+			_ = collector.Add(err)
+		}
+
+		if count > 1 {
+			secretsValidationLog.Printf("Duplicate secret reference detected (%d times)", count)
+			if strictMode {
+				_ = collector.Add(NewValidationError(
+					"secrets",
+					secret,
+					fmt.Sprintf("duplicate secret reference - secret is listed %d times", count),
+					"Remove the repeated entries so each secret is listed once. If the names were meant to differ, check them for typos.",
+				))
+			} else {
+				warnings = append(warnings, fmt.Sprintf("A secret is referenced %d times in the top-level secrets field. Remove the repeated entries so each secret is listed once; if the names were meant to differ, check them for typos.", count))
+			}
+		}
+	}
+
+	return warnings, collector.FormattedError("secret name")
+}
+
+// collectFrontmatterSecretReferences returns the secret names referenced by ${{ }} expressions
+// in the frontmatter, for validateSecretReferences. Names referenced by the values of the
+// top-level 'secrets' field come first, once per value that references them, so that a secret
+// listed more than once is reported as a duplicate. Every other referenced name follows once,
+// in sorted order.
+func collectFrontmatterSecretReferences(frontmatter map[string]any) []string {
+	var names []string
+	seen := make(map[string]bool)
+
+	if secretsMap, ok := frontmatter["secrets"].(map[string]any); ok {
+		for _, key := range slices.Sorted(maps.Keys(secretsMap)) {
+			var expr string
+			switch v := secretsMap[key].(type) {
+			case string:
+				expr = v
+			case map[string]any:
+				expr, _ = v["value"].(string)
+			}
+			for _, name := range expressionSecretNames(expr) {
+				names = append(names, name)
+				seen[name] = true
+			}
+		}
+	}
+
+	var others []string
+	var walk func(value any)
+	walk = func(value any) {
+		switch v := value.(type) {
+		case string:
+			for _, name := range expressionSecretNames(v) {
+				if !seen[name] {
+					seen[name] = true
+					others = append(others, name)
+				}
+			}
+		case map[string]any:
+			for _, item := range v {
+				walk(item)
+			}
+		case []any:
+			for _, item := range v {
+				walk(item)
+			}
+		}
+	}
+	walk(frontmatter)
+	sort.Strings(others)
+
+	return append(names, others...)
+}
+
+// frontmatterSecretFields maps each secret name referenced by a ${{ }} expression in the
+// frontmatter to the first dotted field, in sorted order, that references it. References inside
+// lists are attributed to the field holding the list, such as steps.
+func frontmatterSecretFields(frontmatter map[string]any) map[string]string {
+	fields := make(map[string]string)
+	var walk func(field string, value any)
+	walk = func(field string, value any) {
+		switch v := value.(type) {
+		case string:
+			for _, name := range expressionSecretNames(v) {
+				if _, exists := fields[name]; !exists {
+					fields[name] = field
+				}
+			}
+		case map[string]any:
+			for _, key := range slices.Sorted(maps.Keys(v)) {
+				walk(field, v[key])
+			}
+		case []any:
+			for _, item := range v {
+				walk(field, item)
+			}
+		}
+	}
+
+	flattened := flattenMap(frontmatter)
+	for _, field := range slices.Sorted(maps.Keys(flattened)) {
+		walk(field, flattened[field])
+	}
+	return fields
+}
+
+// expressionSecretNames returns the sorted, unique secret names referenced by ${{ }} expressions
+// in content, whatever their shape
+func expressionSecretNames(content string) []string {
+	unique := make(map[string]bool)
+	for _, expr := range secretExpressionBlockPattern.FindAllString(content, -1) {
+		for _, match := range secretNameReferencePattern.FindAllStringSubmatch(expr, -1) {
+			unique[match[1]] = true
+		}
+	}
+	return slices.Sorted(maps.Keys(unique))
+}
+
+// validateSecretName validates a single secret name, returning the most relevant violation
+func validateSecretName(secret string, policy SecretNamingPolicy) *WorkflowValidationError {
+	if policy == SecretNamingPermissive {
+		if !permissiveSecretNamePattern.MatchString(secret) {
+			secretsValidationLog.Print("Invalid secret name format detected")
 			return NewValidationError(
 				"secrets",
 				secret,
-				"invalid secret name format - must follow environment variable naming conventions",
-				"Secret names must:\n- Start with an uppercase letter\n- Contain only uppercase letters, numbers, and underscores\n\nExamples:\n  MY_SECRET_KEY      ✓\n  API_TOKEN_123      ✓\n  mySecretKey        ✗ (lowercase)\n  123_SECRET         ✗ (starts with number)\n  MY-SECRET          ✗ (hyphens not allowed)",
+				"invalid secret name format - GitHub secret names may only contain letters, numbers, and underscores",
+				"Secret names must:\n- Start with a letter or underscore\n- Contain only letters, numbers, and underscores\n\nExamples:\n  MY_SECRET_KEY      ✓\n  mySecretKey        ✓\n  123_SECRET         ✗ (starts with number)\n  MY-SECRET          ✗ (hyphens not allowed)",
 			)
 		}
+	} else if !secretNamePattern.MatchString(secret) {
+		// Secret names must be valid environment variable names
+		secretsValidationLog.Print("Invalid secret name format detected")
+		return NewValidationError(
+			"secrets",
+			secret,
+			"invalid secret name format - must follow environment variable naming conventions",
+			"Secret names must:\n- Start with an uppercase letter\n- Contain only uppercase letters, numbers, and underscores\n\nExamples:\n  MY_SECRET_KEY      ✓\n  API_TOKEN_123      ✓\n  mySecretKey        ✗ (lowercase)\n  123_SECRET         ✗ (starts with number)\n  MY-SECRET          ✗ (hyphens not allowed)",
+		)
 	}
 
+	if len(secret) > maxSecretNameLength {
+		secretsValidationLog.Printf("Secret name too long: %d characters", len(secret))
+		return NewValidationError(
+			"secrets",
+			secret,
+			fmt.Sprintf("secret name is too long - %d characters exceeds the maximum of %d", len(secret), maxSecretNameLength),
+			fmt.Sprintf("GitHub does not accept secret names longer than %d characters, so this secret can never be set. Use a shorter name, for example:\n  MY_SERVICE_API_KEY ✓", maxSecretNameLength),
+		)
+	}
+
+	// GitHub does not allow secrets named GITHUB_*; GITHUB_TOKEN is the automatic token
+	// that GitHub provides itself. Secret names are case insensitive.
+	if upper := strings.ToUpper(secret); strings.HasPrefix(upper, "GITHUB_") && upper != "GITHUB_TOKEN" {
+		secretsValidationLog.Print("Reserved secret name prefix detected")
+		return NewValidationError(
+			"secrets",
+			secret,
+			"reserved secret name - secret names must not start with the GITHUB_ prefix",
+			"GitHub does not allow creating secrets whose names start with GITHUB_, so this secret can never be set. Rename the secret, for example:\n  GITHUB_API_KEY     ✗ (reserved prefix)\n  GH_API_KEY         ✓\n\nThe automatic ${{ secrets.GITHUB_TOKEN }} is the only GITHUB_ secret. See https://docs.github.com/en/actions/security-for-github-actions/security-guides/using-secrets-in-github-actions#naming-your-secrets",
+		)
+	}
+
+	return nil
+}
+
+// parseSecretsExpression returns the sorted, unique secret names referenced by
+// GitHub Actions expressions in content (e.g., "${{ secrets.API_KEY || secrets.FALLBACK }}"
+// yields ["API_KEY", "FALLBACK"]). Text outside ${{ }} expressions is ignored.
+func parseSecretsExpression(content string) []string {
+	names := make([]string, 0)
+	for name := range ExtractSecretsFromValue(content) {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// collectDeclaredSecrets returns the set of secret names declared by the top-level
+// 'secrets' frontmatter field: each key plus every secret referenced by its value.
+// GITHUB_TOKEN is always considered declared. Returns nil when the workflow has no
+// top-level 'secrets' field, in which case imported fragments are not restricted.
+func collectDeclaredSecrets(frontmatter map[string]any) map[string]bool {
+	secretsMap, ok := frontmatter["secrets"].(map[string]any)
+	if !ok {
+		return nil
+	}
+
+	declared := map[string]bool{"GITHUB_TOKEN": true}
+	for key, value := range secretsMap {
+		declared[key] = true
+		var expr string
+		switch v := value.(type) {
+		case string:
+			expr = v
+		case map[string]any:
+			expr, _ = v["value"].(string)
+		}
+		for _, name := range parseSecretsExpression(expr) {
+			declared[name] = true
+		}
+	}
+	return declared
+}
+
+// validateImportedSecretsDeclared validates that every secret referenced by an imported
+// fragment is declared in the top-level 'secrets' field of the importing workflow.
+// fragment is the import path used in error messages and content is the fragment source.
+func validateImportedSecretsDeclared(fragment string, content string, declared map[string]bool) error {
+	for _, name := range parseSecretsExpression(content) {
+		if declared[name] {
+			continue
+		}
+		secretsValidationLog.Printf("Imported fragment %s references undeclared secret", fragment)
+		return NewValidationError(
+			"secrets",
+			name,
+			fmt.Sprintf("imported fragment '%s' references secret '%s', which is not declared in the top-level secrets field", fragment, name),
+			fmt.Sprintf("Declare the secret in the workflow that imports '%s':\n\nsecrets:\n  %s: ${{ secrets.%s }}", fragment, name, name),
+		)
+	}
+	return nil
+}
+
+// validateNoImportedSecretsBlock validates that an imported fragment does not declare a
+// 'secrets' field. Like triggers, secrets are only read from the top-level workflow, so a
+// secrets block in an imported fragment is ignored. fragment is the import path used in
+// error messages and content is the fragment source.
+func validateNoImportedSecretsBlock(fragment string, content string) error {
+	result, err := parser.ExtractFrontmatterFromContent(content)
+	if err != nil {
+		// Malformed frontmatter is reported by import processing
+		return nil
+	}
+	if _, hasSecrets := result.Frontmatter["secrets"]; !hasSecrets {
+		return nil
+	}
+	secretsValidationLog.Printf("Imported fragment %s declares a secrets block", fragment)
+	return NewValidationError(
+		"secrets",
+		fragment,
+		fmt.Sprintf("imported fragment '%s' declares a secrets block, which is ignored; secrets can only be declared in the top-level workflow", fragment),
+		fmt.Sprintf("Move the secrets declaration from '%s' to the frontmatter of the workflow that imports it:\n\nsecrets:\n  MY_SECRET: ${{ secrets.MY_SECRET }}", fragment),
+	)
+}
+
+// triggerExpressionPattern matches GitHub Actions expressions in the on: section
+var triggerExpressionPattern = regexp.MustCompile(`\$\{\{[^}]+\}\}`)
+
+// triggerSecretReferencePattern matches secrets context references inside an expression
+var triggerSecretReferencePattern = regexp.MustCompile(`\bsecrets\.([A-Za-z_][A-Za-z0-9_]*)`)
+
+// validateNoSecretsInTriggers validates that the on: section does not reference the
+// secrets context. GitHub Actions does not evaluate expressions in trigger filters, so a
+// secret referenced there is never resolved and the filter silently fails to match.
+// Commented-out lines are skipped: gh-aw-specific on: fields that accept secrets (e.g.,
+// github-token) are processed by the compiler and commented out of the on: section.
+func validateNoSecretsInTriggers(on string) error {
+	for line := range strings.SplitSeq(on, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "#") {
+			continue
+		}
+		for _, expr := range triggerExpressionPattern.FindAllString(line, -1) {
+			match := triggerSecretReferencePattern.FindStringSubmatch(expr)
+			if match == nil {
+				continue
+			}
+			secretsValidationLog.Printf("Secret reference found in on: section")
+			return NewValidationError(
+				"on",
+				expr,
+				fmt.Sprintf("secret '%s' is referenced in the on: section, where secrets are not available", match[1]),
+				"Remove the secret reference from the trigger configuration. Secrets are only available to jobs and steps, for example through env: or with: values.",
+			)
+		}
+	}
 	return nil
 }
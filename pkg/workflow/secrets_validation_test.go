@@ -3,8 +3,14 @@
 package workflow
 
 import (
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // TestSecretsExpressionPattern tests the regex pattern directly
@@ -25,15 +31,23 @@ func TestSecretsExpressionPattern(t *testing.T) {
 		{"many spaces", "${{   secrets.TOKEN   ||   secrets.FALLBACK   }}", true},
 		{"lowercase letters in name", "${{ secrets.myToken }}", true},
 		{"mixed case name", "${{ secrets.MyToken }}", true},
+		{"vars context", "${{ vars.TOKEN }}", true},
+		{"vars fallbacks", "${{ vars.TOKEN1 || vars.TOKEN2 }}", true},
+		{"mixed with vars", "${{ secrets.TOKEN || vars.BACKUP }}", true},
+		{"vars then secret", "${{ vars.TOKEN || secrets.BACKUP }}", true},
+		{"literal default", "${{ secrets.TOKEN || 'default' }}", true},
+		{"empty literal default", "${{ secrets.A || secrets.B || '' }}", true},
+		{"literal default with escaped quote", "${{ vars.TOKEN || 'it''s' }}", true},
 
 		// Invalid patterns
 		{"plaintext", "my-secret", false},
 		{"env context", "${{ env.TOKEN }}", false},
-		{"vars context", "${{ vars.TOKEN }}", false},
 		{"github context", "${{ github.token }}", false},
 		{"inputs context", "${{ inputs.TOKEN }}", false},
 		{"mixed contexts", "${{ secrets.TOKEN || env.FALLBACK }}", false},
-		{"mixed with vars", "${{ secrets.TOKEN || vars.BACKUP }}", false},
+		{"mixed with github", "${{ vars.TOKEN || github.token }}", false},
+		{"uppercase VARS", "${{ VARS.TOKEN }}", false},
+		{"empty vars name", "${{ vars. }}", false},
 		{"missing opening", "secrets.TOKEN }}", false},
 		{"missing closing", "${{ secrets.TOKEN", false},
 		{"number prefix", "${{ secrets.123TOKEN }}", false},
@@ -51,6 +65,13 @@ func TestSecretsExpressionPattern(t *testing.T) {
 		{"just secret context", "secrets.TOKEN", false},
 		{"partial expression", "${{ secrets", false},
 		{"dot only", "${{ secrets.TOKEN. }}", false},
+		{"literal first", "${{ 'default' || secrets.TOKEN }}", false},
+		{"literal in the middle", "${{ secrets.A || 'default' || secrets.B }}", false},
+		{"two literals", "${{ secrets.TOKEN || 'a' || 'b' }}", false},
+		{"literal only", "${{ 'default' }}", false},
+		{"double-quoted literal", "${{ secrets.TOKEN || \"default\" }}", false},
+		{"unterminated literal", "${{ secrets.TOKEN || 'default }}", false},
+		{"expression after literal", "${{ secrets.TOKEN || 'a' && github.token }}", false},
 	}
 
 	for _, tt := range tests {
@@ -100,6 +121,11 @@ func TestValidateSecretsExpressionErrorMessages(t *testing.T) {
 			value:          "bad",
 			expectedInErrs: []string{"${{ secrets.SECRET1 || secrets.SECRET2 }}"},
 		},
+		{
+			name:           "supported prefixes in error",
+			value:          "${{ github.token }}",
+			expectedInErrs: []string{"secrets. or vars. references", "${{ vars.MY_VARIABLE }}"},
+		},
 		{
 			name:              "mixed context error does NOT show value",
 			value:             "${{ secrets.TOKEN || env.FALLBACK }}",
@@ -141,7 +167,9 @@ func TestValidateSecretsExpressionWithVariousValues(t *testing.T) {
 		{"valid with fallback", "${{ secrets.TOKEN1 || secrets.TOKEN2 }}", false},
 		{"invalid plaintext", "plaintext", true},
 		{"invalid env", "${{ env.TOKEN }}", true},
-		{"invalid vars", "${{ vars.TOKEN }}", true},
+		{"valid vars", "${{ vars.TOKEN }}", false},
+		{"valid mixed with vars", "${{ secrets.TOKEN || vars.FALLBACK }}", false},
+		{"invalid github token", "${{ github.token }}", true},
 		{"invalid mixed", "${{ secrets.TOKEN || env.FALLBACK }}", true},
 		{"invalid empty", "", true},
 	}
@@ -231,3 +259,465 @@ func TestSecretsValidationEdgeCases(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateSecretsOrEnvExpression(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		wantErr bool
+	}{
+		{name: "secret only", value: "${{ secrets.TOKEN }}"},
+		{name: "env only", value: "${{ env.SOME_TOKEN }}"},
+		{name: "env fallback chain", value: "${{ env.TOKEN1 || env.TOKEN2 }}"},
+		{name: "secret then env", value: "${{ secrets.A || env.B }}"},
+		{name: "env then secret", value: "${{ env.A || secrets.B }}"},
+		{name: "long mixed chain", value: "${{  secrets.A  ||  env.B || secrets.C  }}"},
+		{name: "mixed with vars", value: "${{ env.TOKEN || vars.BACKUP }}"},
+		{name: "inputs context", value: "${{ inputs.TOKEN }}", wantErr: true},
+		{name: "mixed with inputs", value: "${{ env.TOKEN || inputs.BACKUP }}", wantErr: true},
+		{name: "github context", value: "${{ github.token }}", wantErr: true},
+		{name: "uppercase ENV", value: "${{ ENV.TOKEN }}", wantErr: true},
+		{name: "empty env name", value: "${{ env. }}", wantErr: true},
+		{name: "hyphen in env name", value: "${{ env.MY-TOKEN }}", wantErr: true},
+		{name: "text prefix", value: "Bearer ${{ env.TOKEN }}", wantErr: true},
+		{name: "plaintext", value: "ghp_1234567890abcdef", wantErr: true},
+		{name: "empty", value: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateSecretsOrEnvExpression(tt.value)
+			if !tt.wantErr {
+				assert.NoError(t, err, "expression should be accepted")
+				return
+			}
+			require.Error(t, err, "expression should be rejected")
+			assert.Contains(t, err.Error(), "invalid secrets expression", "error should be descriptive")
+			if tt.value != "" {
+				assert.NotContains(t, err.Error(), tt.value, "error should not contain the value")
+			}
+		})
+	}
+
+	// validateSecretsExpression keeps rejecting env references
+	require.Error(t, validateSecretsExpression("${{ secrets.A || env.B }}"), "jobs.secrets should still reject env references")
+}
+
+func TestValidateSecretReferencesReportsAllViolations(t *testing.T) {
+	_, err := validateSecretReferences([]string{"my-secret", "VALID_NAME", "GITHUB_FOO", strings.Repeat("A", maxSecretNameLength+1)}, SecretNamingStrict, false)
+	require.Error(t, err, "invalid secret names should be rejected")
+
+	msg := err.Error()
+	assert.Contains(t, msg, "Found 3 secret name errors", "error should count every invalid name")
+	assert.Contains(t, msg, "invalid secret name format", "format violation should be reported")
+	assert.Contains(t, msg, "GITHUB_FOO", "reserved prefix violation should be reported")
+	assert.Contains(t, msg, "secret name is too long", "length violation should be reported")
+	assert.NotContains(t, msg, "VALID_NAME", "valid names should not be reported")
+
+	_, err = validateSecretReferences([]string{"API_KEY", "my-secret"}, SecretNamingStrict, false)
+	var validationErr *WorkflowValidationError
+	require.ErrorAs(t, err, &validationErr, "a single violation should keep the validation error format")
+	assert.Equal(t, "my-secret", validationErr.Value, "the offending name should be reported")
+}
+
+func TestValidateSecretReferencesNamingPolicy(t *testing.T) {
+	tests := []struct {
+		name          string
+		secret        string
+		strictErr     bool
+		permissiveErr bool
+	}{
+		{name: "uppercase", secret: "MY_SECRET"},
+		{name: "lowercase", secret: "my_secret", strictErr: true},
+		{name: "mixed case", secret: "mySecret", strictErr: true},
+		{name: "leading underscore", secret: "_MY_SECRET", strictErr: true},
+		{name: "leading digit", secret: "1_SECRET", strictErr: true, permissiveErr: true},
+		{name: "hyphen", secret: "my-secret", strictErr: true, permissiveErr: true},
+		{name: "lowercase reserved prefix", secret: "github_foo", strictErr: true, permissiveErr: true},
+		{name: "lowercase automatic token", secret: "github_token", strictErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for policy, wantErr := range map[SecretNamingPolicy]bool{
+				SecretNamingStrict:     tt.strictErr,
+				"":                     tt.strictErr,
+				SecretNamingPermissive: tt.permissiveErr,
+			} {
+				_, err := validateSecretReferences([]string{tt.secret}, policy, false)
+				if wantErr {
+					assert.Error(t, err, "%q should be rejected by the %q policy", tt.secret, policy)
+				} else {
+					assert.NoError(t, err, "%q should be accepted by the %q policy", tt.secret, policy)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateSecretReferencesDuplicates(t *testing.T) {
+	duplicated := []string{"API_KEY", "OTHER_KEY", "API_KEY"}
+
+	warnings, err := validateSecretReferences(duplicated, SecretNamingStrict, true)
+	var validationErr *WorkflowValidationError
+	require.ErrorAs(t, err, &validationErr, "duplicate secret should be rejected in strict mode")
+	assert.Equal(t, "API_KEY", validationErr.Value, "the duplicated name should be reported")
+	assert.Contains(t, validationErr.Reason, "listed 2 times", "error should explain the collision")
+	assert.Empty(t, warnings, "strict mode should not also warn")
+
+	warnings, err = validateSecretReferences(duplicated, SecretNamingStrict, false)
+	require.NoError(t, err, "duplicate secret should only warn outside strict mode")
+	require.Len(t, warnings, 1, "duplicate should be warned about once")
+	assert.Contains(t, warnings[0], "referenced 2 times", "warning should explain the collision")
+	assert.NotContains(t, warnings[0], "API_KEY", "warning should not name the secret")
+
+	distinct := []string{"API_KEY", "api_key", "OTHER_KEY"}
+	warnings, err = validateSecretReferences(distinct, SecretNamingPermissive, true)
+	require.NoError(t, err, "names differing in case should not collide")
+	assert.Empty(t, warnings, "names differing in case should not warn")
+
+	warnings, err = validateSecretReferences([]string{"API_KEY", "OTHER_KEY"}, SecretNamingStrict, false)
+	require.NoError(t, err, "distinct secrets should be accepted")
+	assert.Empty(t, warnings, "distinct secrets should not warn")
+}
+
+func TestCompileWorkflowDuplicateSecretReferences(t *testing.T) {
+	workflowPath := writeSecretsWorkflow(t,
+		"API_KEY: ${{ secrets.SHARED_KEY }}",
+		"OTHER_KEY: ${{ secrets.SHARED_KEY }}",
+	)
+
+	compiler := NewCompiler()
+	compiler.SetSecretNamingPolicy(SecretNamingStrict)
+	err := compiler.CompileWorkflow(workflowPath)
+	require.Error(t, err, "duplicate secret reference should fail compilation in strict mode")
+	assert.Contains(t, err.Error(), "duplicate secret reference", "error should explain the collision")
+
+	content, err := os.ReadFile(workflowPath)
+	require.NoError(t, err, "should read workflow")
+	nonStrict := strings.Replace(string(content), "engine: copilot\n", "engine: copilot\nstrict: false\n", 1)
+	require.NoError(t, os.WriteFile(workflowPath, []byte(nonStrict), 0644), "should write non-strict workflow")
+
+	compiler = NewCompiler()
+	var compileErr error
+	stderr := testutil.CaptureStderr(t, func() {
+		compileErr = compiler.CompileWorkflow(workflowPath)
+	})
+	require.NoError(t, compileErr, "duplicate secret reference should only warn outside strict mode")
+
+	var messages []string
+	for _, warning := range compiler.Warnings() {
+		messages = append(messages, warning.Message)
+	}
+	assert.Contains(t, strings.Join(messages, "\n"), "A secret is referenced 2 times", "duplicate should be recorded as a compiler warning")
+	assert.NotContains(t, stderr, "SHARED_KEY", "warning output should not name the secret")
+}
+
+func TestCompilerSecretNamingPolicy(t *testing.T) {
+	c := NewCompiler()
+	assert.Equal(t, SecretNamingStrict, c.GetSecretNamingPolicy(), "policy should default to strict")
+
+	c.SetSecretNamingPolicy(SecretNamingPermissive)
+	assert.Equal(t, SecretNamingPermissive, c.GetSecretNamingPolicy(), "policy should be configurable")
+	assert.True(t, c.GetSecretNamingPolicy().IsValid(), "permissive should be a valid policy")
+	assert.False(t, SecretNamingPolicy("lenient").IsValid(), "unknown policies should be invalid")
+}
+
+func TestCollectFrontmatterSecretReferences(t *testing.T) {
+	frontmatter := map[string]any{
+		"secrets": map[string]any{
+			"B_TOKEN": "${{ secrets.SHARED || secrets.shared }}",
+			"A_TOKEN": map[string]any{"value": "${{ secrets.SHARED }}", "description": "shared"},
+		},
+		"engine": map[string]any{
+			"env": map[string]any{"KEY": "${{ secrets.ENGINE_KEY }}", "OTHER": "${{ secrets.SHARED }}"},
+		},
+		"steps": []any{map[string]any{"run": "echo ${{ secrets.my-secret }} secrets.NOT_AN_EXPRESSION"}},
+	}
+
+	assert.Equal(t,
+		[]string{"SHARED", "SHARED", "shared", "ENGINE_KEY", "my-secret"},
+		collectFrontmatterSecretReferences(frontmatter),
+		"top-level secrets values should be listed per value, followed by other references once")
+	assert.Empty(t, collectFrontmatterSecretReferences(map[string]any{"on": "push"}), "frontmatter without secrets should yield no names")
+}
+
+// writeSecretsWorkflow writes a workflow whose top-level secrets field holds the given entries
+// (one "KEY: value" per line) and returns its path
+func writeSecretsWorkflow(t *testing.T, entries ...string) string {
+	t.Helper()
+	tmpDir := testutil.TempDir(t, "secret-naming-*")
+	workflowPath := filepath.Join(tmpDir, "main.md")
+	content := `---
+on: issues
+permissions:
+  contents: read
+engine: copilot
+secrets:
+  ` + strings.Join(entries, "\n  ") + `
+---
+
+# Main
+`
+	require.NoError(t, os.WriteFile(workflowPath, []byte(content), 0644), "should write workflow")
+	return workflowPath
+}
+
+func TestFrontmatterSecretFields(t *testing.T) {
+	frontmatter := map[string]any{
+		"secrets": map[string]any{
+			"API_KEY": map[string]any{"value": "${{ secrets.SHARED }}", "description": "shared"},
+		},
+		"engine": map[string]any{
+			"env": map[string]any{"KEY": "${{ secrets.my_key || secrets.SHARED }}"},
+		},
+		"steps": []any{map[string]any{"run": "echo ${{ secrets.STEP_KEY }}"}},
+	}
+
+	assert.Equal(t, map[string]string{
+		"SHARED":   "engine.env.KEY",
+		"my_key":   "engine.env.KEY",
+		"STEP_KEY": "steps",
+	}, frontmatterSecretFields(frontmatter), "each secret should map to the first dotted field referencing it")
+}
+
+func TestValidateFrontmatterSecretReferencesNamesField(t *testing.T) {
+	frontmatter := map[string]any{
+		"engine": map[string]any{
+			"env": map[string]any{"KEY": "${{ secrets.my_key }}"},
+		},
+	}
+
+	_, err := validateFrontmatterSecretReferences(frontmatter, SecretNamingStrict, false)
+	var validationErr *WorkflowValidationError
+	require.ErrorAs(t, err, &validationErr, "invalid secret name should be rejected")
+	assert.Equal(t, "engine.env.KEY", validationErr.Field, "error should point at the nested field")
+	assert.Equal(t, "my_key", validationErr.Value, "error should name the secret")
+}
+
+func TestCompileWorkflowSecretNamingPolicy(t *testing.T) {
+	workflowPath := writeSecretsWorkflow(t, "API_KEY: ${{ secrets.my_api_key }}")
+
+	compiler := NewCompiler()
+	compiler.SetSecretNamingPolicy(SecretNamingStrict)
+	err := compiler.CompileWorkflow(workflowPath)
+	require.Error(t, err, "lowercase secret name should be rejected by the strict policy")
+	assert.Contains(t, err.Error(), "invalid secret name format", "error should explain the naming violation")
+
+	compiler = NewCompiler()
+	compiler.SetSecretNamingPolicy(SecretNamingPermissive)
+	require.NoError(t, compiler.CompileWorkflow(workflowPath), "lowercase secret name should be accepted by the permissive policy")
+}
+
+func TestCompileWorkflowWarnsOnSecretNamesWithoutPolicy(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "secret-naming-default-*")
+	workflowPath := filepath.Join(tmpDir, "main.md")
+	content := `---
+on: issues
+permissions:
+  contents: read
+engine: copilot
+env:
+  MY_KEY: ${{ secrets.my_api_key }}
+---
+
+# Main
+`
+	require.NoError(t, os.WriteFile(workflowPath, []byte(content), 0644), "should write workflow")
+
+	compiler := NewCompiler()
+	require.NoError(t, compiler.CompileWorkflow(workflowPath), "lowercase secret reference should still compile without a naming policy")
+
+	var messages []string
+	for _, warning := range compiler.Warnings() {
+		messages = append(messages, warning.Message)
+	}
+	assert.Contains(t, strings.Join(messages, "\n"), "invalid secret name format", "naming violation should be reported as a warning")
+}
+
+func TestCompileWorkflowRejectsReservedSecretNames(t *testing.T) {
+	t.Run("GITHUB_ prefix", func(t *testing.T) {
+		for _, policy := range []SecretNamingPolicy{SecretNamingStrict, SecretNamingPermissive} {
+			compiler := NewCompiler()
+			compiler.SetSecretNamingPolicy(policy)
+			err := compiler.CompileWorkflow(writeSecretsWorkflow(t, "API_KEY: ${{ secrets.GITHUB_FOO }}"))
+			require.Error(t, err, "secrets.GITHUB_FOO should fail compilation with the %q policy", policy)
+			assert.Contains(t, err.Error(), "reserved secret name", "error should explain the reserved prefix")
+			assert.Contains(t, err.Error(), "GITHUB_FOO", "error should name the secret")
+		}
+	})
+
+	t.Run("automatic token", func(t *testing.T) {
+		compiler := NewCompiler()
+		require.NoError(t, compiler.CompileWorkflow(writeSecretsWorkflow(t, "TOKEN: ${{ secrets.GITHUB_TOKEN }}")), "secrets.GITHUB_TOKEN should be accepted")
+	})
+
+	t.Run("every violation is reported", func(t *testing.T) {
+		compiler := NewCompiler()
+		compiler.SetSecretNamingPolicy(SecretNamingStrict)
+		err := compiler.CompileWorkflow(writeSecretsWorkflow(t,
+			"API_KEY: ${{ secrets.GITHUB_FOO }}",
+			"LONG_KEY: ${{ secrets."+strings.Repeat("A", maxSecretNameLength+1)+" }}",
+		))
+		require.Error(t, err, "invalid secret names should fail compilation")
+		assert.Contains(t, err.Error(), "Found 2 secret name errors", "error should count every invalid name")
+		assert.Contains(t, err.Error(), "secret name is too long", "length violation should be reported")
+	})
+}
+
+func TestParseSecretsExpression(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected []string
+	}{
+		{"no expressions", "plain text mentioning secrets.TOKEN", []string{}},
+		{"single secret", "${{ secrets.API_KEY }}", []string{"API_KEY"}},
+		{"fallback chain", "${{ secrets.PRIMARY || secrets.FALLBACK }}", []string{"FALLBACK", "PRIMARY"}},
+		{"multiple expressions deduplicated", "a: ${{ secrets.B }}\nb: ${{ secrets.A }}\nc: ${{ secrets.B }}", []string{"A", "B"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, parseSecretsExpression(tt.content), "secret names should match")
+		})
+	}
+}
+
+func TestValidateImportedSecretsDeclared(t *testing.T) {
+	frontmatter := map[string]any{
+		"secrets": map[string]any{
+			"API_TOKEN": "${{ secrets.API_TOKEN }}",
+			"DB":        map[string]any{"value": "${{ secrets.DATABASE_URL }}", "description": "db"},
+		},
+	}
+	declared := collectDeclaredSecrets(frontmatter)
+
+	t.Run("no top-level secrets field", func(t *testing.T) {
+		assert.Nil(t, collectDeclaredSecrets(map[string]any{"on": "push"}), "declared set should be nil without a secrets field")
+	})
+
+	t.Run("declared secrets are accepted", func(t *testing.T) {
+		content := "env:\n  A: ${{ secrets.API_TOKEN }}\n  B: ${{ secrets.DATABASE_URL }}\n  C: ${{ secrets.GITHUB_TOKEN }}"
+		assert.NoError(t, validateImportedSecretsDeclared("shared/tool.md", content, declared), "declared secrets should be accepted")
+	})
+
+	t.Run("undeclared secret is rejected", func(t *testing.T) {
+		content := "env:\n  KEY: ${{ secrets.OTHER_KEY }}"
+		err := validateImportedSecretsDeclared("shared/tool.md", content, declared)
+		require.Error(t, err, "undeclared secret should be rejected")
+		var validationErr *WorkflowValidationError
+		require.ErrorAs(t, err, &validationErr, "error should be a validation error")
+		assert.Equal(t, "OTHER_KEY", validationErr.Value, "error should name the undeclared secret")
+		assert.Contains(t, err.Error(), "shared/tool.md", "error should name the fragment")
+	})
+}
+
+func TestValidateNoImportedSecretsBlock(t *testing.T) {
+	t.Run("fragment without secrets block", func(t *testing.T) {
+		content := "---\ntools:\n  github:\n---\n\nUse ${{ secrets.API_TOKEN }}\n"
+		assert.NoError(t, validateNoImportedSecretsBlock("shared/tool.md", content), "secret references without a secrets block should be accepted")
+	})
+
+	t.Run("fragment without frontmatter", func(t *testing.T) {
+		assert.NoError(t, validateNoImportedSecretsBlock("shared/tool.md", "# Instructions\n"), "plain markdown should be accepted")
+	})
+
+	t.Run("fragment with secrets block", func(t *testing.T) {
+		content := "---\nsecrets:\n  API_TOKEN: ${{ secrets.API_TOKEN }}\n---\n\n# Tool\n"
+		err := validateNoImportedSecretsBlock("shared/tool.md", content)
+		require.Error(t, err, "secrets block in a fragment should be reported")
+		var validationErr *WorkflowValidationError
+		require.ErrorAs(t, err, &validationErr, "error should be a validation error")
+		assert.Equal(t, "secrets", validationErr.Field, "error should reference the secrets field")
+		assert.Contains(t, err.Error(), "shared/tool.md", "error should name the fragment")
+		assert.Contains(t, err.Error(), "top-level workflow", "error should direct the user to the top-level workflow")
+	})
+}
+
+func TestCompileWorkflowWarnsOnImportedSecretsBlock(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "imported-secrets-*")
+	sharedDir := filepath.Join(tmpDir, "shared")
+	require.NoError(t, os.MkdirAll(sharedDir, 0755), "should create shared dir")
+	shared := `---
+secrets:
+  API_TOKEN: ${{ secrets.API_TOKEN }}
+---
+
+Use the API token.
+`
+	require.NoError(t, os.WriteFile(filepath.Join(sharedDir, "tool.md"), []byte(shared), 0644), "should write shared fragment")
+
+	workflowPath := filepath.Join(tmpDir, "main.md")
+	content := `---
+on: issues
+permissions:
+  contents: read
+engine: copilot
+imports:
+  - shared/tool.md
+---
+
+# Main
+`
+	require.NoError(t, os.WriteFile(workflowPath, []byte(content), 0644), "should write workflow")
+
+	compiler := NewCompiler()
+	require.NoError(t, compiler.CompileWorkflow(workflowPath), "secrets block in a fragment should only warn")
+
+	var messages []string
+	for _, warning := range compiler.Warnings() {
+		messages = append(messages, warning.Message)
+	}
+	assert.Contains(t, strings.Join(messages, "\n"), "declares a secrets block", "compiler should warn about the ignored secrets block")
+}
+
+func TestValidateNoSecretsInTriggers(t *testing.T) {
+	tests := []struct {
+		name    string
+		on      string
+		wantErr string
+	}{
+		{
+			name: "no expressions",
+			on:   "on:\n  issues:\n    types: [opened]",
+		},
+		{
+			name: "non-secret expression",
+			on:   "on:\n  push:\n    branches: [\"${{ github.event.repository.default_branch }}\"]",
+		},
+		{
+			name: "workflow_call secrets declaration",
+			on:   "on:\n  workflow_call:\n    secrets:\n      API_KEY:\n        required: true",
+		},
+		{
+			name: "commented-out github-token",
+			on:   "on:\n  issue_comment:\n    types: [created]\n  # github-token: ${{ secrets.MY_TOKEN }} # Token processed by gh-aw",
+		},
+		{
+			name:    "secret in branch filter",
+			on:      "on:\n  push:\n    branches: [\"${{ secrets.RELEASE_BRANCH }}\"]",
+			wantErr: "secret 'RELEASE_BRANCH'",
+		},
+		{
+			name:    "secret in sub-expression",
+			on:      "on:\n  workflow_dispatch:\n    inputs:\n      target:\n        default: ${{ github.actor || secrets.default_target }}",
+			wantErr: "secret 'default_target'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateNoSecretsInTriggers(tt.on)
+			if tt.wantErr == "" {
+				assert.NoError(t, err, "triggers should be accepted")
+				return
+			}
+			require.Error(t, err, "secret reference in triggers should be rejected")
+			var validationErr *WorkflowValidationError
+			require.ErrorAs(t, err, &validationErr, "error should be a validation error")
+			assert.Equal(t, "on", validationErr.Field, "error should reference the on field")
+			assert.Contains(t, err.Error(), tt.wantErr, "error should name the secret")
+		})
+	}
+}
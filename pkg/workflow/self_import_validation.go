@@ -0,0 +1,84 @@
+// This file provides validation for workflows that import themselves.
+//
+// # Self-Import Validation
+//
+// A workflow that lists its own file in the imports field creates an import cycle.
+// The general cycle detector in the parser handles transitive cycles, but the
+// direct self-import case is a common copy-paste mistake (e.g., copying the
+// imports block from a shared workflow into the workflow it was shared from), so
+// it is detected early with a targeted error message before import processing.
+//
+// Only local imports are checked. Workflowspec imports (owner/repo/path@ref),
+// repository-only imports, and builtin imports can never resolve to the
+// workflow file being compiled.
+//
+// For general validation, see validation.go.
+// For detailed documentation, see scratchpad/validation-architecture.md
+
+package workflow
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/parser"
+)
+
+var selfImportValidationLog = newValidationLogger("self_import")
+
+// validateNoSelfImport returns a validation error when the imports field of the
+// frontmatter references the workflow file itself. markdownDir is the directory used
+// to resolve local imports and workflowPath is the path of the workflow being compiled.
+func validateNoSelfImport(frontmatter map[string]any, markdownDir, workflowPath string) error {
+	importsField, exists := frontmatter["imports"]
+	if !exists {
+		return nil
+	}
+
+	workflowPath = filepath.Clean(workflowPath)
+	for _, importPath := range collectImportPaths(importsField) {
+		// Strip section references (e.g., "self.md#Section")
+		filePath, _, _ := strings.Cut(importPath, "#")
+		if filePath == "" || strings.HasPrefix(filePath, parser.BuiltinPathPrefix) {
+			continue
+		}
+
+		if filepath.Clean(filepath.Join(markdownDir, filePath)) == workflowPath {
+			selfImportValidationLog.Printf("Workflow imports itself: %s", importPath)
+			return NewValidationError(
+				"imports",
+				importPath,
+				fmt.Sprintf("workflow '%s' imports itself, which creates an import cycle", filepath.Base(workflowPath)),
+				"Remove this entry from the imports list. A workflow cannot import its own file; import a shared workflow component instead.",
+			)
+		}
+	}
+
+	return nil
+}
+
+// collectImportPaths extracts the import path strings from an imports field value.
+// Entries may be plain strings or objects with a 'path' field; entries of any other
+// shape are skipped so the parser can report its own error for them.
+func collectImportPaths(importsField any) []string {
+	var paths []string
+	switch v := importsField.(type) {
+	case []any:
+		for _, item := range v {
+			switch importItem := item.(type) {
+			case string:
+				paths = append(paths, importItem)
+			case map[string]any:
+				if pathStr, ok := importItem["path"].(string); ok {
+					paths = append(paths, pathStr)
+				}
+			}
+		}
+	case []string:
+		paths = append(paths, v...)
+	case string:
+		paths = append(paths, v)
+	}
+	return paths
+}
@@ -0,0 +1,105 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateNoSelfImport(t *testing.T) {
+	dir := filepath.Join("repo", ".github", "workflows")
+	workflowPath := filepath.Join(dir, "self.md")
+
+	tests := []struct {
+		name        string
+		frontmatter map[string]any
+		wantErr     bool
+	}{
+		{
+			name:        "no imports",
+			frontmatter: map[string]any{"on": "push"},
+		},
+		{
+			name:        "unrelated import",
+			frontmatter: map[string]any{"imports": []any{"shared/tools.md"}},
+		},
+		{
+			name:        "direct self import",
+			frontmatter: map[string]any{"imports": []any{"self.md"}},
+			wantErr:     true,
+		},
+		{
+			name:        "self import with dot segment",
+			frontmatter: map[string]any{"imports": []any{"./self.md"}},
+			wantErr:     true,
+		},
+		{
+			name:        "self import with section reference",
+			frontmatter: map[string]any{"imports": []any{"self.md#Instructions"}},
+			wantErr:     true,
+		},
+		{
+			name: "self import in object form",
+			frontmatter: map[string]any{"imports": []any{
+				map[string]any{"path": "self.md", "inputs": map[string]any{"a": "b"}},
+			}},
+			wantErr: true,
+		},
+		{
+			name:        "self import in string slice",
+			frontmatter: map[string]any{"imports": []string{"shared/a.md", "self.md"}},
+			wantErr:     true,
+		},
+		{
+			name:        "same basename in another directory",
+			frontmatter: map[string]any{"imports": []any{"shared/self.md"}},
+		},
+		{
+			name:        "workflowspec import with same basename",
+			frontmatter: map[string]any{"imports": []any{"owner/repo/self.md@main"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateNoSelfImport(tt.frontmatter, dir, workflowPath)
+			if tt.wantErr {
+				require.Error(t, err, "self import should be rejected")
+				var validationErr *WorkflowValidationError
+				require.ErrorAs(t, err, &validationErr, "error should be a validation error")
+				assert.Equal(t, "imports", validationErr.Field, "error should reference the imports field")
+				assert.Contains(t, err.Error(), "imports itself", "error should explain the self import")
+			} else {
+				assert.NoError(t, err, "import should be accepted")
+			}
+		})
+	}
+}
+
+func TestCompileWorkflowRejectsSelfImport(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "self-import-*")
+	workflowPath := filepath.Join(tmpDir, "self.md")
+	content := `---
+on: issues
+permissions:
+  contents: read
+engine: copilot
+imports:
+  - self.md
+---
+
+# Self Import
+`
+	require.NoError(t, os.WriteFile(workflowPath, []byte(content), 0644), "should write workflow")
+
+	compiler := NewCompiler()
+	err := compiler.CompileWorkflow(workflowPath)
+	require.Error(t, err, "compiling a self-importing workflow should fail")
+	assert.Contains(t, err.Error(), "imports itself", "error should explain the self import")
+}
@@ -0,0 +1,175 @@
+// This file provides source maps from compiled lock files back to markdown workflows.
+//
+// # Source Maps
+//
+// Tools such as actionlint report problems against the generated lock file, but
+// users author the markdown workflow. A SourceMap records which markdown line
+// produced each lock file line so those locations can be translated back.
+//
+// Most of a lock file is generated boilerplate. The content that users write and
+// that ends up verbatim in the lock file (custom steps, run scripts, env values,
+// inlined prompt text) is copied line by line, typically with a different
+// indentation. Lines are therefore matched by their trimmed content: a lock line
+// maps to a markdown line when the trimmed text occurs exactly once in each file.
+// A lock line of the form "key: value" also maps to a markdown line that holds
+// only the value, since one-line block scalars are emitted in that form.
+// Lines that are too short to be distinctive, or that occur several times, are
+// left unmapped rather than guessed.
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var sourceMapLog = logger.New("workflow:source_map")
+
+// sourceMapMinLineLength is the minimum trimmed length of a line to be matched
+const sourceMapMinLineLength = 4
+
+// SourceLocation is a line and column in the markdown workflow
+type SourceLocation struct {
+	Line   int
+	Column int
+}
+
+// sourceMapEntry maps one lock file line to a markdown line
+type sourceMapEntry struct {
+	line        int // 1-based markdown line
+	indentDelta int // markdown indentation minus lock file indentation
+}
+
+// SourceMap maps lines of a compiled lock file to the markdown workflow that produced them
+type SourceMap struct {
+	MarkdownPath string
+	lines        map[int]sourceMapEntry // keyed by 1-based lock file line
+}
+
+// BuildSourceMap builds the source map between the markdown workflow content and the
+// lock file content generated from it.
+func BuildSourceMap(markdownPath, markdownContent, lockContent string) *SourceMap {
+	sourceMap := &SourceMap{MarkdownPath: markdownPath, lines: make(map[int]sourceMapEntry)}
+
+	markdownLines := uniqueSourceLines(markdownContent)
+	lockLines := uniqueSourceLines(lockContent)
+	for text, lockLine := range lockLines {
+		if markdownLine, ok := markdownLines[text]; ok {
+			sourceMap.lines[lockLine.number] = sourceMapEntry{
+				line:        markdownLine.number,
+				indentDelta: markdownLine.indent - lockLine.indent,
+			}
+			continue
+		}
+
+		// Single-line block scalars (e.g. a one-line "run: |" script) are emitted
+		// as "key: value", so also match the value on its own
+		if offset, value, ok := sourceMapScalarValue(text); ok {
+			if markdownLine, ok := markdownLines[value]; ok {
+				sourceMap.lines[lockLine.number] = sourceMapEntry{
+					line:        markdownLine.number,
+					indentDelta: markdownLine.indent - (lockLine.indent + offset),
+				}
+			}
+		}
+	}
+
+	sourceMapLog.Printf("Built source map for %s: %d of %d lock line(s) mapped", markdownPath, len(sourceMap.lines), strings.Count(lockContent, "\n")+1)
+	return sourceMap
+}
+
+// Lookup translates a 1-based lock file line and column into the markdown location
+// that produced it. It returns false when the line is generated boilerplate.
+func (m *SourceMap) Lookup(line, column int) (SourceLocation, bool) {
+	if m == nil {
+		return SourceLocation{}, false
+	}
+	entry, ok := m.lines[line]
+	if !ok {
+		return SourceLocation{}, false
+	}
+
+	// Columns shift with the indentation; columns inside the indentation map to the line start
+	column = max(column+entry.indentDelta, 1)
+	return SourceLocation{Line: entry.line, Column: column}, true
+}
+
+// SourceMap returns the source map recorded for the lock file at lockFile, or nil when
+// none was recorded (source maps are disabled, or the workflow was not compiled)
+func (c *Compiler) SourceMap(lockFile string) *SourceMap {
+	return c.sourceMaps[filepath.Clean(lockFile)]
+}
+
+// recordSourceMap builds and stores the source map for a compiled lock file
+func (c *Compiler) recordSourceMap(markdownPath, lockFile, lockContent string) {
+	markdownContent := c.contentOverride
+	if markdownContent == "" {
+		content, err := os.ReadFile(markdownPath)
+		if err != nil {
+			sourceMapLog.Printf("Skipping source map for %s: %v", markdownPath, err)
+			return
+		}
+		markdownContent = string(content)
+	}
+
+	if c.sourceMaps == nil {
+		c.sourceMaps = make(map[string]*SourceMap)
+	}
+	c.sourceMaps[filepath.Clean(lockFile)] = BuildSourceMap(markdownPath, markdownContent, lockContent)
+}
+
+// sourceLine is a distinctive line in a source map input
+type sourceLine struct {
+	number int // 1-based line number
+	indent int // leading whitespace, in bytes
+}
+
+// uniqueSourceLines indexes the distinctive lines of content by their trimmed text.
+// Lines that occur more than once are dropped, since they cannot be mapped unambiguously.
+func uniqueSourceLines(content string) map[string]sourceLine {
+	lines := make(map[string]sourceLine)
+	duplicates := make(map[string]bool)
+	for i, line := range strings.Split(content, "\n") {
+		text := strings.TrimSpace(line)
+		if !isDistinctiveSourceLine(text) || duplicates[text] {
+			continue
+		}
+		if _, seen := lines[text]; seen {
+			delete(lines, text)
+			duplicates[text] = true
+			continue
+		}
+		lines[text] = sourceLine{number: i + 1, indent: len(line) - len(strings.TrimLeft(line, " \t"))}
+	}
+	return lines
+}
+
+// sourceMapScalarKeyPattern matches the key of a "key: value" YAML line, optionally as a list item
+var sourceMapScalarKeyPattern = regexp.MustCompile(`^(- )?[A-Za-z0-9_.-]+: `)
+
+// sourceMapScalarValue splits a trimmed "key: value" YAML line and returns the offset of
+// the value within the line and the value itself
+func sourceMapScalarValue(text string) (int, string, bool) {
+	prefix := sourceMapScalarKeyPattern.FindString(text)
+	if prefix == "" {
+		return 0, "", false
+	}
+	value := text[len(prefix):]
+	if !isDistinctiveSourceLine(value) {
+		return 0, "", false
+	}
+	return len(prefix), value, true
+}
+
+// isDistinctiveSourceLine reports whether a trimmed line carries enough content to be
+// matched, which excludes short lines and lines made of punctuation only (e.g. "---")
+func isDistinctiveSourceLine(text string) bool {
+	return len(text) >= sourceMapMinLineLength && strings.IndexFunc(text, func(r rune) bool {
+		return unicode.IsLetter(r) || unicode.IsDigit(r)
+	}) >= 0
+}
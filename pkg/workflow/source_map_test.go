@@ -0,0 +1,131 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildSourceMap(t *testing.T) {
+	markdown := `---
+on: push
+steps:
+  - name: Prepare data
+    run: |
+      echo "Preparing data"
+      echo "done"
+      echo "done"
+  - name: Single line
+    run: |
+      ls "$RUNNER_TEMP/data"
+---
+# Prompt
+`
+	lock := `name: "Prompt"
+jobs:
+  agent:
+    steps:
+      - name: Prepare data
+        run: |
+          echo "Preparing data"
+          echo "done"
+          echo "done"
+      - name: Boilerplate
+        run: |
+          echo "generated"
+      - name: Single line
+        run: ls "$RUNNER_TEMP/data"
+`
+
+	sourceMap := BuildSourceMap("workflow.md", markdown, lock)
+	assert.Equal(t, "workflow.md", sourceMap.MarkdownPath, "source map should record the markdown path")
+
+	location, ok := sourceMap.Lookup(5, 9)
+	require.True(t, ok, "step name should be mapped")
+	assert.Equal(t, SourceLocation{Line: 4, Column: 5}, location, "column should shift with the indentation")
+
+	location, ok = sourceMap.Lookup(7, 16)
+	require.True(t, ok, "run script line should be mapped")
+	assert.Equal(t, SourceLocation{Line: 6, Column: 12}, location, "run script line should map to its markdown line")
+
+	location, ok = sourceMap.Lookup(7, 2)
+	require.True(t, ok, "run script line should be mapped")
+	assert.Equal(t, 1, location.Column, "columns inside the indentation should map to the line start")
+
+	_, ok = sourceMap.Lookup(8, 11)
+	assert.False(t, ok, "lines that occur more than once should not be mapped")
+
+	_, ok = sourceMap.Lookup(6, 9)
+	assert.False(t, ok, "lines that occur in several places of the lock file should not be mapped")
+
+	_, ok = sourceMap.Lookup(12, 11)
+	assert.False(t, ok, "generated lines should not be mapped")
+
+	location, ok = sourceMap.Lookup(14, 14)
+	require.True(t, ok, "one-line run script should be mapped")
+	assert.Equal(t, SourceLocation{Line: 11, Column: 7}, location, "one-line run script should map to the script line")
+
+	_, ok = (*SourceMap)(nil).Lookup(1, 1)
+	assert.False(t, ok, "nil source map should not map lines")
+}
+
+func TestIsDistinctiveSourceLine(t *testing.T) {
+	assert.True(t, isDistinctiveSourceLine("echo hi"), "commands should be distinctive")
+	assert.False(t, isDistinctiveSourceLine("fi"), "short lines should not be distinctive")
+	assert.False(t, isDistinctiveSourceLine("----"), "punctuation should not be distinctive")
+}
+
+func TestCompilerRecordsSourceMap(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "source-map-test")
+	content := `---
+on:
+  issues:
+    types: [opened]
+permissions:
+  contents: read
+engine: copilot
+strict: false
+steps:
+  - name: Prepare source map data
+    run: |
+      ls "$RUNNER_TEMP/source-map-data"
+---
+
+# Source Map Test
+`
+	markdownPath := filepath.Join(tmpDir, "source-map.md")
+	require.NoError(t, os.WriteFile(markdownPath, []byte(content), 0644), "should write workflow")
+
+	compiler := NewCompiler()
+	lockFile := compiler.LockFilePath(markdownPath)
+
+	require.NoError(t, compiler.CompileWorkflow(markdownPath), "should compile without source maps")
+	assert.Nil(t, compiler.SourceMap(lockFile), "source maps should be disabled by default")
+
+	compiler.SetBuildSourceMaps(true)
+	require.NoError(t, compiler.CompileWorkflow(markdownPath), "should compile with source maps")
+	sourceMap := compiler.SourceMap(lockFile)
+	require.NotNil(t, sourceMap, "source map should be recorded for the lock file")
+
+	lockContent, err := os.ReadFile(lockFile)
+	require.NoError(t, err, "should read lock file")
+	lockLine := 0
+	for i, line := range strings.Split(string(lockContent), "\n") {
+		if strings.Contains(line, "source-map-data") {
+			lockLine = i + 1
+			break
+		}
+	}
+	require.NotZero(t, lockLine, "lock file should contain the run script")
+
+	location, ok := sourceMap.Lookup(lockLine, 1)
+	require.True(t, ok, "run script should be mapped to the markdown")
+	assert.Equal(t, 12, location.Line, "run script should map to its markdown line")
+}
@@ -7,8 +7,6 @@ import (
 	"strings"
 	"time"
 
-	"github.com/github/gh-aw/pkg/stringutil"
-
 	"github.com/github/gh-aw/pkg/console"
 	"github.com/github/gh-aw/pkg/logger"
 )
@@ -64,7 +62,7 @@ func (c *Compiler) processStopAfterConfiguration(frontmatter map[string]any, wor
 	if workflowData.StopTime != "" {
 		stopAfterLog.Printf("Stop-after value specified: %s", workflowData.StopTime)
 		// Check if there's already a lock file with a stop time (recompilation case)
-		lockFile := stringutil.MarkdownToLockFile(markdownPath)
+		lockFile := c.LockFilePath(markdownPath)
 		existingStopTime := ExtractStopTimeFromLockFile(lockFile)
 
 		// If refresh flag is set, always regenerate the stop time
@@ -42,11 +42,9 @@ package workflow
 import (
 	"errors"
 	"fmt"
-	"os"
 	"slices"
 	"strings"
 
-	"github.com/github/gh-aw/pkg/console"
 	"github.com/github/gh-aw/pkg/parser"
 )
 
@@ -357,8 +355,7 @@ func (c *Compiler) validateEnvSecretsSection(config map[string]any, sectionName
 
 	// In non-strict mode, emit a warning
 	warningMsg := fmt.Sprintf("Warning: secrets detected in '%s' section will be leaked to the agent container. Found: %s. Consider using engine-specific secret configuration instead.", sectionName, strings.Join(secretRefs, ", "))
-	fmt.Fprintln(os.Stderr, console.FormatWarningMessage(warningMsg))
-	c.IncrementWarningCount()
+	c.emitWarning(warningMsg)
 
 	return nil
 }
@@ -502,9 +499,8 @@ func (c *Compiler) validateStrictFirewall(engineID string, networkPermissions *N
 
 			warningMsg := "strict mode: recommend using ecosystem identifiers instead of individual domain names for better maintainability: " + strings.Join(suggestions, ", ")
 
-			// Print warning message and increment warning count
-			fmt.Fprintln(os.Stderr, console.FormatWarningMessage(warningMsg))
-			c.IncrementWarningCount()
+			// Print warning message and record it as a compiler warning
+			c.emitWarning(warningMsg)
 		}
 	}
 
@@ -72,16 +72,12 @@ func (c *Compiler) applyDefaults(data *WorkflowData, markdownPath string) error
 				maps.Copy(commandEventsMap, data.CommandOtherEvents)
 			}
 
-			// Convert merged events to YAML
-			mergedEventsYAML, err := yaml.Marshal(map[string]any{"on": commandEventsMap})
-			if err == nil {
-				yamlStr := strings.TrimSuffix(string(mergedEventsYAML), "\n")
-				// Post-process YAML to ensure cron expressions are quoted
-				yamlStr = parser.QuoteCronExpressions(yamlStr)
+			// Render the merged events as a canonical on section (sorted events, quoted
+			// cron expressions, "on" quoted as it's a YAML boolean keyword)
+			if yamlStr := TriggerSet(commandEventsMap).ToYAML(); yamlStr != "" {
 				// Apply comment processing to filter fields (draft, forks, names)
 				// Pass empty frontmatter since this is for command triggers
 				yamlStr = c.commentOutProcessedFieldsInOnSection(yamlStr, map[string]any{})
-				// Keep "on" quoted as it's a YAML boolean keyword
 				data.On = yamlStr
 			} else {
 				// If conversion fails, build a basic YAML string manually
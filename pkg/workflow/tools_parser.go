@@ -53,7 +53,6 @@ package workflow
 import (
 	"fmt"
 	"maps"
-	"strconv"
 
 	"github.com/github/gh-aw/pkg/logger"
 )
@@ -299,29 +298,10 @@ func parsePlaywrightTool(val any) *PlaywrightToolConfig {
 		config := &PlaywrightToolConfig{}
 
 		// Handle version field - can be string or number
-		if version, ok := configMap["version"].(string); ok {
-			config.Version = version
-		} else if versionNum, ok := configMap["version"].(int); ok {
-			config.Version = strconv.Itoa(versionNum)
-		} else if versionNum, ok := configMap["version"].(int64); ok {
-			config.Version = strconv.FormatInt(versionNum, 10)
-		} else if versionNum, ok := configMap["version"].(float64); ok {
-			config.Version = fmt.Sprintf("%g", versionNum)
-		}
+		config.Version = getMapFieldAsStringCoerce(configMap, "version", "")
 
 		// Handle args field - can be []any or []string
-		if argsValue, ok := configMap["args"]; ok {
-			if arr, ok := argsValue.([]any); ok {
-				config.Args = make([]string, 0, len(arr))
-				for _, item := range arr {
-					if str, ok := item.(string); ok {
-						config.Args = append(config.Args, str)
-					}
-				}
-			} else if arr, ok := argsValue.([]string); ok {
-				config.Args = arr
-			}
-		}
+		config.Args = getMapFieldAsStringSlice(configMap, "args")
 
 		return config
 	}
@@ -492,23 +472,9 @@ func parseMCPServerConfig(val any) MCPServerConfig {
 		config.Command = command
 	}
 
-	if args, ok := configMap["args"].([]any); ok {
-		config.Args = make([]string, 0, len(args))
-		for _, arg := range args {
-			if str, ok := arg.(string); ok {
-				config.Args = append(config.Args, str)
-			}
-		}
-	}
+	config.Args = getMapFieldAsStringSlice(configMap, "args")
 
-	if env, ok := configMap["env"].(map[string]any); ok {
-		config.Env = make(map[string]string)
-		for k, v := range env {
-			if str, ok := v.(string); ok {
-				config.Env[k] = str
-			}
-		}
-	}
+	config.Env = getMapFieldAsStringMap(configMap, "env")
 
 	if mode, ok := configMap["mode"].(string); ok {
 		config.Mode = mode
@@ -524,28 +490,14 @@ func parseMCPServerConfig(val any) MCPServerConfig {
 		config.Version = fmt.Sprintf("%.0f", versionNum)
 	}
 
-	if toolsets, ok := configMap["toolsets"].([]any); ok {
-		config.Toolsets = make([]string, 0, len(toolsets))
-		for _, item := range toolsets {
-			if str, ok := item.(string); ok {
-				config.Toolsets = append(config.Toolsets, str)
-			}
-		}
-	}
+	config.Toolsets = getMapFieldAsStringSlice(configMap, "toolsets")
 
 	// Parse HTTP-specific fields
 	if url, ok := configMap["url"].(string); ok {
 		config.URL = url
 	}
 
-	if headers, ok := configMap["headers"].(map[string]any); ok {
-		config.Headers = make(map[string]string)
-		for k, v := range headers {
-			if str, ok := v.(string); ok {
-				config.Headers[k] = str
-			}
-		}
-	}
+	config.Headers = getMapFieldAsStringMap(configMap, "headers")
 
 	// Parse container-specific fields
 	if container, ok := configMap["container"].(string); ok {
@@ -556,23 +508,8 @@ func parseMCPServerConfig(val any) MCPServerConfig {
 		config.Entrypoint = entrypoint
 	}
 
-	if entrypointArgs, ok := configMap["entrypointArgs"].([]any); ok {
-		config.EntrypointArgs = make([]string, 0, len(entrypointArgs))
-		for _, arg := range entrypointArgs {
-			if str, ok := arg.(string); ok {
-				config.EntrypointArgs = append(config.EntrypointArgs, str)
-			}
-		}
-	}
-
-	if mounts, ok := configMap["mounts"].([]any); ok {
-		config.Mounts = make([]string, 0, len(mounts))
-		for _, mount := range mounts {
-			if str, ok := mount.(string); ok {
-				config.Mounts = append(config.Mounts, str)
-			}
-		}
-	}
+	config.EntrypointArgs = getMapFieldAsStringSlice(configMap, "entrypointArgs")
+	config.Mounts = getMapFieldAsStringSlice(configMap, "mounts")
 
 	// Store any unknown fields in CustomFields
 	knownFields := map[string]bool{
@@ -0,0 +1,92 @@
+package workflow
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/parser"
+	"github.com/goccy/go-yaml"
+)
+
+var triggerSetLog = logger.New("workflow:trigger_set")
+
+// TriggerSet is the set of events in a workflow's "on" section, keyed by event name.
+// Each value is the event configuration (e.g. branches, types, or a cron list), or nil
+// for events without configuration such as a bare workflow_dispatch.
+type TriggerSet map[string]any
+
+// ParseTriggerSet parses a rendered "on" section (e.g. WorkflowData.On) into a TriggerSet.
+// The string form ("on: push"), list form ("on: [push, issues]"), and map form are accepted.
+func ParseTriggerSet(on string) (TriggerSet, error) {
+	var section map[string]any
+	if err := yaml.Unmarshal([]byte(on), &section); err != nil {
+		return nil, fmt.Errorf("failed to parse on section: %w", err)
+	}
+
+	value, exists := section["on"]
+	if !exists {
+		return nil, errors.New("on section not found")
+	}
+
+	triggers := make(TriggerSet)
+	switch v := value.(type) {
+	case string:
+		triggers[v] = nil
+	case []any:
+		for _, item := range v {
+			event, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("invalid event in on section: %v", item)
+			}
+			triggers[event] = nil
+		}
+	case map[string]any:
+		for event, config := range v {
+			triggers[event] = config
+		}
+	default:
+		return nil, fmt.Errorf("invalid on section: expected an event name, list, or map, got %T", value)
+	}
+
+	triggerSetLog.Printf("Parsed trigger set with %d events", len(triggers))
+	return triggers, nil
+}
+
+// Has reports whether any of events is in the trigger set
+func (ts TriggerSet) Has(events ...string) bool {
+	return slices.ContainsFunc(events, func(event string) bool {
+		_, exists := ts[event]
+		return exists
+	})
+}
+
+// Names returns the event names of the trigger set in sorted order
+func (ts TriggerSet) Names() []string {
+	return slices.Sorted(maps.Keys(ts))
+}
+
+// ToYAML renders the trigger set as an "on" section in canonical form: events sorted
+// alphabetically, nested keys sorted, 2-space indentation, quoted cron expressions, and
+// bare keys for events without configuration. The output matches the "on" section the
+// compiler emits for the same triggers, so merged trigger sets render deterministically.
+// An empty trigger set renders as "".
+func (ts TriggerSet) ToYAML() string {
+	if len(ts) == 0 {
+		return ""
+	}
+
+	wrappedData := yaml.MapSlice{{Key: "on", Value: OrderMapFields(ts, []string{})}}
+	yamlBytes, err := yaml.MarshalWithOptions(wrappedData, DefaultMarshalOptions...)
+	if err != nil {
+		triggerSetLog.Printf("Failed to render trigger set: %v", err)
+		return ""
+	}
+
+	yamlStr := strings.TrimSuffix(string(yamlBytes), "\n")
+	yamlStr = parser.QuoteCronExpressions(yamlStr)
+	return CleanYAMLNullValues(yamlStr)
+}
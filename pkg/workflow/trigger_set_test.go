@@ -0,0 +1,160 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTriggerSet(t *testing.T) {
+	tests := []struct {
+		name     string
+		on       string
+		expected TriggerSet
+		wantErr  bool
+	}{
+		{
+			name:     "string form",
+			on:       "on: push",
+			expected: TriggerSet{"push": nil},
+		},
+		{
+			name:     "list form",
+			on:       "on: [push, workflow_dispatch]",
+			expected: TriggerSet{"push": nil, "workflow_dispatch": nil},
+		},
+		{
+			name: "map form",
+			on:   "\"on\":\n  issues:\n    types:\n    - opened\n  workflow_dispatch:",
+			expected: TriggerSet{
+				"issues":            map[string]any{"types": []any{"opened"}},
+				"workflow_dispatch": nil,
+			},
+		},
+		{name: "missing on key", on: "name: test", wantErr: true},
+		{name: "invalid event in list", on: "on: [push, {a: b}]", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			triggers, err := ParseTriggerSet(tt.on)
+			if tt.wantErr {
+				assert.Error(t, err, "invalid on section should fail to parse")
+				return
+			}
+			require.NoError(t, err, "on section should parse")
+			assert.Equal(t, tt.expected, triggers, "parsed triggers should match")
+		})
+	}
+}
+
+func TestTriggerSetHasAndNames(t *testing.T) {
+	triggers, err := ParseTriggerSet("on:\n  workflow_dispatch:\n    inputs:\n      issues:\n        type: string\n  push:\n    branches: [main]")
+	require.NoError(t, err, "on section should parse")
+
+	assert.True(t, triggers.Has("push"), "push should be in the set")
+	assert.True(t, triggers.Has("issues", "workflow_dispatch"), "any matching event should be enough")
+	assert.False(t, triggers.Has("issues"), "input names should not be triggers")
+	assert.False(t, triggers.Has(), "no events should never match")
+	assert.Equal(t, []string{"push", "workflow_dispatch"}, triggers.Names(), "names should be sorted")
+	assert.Empty(t, TriggerSet{}.Names(), "empty set should have no names")
+}
+
+func TestTriggerSetToYAML(t *testing.T) {
+	tests := []struct {
+		name     string
+		triggers TriggerSet
+		expected string
+	}{
+		{
+			name:     "empty set",
+			triggers: TriggerSet{},
+			expected: "",
+		},
+		{
+			name: "events and nested keys sorted",
+			triggers: TriggerSet{
+				"workflow_dispatch": nil,
+				"pull_request": map[string]any{
+					"types":    []any{"opened", "synchronize"},
+					"branches": []any{"main"},
+				},
+				"issues": map[string]any{"types": []any{"opened"}},
+			},
+			expected: `"on":
+  issues:
+    types:
+    - opened
+  pull_request:
+    branches:
+    - main
+    types:
+    - opened
+    - synchronize
+  workflow_dispatch:`,
+		},
+		{
+			name: "cron expressions quoted",
+			triggers: TriggerSet{
+				"schedule": []any{map[string]any{"cron": "0 9 * * 1"}},
+			},
+			expected: `"on":
+  schedule:
+  - cron: "0 9 * * 1"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.triggers.ToYAML(), "rendered triggers should be canonical")
+		})
+	}
+}
+
+func TestTriggerSetRoundTrip(t *testing.T) {
+	on := "on:\n  workflow_dispatch:\n  push:\n    branches: [main]\n  issues:\n    types: [opened, edited]"
+
+	triggers, err := ParseTriggerSet(on)
+	require.NoError(t, err, "on section should parse")
+	rendered := triggers.ToYAML()
+
+	reparsed, err := ParseTriggerSet(rendered)
+	require.NoError(t, err, "rendered on section should parse")
+	assert.Equal(t, triggers, reparsed, "round trip should preserve triggers")
+	assert.Equal(t, rendered, reparsed.ToYAML(), "rendering should be deterministic")
+}
+
+// TestCommandTriggerOnSectionRenderedCanonically verifies that the on section merged from
+// a slash command and other events is rendered through TriggerSet.ToYAML
+func TestCommandTriggerOnSectionRenderedCanonically(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "trigger-set-command-test")
+	testContent := `---
+on:
+  slash_command:
+    name: tidy
+  workflow_dispatch:
+  schedule:
+    - cron: "0 7 * * *"
+engine: copilot
+---
+
+# Tidy
+`
+	testFile := filepath.Join(tmpDir, "tidy.md")
+	require.NoError(t, os.WriteFile(testFile, []byte(testContent), 0644), "should write workflow")
+
+	compiler := NewCompiler()
+	require.NoError(t, compiler.CompileWorkflow(testFile), "workflow should compile")
+
+	lockContent, err := os.ReadFile(filepath.Join(tmpDir, "tidy.lock.yml"))
+	require.NoError(t, err, "should read lock file")
+	lock := string(lockContent)
+	assert.Contains(t, lock, "  schedule:\n  - cron: \"0 7 * * *\"\n  workflow_dispatch:\n", "merged events should be sorted with cron quoted")
+	assert.NotContains(t, lock, "workflow_dispatch: null", "events without configuration should render as bare keys")
+}
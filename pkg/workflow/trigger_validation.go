@@ -0,0 +1,96 @@
+package workflow
+
+import (
+	"fmt"
+
+	"github.com/goccy/go-yaml/ast"
+	"github.com/goccy/go-yaml/parser"
+)
+
+var triggerValidationLog = newValidationLogger("trigger")
+
+// validateTriggerForms validates that no trigger in the on: section is declared both inline and
+// as an object key, as in the flow mapping "on: {push, push: {branches: [main]}}", where the
+// inline "push" is a key without a value. Parsing into a map silently keeps only one of the two
+// declarations, so the check runs on the syntax tree of the raw frontmatter YAML instead.
+func validateTriggerForms(frontmatterYAML string) error {
+	file, err := parser.ParseBytes([]byte(frontmatterYAML), 0)
+	if err != nil {
+		// The frontmatter was already parsed successfully; malformed YAML is reported there
+		triggerValidationLog.Printf("Skipping trigger form validation: %v", err)
+		return nil
+	}
+
+	for _, doc := range file.Docs {
+		root, ok := doc.Body.(*ast.MappingNode)
+		if !ok {
+			continue
+		}
+		for _, entry := range root.Values {
+			if key, ok := entry.Key.(*ast.StringNode); ok && key.Value == "on" {
+				return validateTriggerFormsNode(entry.Value)
+			}
+		}
+	}
+	return nil
+}
+
+// validateTriggerFormsNode checks the value of the on: key. A map entry without a value and a
+// plain list item are inline declarations; a map entry with a value, or a list item that is a
+// map, declares the trigger as a key.
+func validateTriggerFormsNode(on ast.Node) error {
+	inline := make(map[string]bool)
+	keyed := make(map[string]bool)
+	var order []string
+	record := func(event string, isInline bool) {
+		if !inline[event] && !keyed[event] {
+			order = append(order, event)
+		}
+		if isInline {
+			inline[event] = true
+		} else {
+			keyed[event] = true
+		}
+	}
+
+	switch v := on.(type) {
+	case *ast.MappingNode:
+		for _, entry := range v.Values {
+			if key, ok := entry.Key.(*ast.StringNode); ok {
+				_, isNull := entry.Value.(*ast.NullNode)
+				record(key.Value, isNull)
+			}
+		}
+	case *ast.SequenceNode:
+		for _, item := range v.Values {
+			switch node := item.(type) {
+			case *ast.StringNode:
+				record(node.Value, true)
+			case *ast.MappingNode:
+				for _, entry := range node.Values {
+					if key, ok := entry.Key.(*ast.StringNode); ok {
+						record(key.Value, false)
+					}
+				}
+			case *ast.MappingValueNode:
+				if key, ok := node.Key.(*ast.StringNode); ok {
+					record(key.Value, false)
+				}
+			}
+		}
+	}
+
+	for _, event := range order {
+		if !inline[event] || !keyed[event] {
+			continue
+		}
+		triggerValidationLog.Printf("Trigger %s declared both inline and as a key", event)
+		return NewValidationError(
+			"on",
+			event,
+			fmt.Sprintf("trigger '%s' is declared both inline and as a key (%s:) in the on: section, and only one of the declarations would apply", event, event),
+			fmt.Sprintf("Declare each trigger once. Use the map form when any trigger needs configuration:\n\non:\n  %s:\n    branches: [main]", event),
+		)
+	}
+	return nil
+}
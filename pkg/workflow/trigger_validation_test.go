@@ -0,0 +1,87 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateTriggerForms(t *testing.T) {
+	tests := []struct {
+		name        string
+		frontmatter string
+		wantErr     string
+	}{
+		{
+			name:        "inline event",
+			frontmatter: "on: push\nengine: copilot",
+		},
+		{
+			name:        "inline list",
+			frontmatter: "on: [push, workflow_dispatch]",
+		},
+		{
+			name:        "map form",
+			frontmatter: "\"on\":\n  push:\n    branches: [main]\n  workflow_dispatch:",
+		},
+		{
+			name:        "flow mapping with distinct triggers",
+			frontmatter: "on: {workflow_dispatch, push: {branches: [main]}}",
+		},
+		{
+			name:        "nested key named like a trigger",
+			frontmatter: "on:\n  issues:\n    types: [opened]\n  workflow_dispatch:\n    inputs:\n      issues:\n        type: string",
+		},
+		{
+			name:        "flow mapping declares trigger inline and as key",
+			frontmatter: "on: {push, push: {branches: [main]}}",
+			wantErr:     "trigger 'push' is declared both inline and as a key (push:)",
+		},
+		{
+			name:        "list declares trigger inline and as key",
+			frontmatter: "on:\n  - schedule\n  - schedule:\n      - cron: \"0 9 * * 1\"",
+			wantErr:     "trigger 'schedule' is declared both inline and as a key",
+		},
+		{
+			name:        "malformed YAML is left to the frontmatter parser",
+			frontmatter: "on: [push",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateTriggerForms(tt.frontmatter)
+			if tt.wantErr == "" {
+				assert.NoError(t, err, "consistent on: section should pass validation")
+				return
+			}
+			require.Error(t, err, "trigger declared in both forms should fail validation")
+			assert.Contains(t, err.Error(), tt.wantErr, "error should name the trigger")
+		})
+	}
+}
+
+func TestCompileWorkflowRejectsTriggerDeclaredInBothForms(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "trigger-forms-*")
+	workflowPath := filepath.Join(tmpDir, "main.md")
+	content := `---
+on: {push, push: {branches: [main]}}
+permissions:
+  contents: read
+engine: copilot
+---
+
+# Main
+`
+	require.NoError(t, os.WriteFile(workflowPath, []byte(content), 0644), "should write workflow")
+
+	err := NewCompiler().CompileWorkflow(workflowPath)
+	require.Error(t, err, "trigger declared inline and as a key should fail compilation")
+	assert.Contains(t, err.Error(), "trigger 'push' is declared both inline and as a key", "error should name the trigger")
+}
@@ -8,7 +8,10 @@
 //
 //   - newValidationLogger() - Creates a standardized logger for a validation domain
 //   - validateIntRange() - Validates that an integer value is within a specified range
+//   - validateFloatRange() - Validates that a floating-point value is within a specified range
 //   - validateMountStringFormat() - Parses and validates a "source:dest:mode" mount string
+//   - isEmptyOrNil() - Checks if a value is nil, an empty/whitespace-only string, a zero time,
+//     or a pointer that is nil or points to an empty or zero value
 //
 // # Design Rationale
 //
@@ -26,7 +29,9 @@ package workflow
 import (
 	"errors"
 	"fmt"
+	"reflect"
 	"strings"
+	"time"
 
 	"github.com/github/gh-aw/pkg/logger"
 )
@@ -71,6 +76,43 @@ func validateIntRange(value, min, max int, fieldName string) error {
 	return nil
 }
 
+// validateFloatRange validates that a value is within the specified inclusive range [min, max].
+// It is the floating-point counterpart of validateIntRange and returns an error with the
+// same message shape when the value is outside the range.
+func validateFloatRange(value, min, max float64, fieldName string) error {
+	if value < min || value > max {
+		return fmt.Errorf("%s must be between %g and %g, got %g",
+			fieldName, min, max, value)
+	}
+	return nil
+}
+
+// isEmptyOrNil reports whether value is nil, a string that is empty or contains only
+// whitespace, or a zero time.Time. A nil pointer is empty, and a non-nil pointer is empty
+// when the value it points to is empty by these rules or is its type's zero value (so a
+// pointer to an empty struct is empty). Non-pointer values of other types are never
+// considered empty.
+func isEmptyOrNil(value any) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return strings.TrimSpace(v) == ""
+	case time.Time:
+		return v.IsZero()
+	}
+
+	// Pointers are rare here, so only they pay for reflection
+	if rv := reflect.ValueOf(value); rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return true
+		}
+		target := rv.Elem()
+		return isEmptyOrNil(target.Interface()) || target.IsZero()
+	}
+	return false
+}
+
 // validateMountStringFormat parses a mount string and validates its basic format.
 // Expected format: "source:destination:mode" where mode is "ro" or "rw".
 // Returns (source, dest, mode, nil) on success, or ("", "", "", error) on failure.
@@ -5,6 +5,7 @@ package workflow
 import (
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/github/gh-aw/pkg/fileutil"
 	"github.com/stretchr/testify/assert"
@@ -146,6 +147,70 @@ func TestValidateIntRange(t *testing.T) {
 	}
 }
 
+// TestValidateFloatRange tests the validateFloatRange helper function with boundary values
+func TestValidateFloatRange(t *testing.T) {
+	tests := []struct {
+		name      string
+		value     float64
+		min       float64
+		max       float64
+		errorText string
+	}{
+		{name: "value at minimum", value: 0, min: 0, max: 1},
+		{name: "value at maximum", value: 1, min: 0, max: 1},
+		{name: "fractional value in range", value: 0.5, min: 0, max: 1},
+		{name: "value below minimum", value: -0.1, min: 0, max: 1, errorText: "test-field must be between 0 and 1, got -0.1"},
+		{name: "value above maximum", value: 1.5, min: 0, max: 1, errorText: "test-field must be between 0 and 1, got 1.5"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateFloatRange(tt.value, tt.min, tt.max, "test-field")
+			if tt.errorText == "" {
+				assert.NoError(t, err, "value should be within range")
+				return
+			}
+			require.Error(t, err, "value should be out of range")
+			assert.Equal(t, tt.errorText, err.Error(), "error message should describe the range")
+		})
+	}
+}
+
+// TestIsEmptyOrNil tests the isEmptyOrNil helper function
+func TestIsEmptyOrNil(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    any
+		expected bool
+	}{
+		{name: "nil", value: nil, expected: true},
+		{name: "empty string", value: "", expected: true},
+		{name: "whitespace-only string", value: " \t\n", expected: true},
+		{name: "non-empty string", value: "feature", expected: false},
+		{name: "string with surrounding whitespace", value: " feature ", expected: false},
+		{name: "zero int", value: 0, expected: false},
+		{name: "false bool", value: false, expected: false},
+		{name: "zero time", value: time.Time{}, expected: true},
+		{name: "non-zero time", value: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), expected: false},
+		{name: "nil string pointer", value: (*string)(nil), expected: true},
+		{name: "nil struct pointer", value: (*WorkflowData)(nil), expected: true},
+		{name: "pointer to empty string", value: new(string), expected: true},
+		{name: "pointer to non-empty string", value: func() *string { s := "feature"; return &s }(), expected: false},
+		{name: "pointer to zero time", value: &time.Time{}, expected: true},
+		{name: "pointer to non-zero time", value: func() *time.Time { t := time.Unix(1, 0); return &t }(), expected: false},
+		{name: "pointer to empty struct", value: &WorkflowData{}, expected: true},
+		{name: "pointer to non-empty struct", value: &WorkflowData{Name: "ci"}, expected: false},
+		{name: "pointer to zero int", value: new(int), expected: true},
+		{name: "pointer to pointer to empty string", value: func() **string { s := new(string); return &s }(), expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, isEmptyOrNil(tt.value), "isEmptyOrNil result should match")
+		})
+	}
+}
+
 // TestValidateIntRangeWithRealWorldValues tests validateIntRange with actual constraint values
 func TestValidateIntRangeWithRealWorldValues(t *testing.T) {
 	tests := []struct {
@@ -0,0 +1,60 @@
+// This file provides validation that workflow names are unique within a directory.
+//
+// # Workflow Name Validation
+//
+// Concurrency groups embed ${{ github.workflow }}, which resolves to the workflow's
+// name. Two workflows with the same name therefore share concurrency groups and can
+// cancel or queue behind each other's runs. This validation runs once per directory
+// compile, after each workflow has been compiled, and reports every duplicate name.
+//
+// For general validation, see validation.go.
+// For detailed documentation, see scratchpad/validation-architecture.md
+
+package workflow
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+var workflowNameValidationLog = newValidationLogger("workflow_name")
+
+// WorkflowNameEntry associates a workflow source file with its compiled workflow name
+type WorkflowNameEntry struct {
+	Path string // Workflow source file path
+	Name string // Workflow name (rendered as name: in the lock file)
+}
+
+// ValidateUniqueWorkflowNames validates that no two entries share the same workflow name.
+// Names are compared case-sensitively, matching ${{ github.workflow }}. Each duplicate
+// produces a validation error naming the first file that used the name and the duplicate;
+// all duplicates are joined into the returned error.
+func ValidateUniqueWorkflowNames(entries []WorkflowNameEntry) error {
+	workflowNameValidationLog.Printf("Validating uniqueness of %d workflow names", len(entries))
+
+	firstByName := make(map[string]string, len(entries))
+	var errs []error
+	for _, entry := range entries {
+		name := strings.TrimSpace(entry.Name)
+		if name == "" {
+			continue
+		}
+		first, exists := firstByName[name]
+		if !exists {
+			firstByName[name] = entry.Path
+			continue
+		}
+
+		workflowNameValidationLog.Printf("Duplicate workflow name %q in %s and %s", name, first, entry.Path)
+		errs = append(errs, NewValidationError(
+			"name",
+			name,
+			fmt.Sprintf("workflows '%s' and '%s' have the same name, so they share concurrency groups and can cancel each other's runs", filepath.Base(first), filepath.Base(entry.Path)),
+			fmt.Sprintf("Give each workflow a unique name: field. Example in %s:\n\nname: %s (2)", filepath.Base(entry.Path), name),
+		))
+	}
+
+	return errors.Join(errs...)
+}
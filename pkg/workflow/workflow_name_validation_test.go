@@ -0,0 +1,45 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateUniqueWorkflowNames(t *testing.T) {
+	t.Run("unique names", func(t *testing.T) {
+		err := ValidateUniqueWorkflowNames([]WorkflowNameEntry{
+			{Path: "wf/a.md", Name: "Triage"},
+			{Path: "wf/b.md", Name: "Release"},
+			{Path: "wf/c.md", Name: ""},
+			{Path: "wf/d.md", Name: ""},
+		})
+		assert.NoError(t, err, "unique and empty names should be accepted")
+	})
+
+	t.Run("duplicate names name both files", func(t *testing.T) {
+		err := ValidateUniqueWorkflowNames([]WorkflowNameEntry{
+			{Path: "wf/a.md", Name: "Triage"},
+			{Path: "wf/b.md", Name: "Release"},
+			{Path: "wf/c.md", Name: "Triage"},
+		})
+		require.Error(t, err, "duplicate names should be rejected")
+		var validationErr *WorkflowValidationError
+		require.ErrorAs(t, err, &validationErr, "error should be a validation error")
+		assert.Equal(t, "Triage", validationErr.Value, "error should include the duplicate name")
+		assert.Contains(t, err.Error(), "a.md", "error should name the first file")
+		assert.Contains(t, err.Error(), "c.md", "error should name the duplicate file")
+		assert.NotContains(t, err.Error(), "b.md", "error should not name unrelated files")
+	})
+
+	t.Run("names are case-sensitive", func(t *testing.T) {
+		err := ValidateUniqueWorkflowNames([]WorkflowNameEntry{
+			{Path: "wf/a.md", Name: "Triage"},
+			{Path: "wf/b.md", Name: "triage"},
+		})
+		assert.NoError(t, err, "names differing only in case are distinct workflow names")
+	})
+}